@@ -3,11 +3,57 @@ package cmd
 import (
 	"fmt"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"net/http"
 )
 
 func AddErrorHelp(err error) error {
 	if client.IsAuthFailed(err) {
 		return fmt.Errorf("%w; please authenticate with the 'auth login' command first", err)
 	}
+	if apiError, ok := client.AsETradeAPIError(err); ok {
+		if hint := eTradeAPIErrorHint(apiError); hint != "" {
+			return fmt.Errorf("%w; %s", err, hint)
+		}
+	}
 	return err
 }
+
+// ETrade error codes that have hints. These come from the error messages in
+// ETrade's Accounts and Order API documentation.
+const (
+	eTradeErrorCodeInvalidAccountKey = 100
+	eTradeErrorCodePreviewExpired    = 101
+	eTradeErrorCodePreviewMismatch   = 1037
+	eTradeErrorCodeInsufficientFunds = 1508
+)
+
+// eTradeAPIErrorCodeHints maps ETrade error codes to hints. A hint for an
+// error's code takes precedence over a hint for its HTTP status.
+var eTradeAPIErrorCodeHints = map[int]string{
+	eTradeErrorCodeInvalidAccountKey: "check the account ID with the 'accounts list' command",
+	eTradeErrorCodePreviewExpired: "the order's preview has expired or doesn't match the order; " +
+		"preview the order again before placing it",
+	eTradeErrorCodePreviewMismatch: "the order's preview has expired or doesn't match the order; " +
+		"preview the order again before placing it",
+	eTradeErrorCodeInsufficientFunds: "the account doesn't have enough buying power for the order; " +
+		"check the account's balance with the 'accounts balances' command",
+}
+
+func eTradeAPIErrorHint(apiError *client.ETradeAPIError) string {
+	if hint, ok := eTradeAPIErrorCodeHints[apiError.Code]; ok {
+		return hint
+	}
+	switch {
+	case apiError.StatusCode == http.StatusTooManyRequests:
+		return "ETrade is limiting the rate of requests; wait and try again, or set customerRateLimits in the " +
+			"configuration to stay under ETrade's quotas"
+	case apiError.StatusCode >= http.StatusInternalServerError:
+		return "ETrade may be having problems; try again later"
+	case apiError.StatusCode == http.StatusNotFound:
+		return "check that the account, order, alert, or transaction ID is correct"
+	case apiError.StatusCode == http.StatusBadRequest && apiError.Message == "":
+		return "ETrade rejected the request without an explanation; run with --debug to see the full response"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAddErrorHelp(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		expectError string
+	}{
+		{
+			name:        "Auth Failure",
+			err:         client.ErrETradeAuthFailed,
+			expectError: "authentication failed; please authenticate with the 'auth login' command first",
+		},
+		{
+			name: "Rate Limited",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests",
+			},
+			expectError: "request failed: 429 Too Many Requests; ETrade is limiting the rate of requests; wait " +
+				"and try again, or set customerRateLimits in the configuration to stay under ETrade's quotas",
+		},
+		{
+			name: "Server Error",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway",
+			},
+			expectError: "request failed: 502 Bad Gateway; ETrade may be having problems; try again later",
+		},
+		{
+			name: "Bad Request With Unknown Code And Message Has No Hint",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Code: 10033, Message: "Test error",
+			},
+			expectError: "request failed: 400 Bad Request (ETrade error 10033: Test error)",
+		},
+		{
+			name: "Invalid Account Key",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Code: eTradeErrorCodeInvalidAccountKey,
+			},
+			expectError: "request failed: 400 Bad Request (ETrade error 100); check the account ID with the " +
+				"'accounts list' command",
+		},
+		{
+			name: "Expired Preview",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Code: eTradeErrorCodePreviewExpired,
+				Message: "Test error",
+			},
+			expectError: "request failed: 400 Bad Request (ETrade error 101: Test error); the order's preview has " +
+				"expired or doesn't match the order; preview the order again before placing it",
+		},
+		{
+			name: "Mismatched Preview",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Code: eTradeErrorCodePreviewMismatch,
+				Message: "Test error",
+			},
+			expectError: "request failed: 400 Bad Request (ETrade error 1037: Test error); the order's preview has " +
+				"expired or doesn't match the order; preview the order again before placing it",
+		},
+		{
+			name: "Insufficient Funds",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Code: eTradeErrorCodeInsufficientFunds,
+				Message: "Test error",
+			},
+			expectError: "request failed: 400 Bad Request (ETrade error 1508: Test error); the account doesn't " +
+				"have enough buying power for the order; check the account's balance with the 'accounts " +
+				"balances' command",
+		},
+		{
+			name: "Code Hint Takes Precedence Over Status Hint",
+			err: &client.ETradeAPIError{
+				StatusCode: http.StatusNotFound, Status: "404 Not Found", Code: eTradeErrorCodeInvalidAccountKey,
+			},
+			expectError: "request failed: 404 Not Found (ETrade error 100); check the account ID with the " +
+				"'accounts list' command",
+		},
+		{
+			name:        "Other Error Has No Hint",
+			err:         errors.New("test error"),
+			expectError: "test error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualError := AddErrorHelp(tt.err)
+				assert.Equal(t, tt.expectError, actualError.Error())
+				assert.ErrorIs(t, actualError, tt.err)
+			},
+		)
+	}
+}
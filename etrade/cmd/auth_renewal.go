@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"time"
+)
+
+// RenewIdleAuth prepares a customer's client for use. If the cached access
+// token has gone idle, it is renewed with ETrade. If the token has expired or
+// there is no token, the returned error wraps client.ErrETradeAuthFailed, since
+// the customer must log in again. Unless an error is returned, the token's use
+// is recorded in the credential store so that its idle time can be tracked,
+// and the recorded credentials are returned.
+func RenewIdleAuth(
+	customerId string, eTradeClient client.ETradeClient, credentialStore CredentialStore, now time.Time,
+) (*CachedCredentials, error) {
+	_, _, accessToken, accessSecret := eTradeClient.GetKeys()
+	if accessToken == "" {
+		return nil, fmt.Errorf("%w: customer '%s' is not logged in", client.ErrETradeAuthFailed, customerId)
+	}
+
+	cachedCredentials, err := credentialStore.LoadCredentials()
+	if err != nil || cachedCredentials.AccessToken != accessToken {
+		// The cache doesn't describe the client's token, so the token's age
+		// is unknown. Start tracking it from now.
+		cachedCredentials = nil
+	} else {
+		switch cachedCredentials.GetTokenStatus(now) {
+		case tokenStatusExpired:
+			return nil, fmt.Errorf(
+				"%w: the access token for customer '%s' expired at %s", client.ErrETradeAuthFailed,
+				customerId, cachedCredentials.GetExpiresAt().Format(time.RFC3339),
+			)
+		case tokenStatusIdle:
+			if _, err = eTradeClient.RenewAccessToken(); err != nil {
+				return nil, fmt.Errorf(
+					"unable to renew the idle access token for customer '%s' (%w); log in again", customerId, err,
+				)
+			}
+		}
+	}
+
+	usedCredentials := NewCachedCredentials(accessToken, accessSecret, cachedCredentials, now)
+	if err = credentialStore.SaveCredentials(usedCredentials); err != nil {
+		return nil, err
+	}
+	return usedCredentials, nil
+}
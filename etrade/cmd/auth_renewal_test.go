@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRenewIdleAuth(t *testing.T) {
+	// Wednesday, June 14, 2023 at 9:00am US Eastern
+	issuedAt := time.Date(2023, 6, 14, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		cached      *CachedCredentials
+		clientToken string
+		now         time.Time
+		setupMock   func(mockClient *client.ETradeClientMock)
+		expectErr   bool
+		// expectAuthFailed is set if the error must tell the server's clients
+		// to log in again.
+		expectAuthFailed bool
+		expectIssuedAt   time.Time
+	}{
+		{
+			name:           "Records Use Of Valid Token",
+			cached:         &CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			clientToken:    "TestToken",
+			now:            issuedAt.Add(time.Hour),
+			expectErr:      false,
+			expectIssuedAt: issuedAt,
+		},
+		{
+			name:        "Renews Idle Token",
+			cached:      &CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			clientToken: "TestToken",
+			now:         issuedAt.Add(3 * time.Hour),
+			setupMock: func(mockClient *client.ETradeClientMock) {
+				mockClient.On("RenewAccessToken").Return([]byte(`{"status":"success"}`), nil)
+			},
+			expectErr:      false,
+			expectIssuedAt: issuedAt,
+		},
+		{
+			name:        "Fails When Idle Token Cannot Be Renewed",
+			cached:      &CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			clientToken: "TestToken",
+			now:         issuedAt.Add(3 * time.Hour),
+			setupMock: func(mockClient *client.ETradeClientMock) {
+				mockClient.On("RenewAccessToken").Return([]byte(nil), errors.New("test error"))
+			},
+			expectErr:      true,
+			expectIssuedAt: issuedAt,
+		},
+		{
+			name:             "Fails When Token Has Expired",
+			cached:           &CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			clientToken:      "TestToken",
+			now:              issuedAt.Add(24 * time.Hour),
+			expectErr:        true,
+			expectAuthFailed: true,
+			expectIssuedAt:   issuedAt,
+		},
+		{
+			name:             "Fails Without Token",
+			cached:           nil,
+			clientToken:      "",
+			now:              issuedAt,
+			expectErr:        true,
+			expectAuthFailed: true,
+			expectIssuedAt:   time.Time{},
+		},
+		{
+			name:           "Starts Tracking Untracked Token",
+			cached:         nil,
+			clientToken:    "TestToken",
+			now:            issuedAt,
+			expectErr:      false,
+			expectIssuedAt: issuedAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				credentialStore := NewPlainCredentialStore(filepath.Join(t.TempDir(), "credentials"), nil)
+				if tt.cached != nil {
+					err := credentialStore.SaveCredentials(tt.cached)
+					assert.Nil(t, err)
+				}
+				mockClient := client.ETradeClientMock{}
+				mockClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", tt.clientToken, "TestSecret")
+				if tt.setupMock != nil {
+					tt.setupMock(&mockClient)
+				}
+				// Call the Method Under Test
+				usedCredentials, err := RenewIdleAuth("TestCustomerId", &mockClient, credentialStore, tt.now)
+				if tt.expectErr {
+					assert.Error(t, err)
+					assert.Equal(t, tt.expectAuthFailed, client.IsAuthFailed(err))
+				} else {
+					assert.Nil(t, err)
+					cached, err := credentialStore.LoadCredentials()
+					assert.Nil(t, err)
+					assert.True(t, tt.expectIssuedAt.Equal(cached.IssuedAt))
+					assert.True(t, tt.now.Equal(cached.LastUsed))
+					assert.Equal(t, cached.AccessToken, usedCredentials.AccessToken)
+					assert.True(t, tt.now.Equal(usedCredentials.LastUsed))
+				}
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"golang.org/x/exp/slog"
 	"io"
 	"os"
@@ -14,6 +15,85 @@ type CachedCredentials struct {
 	AccessToken  string    `json:"accessToken"`
 	AccessSecret string    `json:"accessSecret"`
 	LastUpdated  time.Time `json:"lastUpdated"`
+	IssuedAt     time.Time `json:"issuedAt"`
+	LastUsed     time.Time `json:"lastUsed"`
+}
+
+// Token status values reported for cached credentials.
+const (
+	// tokenStatusNone indicates that there is no cached access token.
+	tokenStatusNone = "none"
+	// tokenStatusValid indicates that the access token may be used as is.
+	tokenStatusValid = "valid"
+	// tokenStatusIdle indicates that the access token has gone inactive
+	// and must be renewed before it can be used.
+	tokenStatusIdle = "idle"
+	// tokenStatusExpired indicates that the access token has expired and a
+	// new one must be obtained by logging in again.
+	tokenStatusExpired = "expired"
+)
+
+// tokenIdleTimeout is how long an ETrade access token may go unused before
+// it becomes inactive and must be renewed.
+const tokenIdleTimeout = 2 * time.Hour
+
+// NewCachedCredentials returns credentials for an access token that was
+// just used. If previous holds the same access token, its issue time is kept;
+// otherwise, the token is treated as newly issued.
+func NewCachedCredentials(
+	accessToken string, accessSecret string, previous *CachedCredentials, now time.Time,
+) *CachedCredentials {
+	issuedAt := now
+	if previous != nil && previous.AccessToken == accessToken && !previous.GetIssuedAt().IsZero() {
+		issuedAt = previous.GetIssuedAt()
+	}
+	return &CachedCredentials{
+		AccessToken:  accessToken,
+		AccessSecret: accessSecret,
+		LastUpdated:  now,
+		IssuedAt:     issuedAt,
+		LastUsed:     now,
+	}
+}
+
+// GetIssuedAt returns the time the access token was issued. Credentials
+// cached before issue times were recorded fall back to the last update time.
+func (c *CachedCredentials) GetIssuedAt() time.Time {
+	if c.IssuedAt.IsZero() {
+		return c.LastUpdated
+	}
+	return c.IssuedAt
+}
+
+// GetLastUsed returns the time the access token was last used. Credentials
+// cached before use times were recorded fall back to the last update time.
+func (c *CachedCredentials) GetLastUsed() time.Time {
+	if c.LastUsed.IsZero() {
+		return c.LastUpdated
+	}
+	return c.LastUsed
+}
+
+// GetExpiresAt returns the time the access token expires, which is the
+// midnight US Eastern time following its issue.
+func (c *CachedCredentials) GetExpiresAt() time.Time {
+	issuedAt := c.GetIssuedAt().In(client.EasternTime)
+	return time.Date(issuedAt.Year(), issuedAt.Month(), issuedAt.Day()+1, 0, 0, 0, 0, client.EasternTime)
+}
+
+// GetTokenStatus determines, without contacting ETrade, whether the access
+// token is valid, idle, or expired at the given time.
+func (c *CachedCredentials) GetTokenStatus(now time.Time) string {
+	if c.AccessToken == "" {
+		return tokenStatusNone
+	}
+	if !now.Before(c.GetExpiresAt()) {
+		return tokenStatusExpired
+	}
+	if now.Sub(c.GetLastUsed()) >= tokenIdleTimeout {
+		return tokenStatusIdle
+	}
+	return tokenStatusValid
 }
 
 func LoadCachedCredentials(reader io.Reader) (*CachedCredentials, error) {
@@ -59,7 +139,8 @@ func SaveCachedCredentialsToFile(filename string, credentials *CachedCredentials
 		return err
 	}
 
-	file, err := os.Create(filename)
+	// Credentials are readable only by their owner.
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if file != nil {
 		defer func(file *os.File) {
 			err = file.Close()
@@ -68,11 +68,15 @@ func TestSaveCachedCredentialsSucceeds(t *testing.T) {
 		AccessToken:  "TestToken",
 		AccessSecret: "TestSecret",
 		LastUpdated:  lastUpdated,
+		IssuedAt:     lastUpdated,
+		LastUsed:     lastUpdated,
 	}
 	expectedJson := `{
   "accessToken": "TestToken",
   "accessSecret": "TestSecret",
-  "lastUpdated": "2021-02-18T21:54:42.123Z"
+  "lastUpdated": "2021-02-18T21:54:42.123Z",
+  "issuedAt": "2021-02-18T21:54:42.123Z",
+  "lastUsed": "2021-02-18T21:54:42.123Z"
 }` + "\n"
 
 	actualJson := strings.Builder{}
@@ -81,3 +85,108 @@ func TestSaveCachedCredentialsSucceeds(t *testing.T) {
 
 	assert.Equal(t, expectedJson, actualJson.String())
 }
+
+func TestNewCachedCredentials(t *testing.T) {
+	issuedAt := time.Date(2023, 6, 14, 13, 0, 0, 0, time.UTC)
+	now := time.Date(2023, 6, 14, 15, 0, 0, 0, time.UTC)
+	previous := &CachedCredentials{
+		AccessToken:  "TestToken",
+		AccessSecret: "TestSecret",
+		LastUpdated:  issuedAt,
+		IssuedAt:     issuedAt,
+		LastUsed:     issuedAt,
+	}
+
+	tests := []struct {
+		name          string
+		accessToken   string
+		previous      *CachedCredentials
+		expectIssueAt time.Time
+	}{
+		{
+			name:          "Keeps Issue Time For Same Token",
+			accessToken:   "TestToken",
+			previous:      previous,
+			expectIssueAt: issuedAt,
+		},
+		{
+			name:          "Uses Current Time For New Token",
+			accessToken:   "NewToken",
+			previous:      previous,
+			expectIssueAt: now,
+		},
+		{
+			name:          "Uses Current Time Without Previous Credentials",
+			accessToken:   "TestToken",
+			previous:      nil,
+			expectIssueAt: now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue := NewCachedCredentials(tt.accessToken, "TestSecret", tt.previous, now)
+				assert.Equal(t, tt.accessToken, actualValue.AccessToken)
+				assert.Equal(t, tt.expectIssueAt, actualValue.IssuedAt)
+				assert.Equal(t, now, actualValue.LastUsed)
+				assert.Equal(t, now, actualValue.LastUpdated)
+			},
+		)
+	}
+}
+
+func TestCachedCredentials_GetTokenStatus(t *testing.T) {
+	// Wednesday, June 14, 2023 at 9:00am US Eastern
+	issuedAt := time.Date(2023, 6, 14, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		credentials CachedCredentials
+		now         time.Time
+		expectValue string
+	}{
+		{
+			name:        "No Token",
+			credentials: CachedCredentials{},
+			now:         issuedAt,
+			expectValue: tokenStatusNone,
+		},
+		{
+			name:        "Recently Used Token Is Valid",
+			credentials: CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			now:         issuedAt.Add(tokenIdleTimeout - time.Minute),
+			expectValue: tokenStatusValid,
+		},
+		{
+			name:        "Unused Token Is Idle",
+			credentials: CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			now:         issuedAt.Add(tokenIdleTimeout),
+			expectValue: tokenStatusIdle,
+		},
+		{
+			name:        "Token Expires At Midnight US Eastern",
+			credentials: CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			// Thursday, June 15, 2023 at 12:00am US Eastern
+			now:         time.Date(2023, 6, 15, 4, 0, 0, 0, time.UTC),
+			expectValue: tokenStatusExpired,
+		},
+		{
+			name:        "Falls Back To Last Updated Time",
+			credentials: CachedCredentials{AccessToken: "TestToken", LastUpdated: issuedAt},
+			now:         issuedAt.Add(time.Minute),
+			expectValue: tokenStatusValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue := tt.credentials.GetTokenStatus(tt.now)
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
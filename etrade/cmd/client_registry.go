@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"sync"
+	"time"
+)
+
+// authUseRecordInterval is how often UseAuth records the use of a customer's
+// access token in the credential store. In between, the token's use is only
+// tracked in memory. It is well under tokenIdleTimeout, so the recorded use
+// time never makes a token that is in use look idle to another process.
+const authUseRecordInterval = 10 * time.Minute
+
+// clientRegistry caches a client and credential store for each customer so
+// that the server doesn't have to create them for every request. It is safe
+// for concurrent use.
+type clientRegistry struct {
+	// mutex guards the customers map, but not the entries in it, so that
+	// creating one customer's client doesn't hold up requests for others.
+	mutex     sync.Mutex
+	customers map[string]*customerClientEntry
+	// newRateLimiter creates a customer's rate limiter. It fails if the
+	// customer isn't configured.
+	newRateLimiter func(customerId string) (*client.RateLimiter, error)
+	// newClient creates a client and credential store for a customer.
+	newClient func(customerId string, rateLimiter *client.RateLimiter) (client.ETradeClient, CredentialStore, error)
+}
+
+type customerClientEntry struct {
+	// mutex guards the entry's client and credential store.
+	mutex           sync.Mutex
+	eTradeClient    client.ETradeClient
+	credentialStore CredentialStore
+	// rateLimiter outlives the cached client so that all of a customer's
+	// requests share one limiter, even across logins.
+	rateLimiter *client.RateLimiter
+	// authMutex serializes the operations that change the customer's
+	// authentication (logging in, logging out and renewing the access token)
+	// so that they don't overwrite each other's credentials.
+	authMutex sync.Mutex
+	// useMutex guards the in-memory record of the access token's use.
+	useMutex sync.Mutex
+	// usedCredentials are the credentials last recorded by UseAuth, with
+	// LastUsed updated in memory since then. They are nil until the first
+	// request.
+	usedCredentials *CachedCredentials
+	// usedCredentialsRecordedAt is when usedCredentials were last recorded in
+	// the credential store.
+	usedCredentialsRecordedAt time.Time
+}
+
+func newClientRegistry(
+	newRateLimiter func(customerId string) (*client.RateLimiter, error),
+	newClient func(customerId string, rateLimiter *client.RateLimiter) (client.ETradeClient, CredentialStore, error),
+) *clientRegistry {
+	return &clientRegistry{
+		customers:      map[string]*customerClientEntry{},
+		newRateLimiter: newRateLimiter,
+		newClient:      newClient,
+	}
+}
+
+// Get returns the cached client and credential store for a customer,
+// creating them if they aren't cached.
+func (r *clientRegistry) Get(customerId string) (client.ETradeClient, CredentialStore, error) {
+	entry, err := r.getEntry(customerId)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	if entry.eTradeClient == nil {
+		eTradeClient, credentialStore, err := r.newClient(customerId, entry.rateLimiter)
+		if err != nil {
+			return nil, nil, err
+		}
+		entry.eTradeClient, entry.credentialStore = eTradeClient, credentialStore
+	}
+	return entry.eTradeClient, entry.credentialStore, nil
+}
+
+// Remove removes a customer's cached client and credential store, so that
+// the next call to Get creates new ones. Requests that already have the old
+// client may continue to use it.
+func (r *clientRegistry) Remove(customerId string) {
+	r.mutex.Lock()
+	entry, ok := r.customers[customerId]
+	r.mutex.Unlock()
+	if !ok {
+		return
+	}
+	entry.mutex.Lock()
+	entry.eTradeClient, entry.credentialStore = nil, nil
+	entry.mutex.Unlock()
+	entry.useMutex.Lock()
+	entry.usedCredentials = nil
+	entry.useMutex.Unlock()
+}
+
+// LockAuth waits until no other operation is changing the customer's
+// authentication and then locks it. The returned function unlocks it.
+func (r *clientRegistry) LockAuth(customerId string) (func(), error) {
+	entry, err := r.getEntry(customerId)
+	if err != nil {
+		return nil, err
+	}
+	entry.authMutex.Lock()
+	return entry.authMutex.Unlock, nil
+}
+
+// UseAuth prepares a customer's client for a request, as RenewIdleAuth does,
+// without reading or writing the credential store for most requests. The
+// token's use is tracked in memory, and the customer's auth lock is only taken
+// to check the store, renew the token, or record its use when the token is new
+// to the registry, idle, or expired, or its use hasn't been recorded for
+// authUseRecordInterval.
+func (r *clientRegistry) UseAuth(
+	customerId string, eTradeClient client.ETradeClient, credentialStore CredentialStore, now time.Time,
+) error {
+	entry, err := r.getEntry(customerId)
+	if err != nil {
+		return err
+	}
+	_, _, accessToken, _ := eTradeClient.GetKeys()
+	if entry.useRecentlyRecorded(accessToken, now) {
+		return nil
+	}
+
+	entry.authMutex.Lock()
+	defer entry.authMutex.Unlock()
+	// Another request may have recorded the token's use while this one was
+	// waiting for the lock.
+	if entry.useRecentlyRecorded(accessToken, now) {
+		return nil
+	}
+	usedCredentials, err := RenewIdleAuth(customerId, eTradeClient, credentialStore, now)
+	if err != nil {
+		return err
+	}
+	entry.useMutex.Lock()
+	defer entry.useMutex.Unlock()
+	entry.usedCredentials, entry.usedCredentialsRecordedAt = usedCredentials, now
+	return nil
+}
+
+// useRecentlyRecorded returns whether the access token is the one whose use
+// was last recorded, is still valid, and had its use recorded within
+// authUseRecordInterval. If so, the use is noted in memory.
+func (e *customerClientEntry) useRecentlyRecorded(accessToken string, now time.Time) bool {
+	e.useMutex.Lock()
+	defer e.useMutex.Unlock()
+	credentials := e.usedCredentials
+	if credentials == nil || accessToken == "" || credentials.AccessToken != accessToken ||
+		credentials.GetTokenStatus(now) != tokenStatusValid ||
+		now.Sub(e.usedCredentialsRecordedAt) >= authUseRecordInterval {
+		return false
+	}
+	if now.After(credentials.LastUsed) {
+		credentials.LastUsed = now
+	}
+	return true
+}
+
+// getEntry returns the entry for a customer, creating it if necessary. An
+// entry is only created for a configured customer, so that requests for
+// arbitrary customer IDs can't grow the registry.
+func (r *clientRegistry) getEntry(customerId string) (*customerClientEntry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if entry, ok := r.customers[customerId]; ok {
+		return entry, nil
+	}
+	rateLimiter, err := r.newRateLimiter(customerId)
+	if err != nil {
+		return nil, err
+	}
+	entry := &customerClientEntry{rateLimiter: rateLimiter}
+	r.customers[customerId] = entry
+	return entry, nil
+}
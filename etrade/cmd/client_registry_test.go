@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClientRegistry creates a registry for the given customers that
+// counts the clients it creates for each customer.
+func newTestClientRegistry(customerIds []string, createdCounts map[string]*int32) *clientRegistry {
+	return newClientRegistry(
+		func(customerId string) (*client.RateLimiter, error) {
+			for _, id := range customerIds {
+				if id == customerId {
+					return client.NewRateLimiter(client.RateLimits{}), nil
+				}
+			}
+			return nil, errors.New("customer not found")
+		},
+		func(customerId string, rateLimiter *client.RateLimiter) (client.ETradeClient, CredentialStore, error) {
+			atomic.AddInt32(createdCounts[customerId], 1)
+			return &client.ETradeClientMock{}, NewPlainCredentialStore(customerId, etradelibtest.CreateNullLogger()), nil
+		},
+	)
+}
+
+func TestClientRegistry_Get_CreatesOneClientPerCustomer(t *testing.T) {
+	createdCounts := map[string]*int32{"alice": new(int32), "bob": new(int32)}
+	registry := newTestClientRegistry([]string{"alice", "bob"}, createdCounts)
+
+	// Call the Method Under Test
+	var waitGroup sync.WaitGroup
+	clients := make([]client.ETradeClient, 50)
+	for i := range clients {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+			customerId := []string{"alice", "bob"}[i%2]
+			eTradeClient, _, err := registry.Get(customerId)
+			assert.Nil(t, err)
+			clients[i] = eTradeClient
+		}(i)
+	}
+	waitGroup.Wait()
+
+	assert.Equal(t, int32(1), *createdCounts["alice"])
+	assert.Equal(t, int32(1), *createdCounts["bob"])
+	for i := range clients {
+		assert.Same(t, clients[i%2], clients[i])
+	}
+	assert.NotSame(t, clients[0], clients[1])
+}
+
+func TestClientRegistry_Get_FailsForUnknownCustomer(t *testing.T) {
+	registry := newTestClientRegistry([]string{"alice"}, map[string]*int32{"alice": new(int32)})
+
+	// Call the Method Under Test
+	_, _, err := registry.Get("mallory")
+
+	assert.Error(t, err)
+	assert.Empty(t, registry.customers)
+}
+
+func TestClientRegistry_Remove(t *testing.T) {
+	createdCounts := map[string]*int32{"alice": new(int32)}
+	registry := newTestClientRegistry([]string{"alice"}, createdCounts)
+	firstClient, _, err := registry.Get("alice")
+	assert.Nil(t, err)
+	rateLimiter := registry.customers["alice"].rateLimiter
+
+	// Call the Method Under Test
+	registry.Remove("alice")
+
+	secondClient, _, err := registry.Get("alice")
+	assert.Nil(t, err)
+	assert.NotSame(t, firstClient, secondClient)
+	assert.Equal(t, int32(2), *createdCounts["alice"])
+	// The rate limiter outlives the client.
+	assert.Same(t, rateLimiter, registry.customers["alice"].rateLimiter)
+}
+
+func TestClientRegistry_LockAuth(t *testing.T) {
+	registry := newTestClientRegistry([]string{"alice"}, map[string]*int32{"alice": new(int32)})
+	var holders, maxHolders int32
+
+	// Call the Method Under Test
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			unlock, err := registry.LockAuth("alice")
+			if !assert.Nil(t, err) {
+				return
+			}
+			current := atomic.AddInt32(&holders, 1)
+			if current > atomic.LoadInt32(&maxHolders) {
+				atomic.StoreInt32(&maxHolders, current)
+			}
+			atomic.AddInt32(&holders, -1)
+			unlock()
+		}()
+	}
+	waitGroup.Wait()
+
+	assert.Equal(t, int32(1), maxHolders)
+
+	_, err := registry.LockAuth("mallory")
+	assert.Error(t, err)
+}
+
+// countingCredentialStore counts the loads and saves of a credential store.
+type countingCredentialStore struct {
+	CredentialStore
+	loads int32
+	saves int32
+}
+
+func (s *countingCredentialStore) LoadCredentials() (*CachedCredentials, error) {
+	atomic.AddInt32(&s.loads, 1)
+	return s.CredentialStore.LoadCredentials()
+}
+
+func (s *countingCredentialStore) SaveCredentials(credentials *CachedCredentials) error {
+	atomic.AddInt32(&s.saves, 1)
+	return s.CredentialStore.SaveCredentials(credentials)
+}
+
+func TestClientRegistry_UseAuth_RecordsUseOnlyWhenNeeded(t *testing.T) {
+	registry := newTestClientRegistry([]string{"alice"}, map[string]*int32{"alice": new(int32)})
+	credentialStore := &countingCredentialStore{
+		CredentialStore: NewPlainCredentialStore(filepath.Join(t.TempDir(), "credentials"), nil),
+	}
+	mockClient := &client.ETradeClientMock{}
+	mockClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "TestToken", "TestSecret")
+	start := time.Date(2023, 9, 15, 10, 0, 0, 0, time.UTC)
+
+	// Call the Method Under Test
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			assert.Nil(t, registry.UseAuth("alice", mockClient, credentialStore, start))
+		}()
+	}
+	waitGroup.Wait()
+
+	// The first request records the token's use, and the rest rely on it.
+	assert.Equal(t, int32(1), credentialStore.loads)
+	assert.Equal(t, int32(1), credentialStore.saves)
+
+	assert.Nil(t, registry.UseAuth("alice", mockClient, credentialStore, start.Add(authUseRecordInterval/2)))
+	assert.Equal(t, int32(1), credentialStore.saves)
+
+	// The use is recorded again once the interval has passed.
+	recordTime := start.Add(authUseRecordInterval)
+	assert.Nil(t, registry.UseAuth("alice", mockClient, credentialStore, recordTime))
+	assert.Equal(t, int32(2), credentialStore.saves)
+	cached, err := credentialStore.LoadCredentials()
+	assert.Nil(t, err)
+	assert.True(t, recordTime.Equal(cached.LastUsed))
+}
+
+func TestClientRegistry_UseAuth_ChecksStoreForNewToken(t *testing.T) {
+	registry := newTestClientRegistry([]string{"alice"}, map[string]*int32{"alice": new(int32)})
+	credentialStore := &countingCredentialStore{
+		CredentialStore: NewPlainCredentialStore(filepath.Join(t.TempDir(), "credentials"), nil),
+	}
+	now := time.Date(2023, 9, 15, 10, 0, 0, 0, time.UTC)
+	firstClient := &client.ETradeClientMock{}
+	firstClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "FirstToken", "TestSecret")
+	assert.Nil(t, registry.UseAuth("alice", firstClient, credentialStore, now))
+	loggedOutClient := &client.ETradeClientMock{}
+	loggedOutClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "", "")
+
+	// Call the Method Under Test
+	err := registry.UseAuth("alice", loggedOutClient, credentialStore, now)
+
+	assert.True(t, client.IsAuthFailed(err))
+}
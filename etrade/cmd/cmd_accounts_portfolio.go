@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/spf13/cobra"
 )
@@ -9,6 +10,8 @@ import (
 type accountsPortfolioFlags struct {
 	totalsRequired bool
 	withLots       bool
+	concurrency    int
+	maxItems       int
 	portfolioView  enumFlagValue[constants.PortfolioView]
 	sortBy         enumFlagValue[constants.PortfolioSortBy]
 	sortOrder      enumFlagValue[constants.SortOrder]
@@ -28,10 +31,11 @@ func (c *CommandAccountsPortfolio) Command() *cobra.Command {
 		Args:  cobra.MatchAll(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			accountId := args[0]
-			if response, err := ViewPortfolio(
+			if stream, err := StreamPortfolio(
 				c.Context.Client, accountId, c.flags.sortBy.Value(), c.flags.sortOrder.Value(),
 				c.flags.marketSession.Value(),
-				c.flags.totalsRequired, c.flags.portfolioView.Value(), c.flags.withLots,
+				c.flags.totalsRequired, c.flags.portfolioView.Value(), c.flags.withLots, c.flags.concurrency,
+				c.flags.maxItems,
 			); err == nil {
 				renderDescriptor := GetQuickViewRenderDescriptor(c.flags.withLots)
 				switch c.flags.portfolioView.Value() {
@@ -44,7 +48,7 @@ func (c *CommandAccountsPortfolio) Command() *cobra.Command {
 				case constants.PortfolioViewComplete:
 					renderDescriptor = GetCompleteViewRenderDescriptor(c.flags.withLots)
 				}
-				return c.Context.Renderer.Render(response, renderDescriptor)
+				return c.Context.Renderer.RenderStream(cmd.Context(), stream, renderDescriptor)
 			} else {
 				return err
 			}
@@ -54,6 +58,11 @@ func (c *CommandAccountsPortfolio) Command() *cobra.Command {
 	// Add Flags
 	cmd.Flags().BoolVarP(&c.flags.totalsRequired, "totals-required", "t", true, "include totals in results")
 	cmd.Flags().BoolVarP(&c.flags.withLots, "with-lots", "l", false, "include lots in results")
+	cmd.Flags().IntVarP(
+		&c.flags.concurrency, "concurrency", "c", etradelib.DefaultLotsConcurrency,
+		"number of positions to fetch lots for at once",
+	)
+	cmd.Flags().IntVarP(&c.flags.maxItems, "max-items", "n", 0, "maximum number of positions to list (0 for all)")
 
 	// Initialize Enum Flag Values
 	c.flags.portfolioView = *newEnumFlagValue(portfolioViewMap, constants.PortfolioViewQuick)
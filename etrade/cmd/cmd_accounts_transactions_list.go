@@ -11,6 +11,7 @@ import (
 type accountsTransactionsListFlags struct {
 	startDate string
 	endDate   string
+	maxItems  int
 	sortOrder enumFlagValue[constants.SortOrder]
 }
 
@@ -42,10 +43,10 @@ func (c *CommandAccountsTransactionsList) Command() *cobra.Command {
 					return errors.New("end date must be in format MMDDYYYY")
 				}
 			}
-			if response, err := ListTransactions(
-				c.Context.Client, accountId, startDate, endDate, c.flags.sortOrder.Value(),
+			if stream, err := StreamTransactions(
+				c.Context.Client, accountId, startDate, endDate, c.flags.sortOrder.Value(), c.flags.maxItems,
 			); err == nil {
-				return c.Context.Renderer.Render(response, transactionListDescriptor)
+				return c.Context.Renderer.RenderStream(cmd.Context(), stream, transactionListDescriptor)
 			} else {
 				return err
 			}
@@ -55,6 +56,7 @@ func (c *CommandAccountsTransactionsList) Command() *cobra.Command {
 	// Add Flags
 	cmd.Flags().StringVarP(&c.flags.startDate, "start-date", "s", "", "start date (MMDDYYYY)")
 	cmd.Flags().StringVarP(&c.flags.endDate, "end-date", "e", "", "end date (MMDDYYYY)")
+	cmd.Flags().IntVarP(&c.flags.maxItems, "max-items", "n", 0, "maximum number of transactions to list (0 for all)")
 
 	// Initialize Enum Flag Values
 	c.flags.sortOrder = *newEnumFlagValue(sortOrderMap, constants.SortOrderNil)
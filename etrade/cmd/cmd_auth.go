@@ -28,5 +28,6 @@ func (c *CommandAuth) Command(globalFlags *globalFlags) *cobra.Command {
 	// Add Subcommands
 	cmd.AddCommand((&CommandAuthClear{Context: &c.context}).Command(globalFlags))
 	cmd.AddCommand((&CommandAuthLogin{Context: &c.context}).Command(globalFlags))
+	cmd.AddCommand((&CommandAuthStatus{Context: &c.context}).Command(globalFlags))
 	return cmd
 }
@@ -1,28 +1,57 @@
 package cmd
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"github.com/spf13/cobra"
 )
 
+type authClearFlags struct {
+	localOnly bool
+}
+
 type CommandAuthClear struct {
 	Context *CommandContextWithStore
+	flags   authClearFlags
 }
 
 func (c *CommandAuthClear) Command(globalFlags *globalFlags) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "clear",
-		Short: "Clear authentication credentials for the current Customer ID",
-		Long:  "Clear authentication credentials for the current Customer ID",
+		Use:     "clear",
+		Aliases: []string{"logout"},
+		Short:   "Clear authentication credentials for the current Customer ID",
+		Long: "Revoke the access token for the current Customer ID with ETrade and then clear the cached " +
+			"authentication credentials",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if response, err := ClearAuth(
-				globalFlags.customerId, c.Context.ConfigurationFolder, c.Context.CustomerConfigurationStore,
-			); err == nil {
+			var eTradeClient client.ETradeClient
+			var credentialStore CredentialStore
+			var err error
+			if c.flags.localOnly {
+				credentialStore, err = NewCredentialStoreForCustomer(
+					globalFlags.customerId, c.Context.ConfigurationFolder, c.Context.CustomerConfigurationStore,
+					c.Context.Logger,
+				)
+			} else {
+				eTradeClient, credentialStore, err = NewETradeClientForCustomer(
+					globalFlags.customerId, c.Context.ConfigurationFolder, c.Context.CustomerConfigurationStore, nil,
+					c.Context.Logger,
+				)
+			}
+			if err != nil {
+				return err
+			}
+			if response, err := ClearAuth(globalFlags.customerId, eTradeClient, credentialStore); err == nil {
 				return c.Context.Renderer.Render(response, clearAuthDescriptor)
 			} else {
 				return err
 			}
 		},
 	}
+
+	// Add Flags
+	cmd.Flags().BoolVar(
+		&c.flags.localOnly, "local-only", false,
+		"clear the cached credentials without revoking the access token with ETrade",
+	)
 	return cmd
 }
 
@@ -31,6 +60,7 @@ var clearAuthDescriptor = []RenderDescriptor{
 		ObjectPath: "",
 		Values: []RenderValue{
 			{Header: "Status", Path: ".status"},
+			{Header: "Token Revoked", Path: ".tokenRevoked"},
 		},
 		DefaultValue: "",
 		SpaceAfter:   false,
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommandAuthClear_RunsAsLogout(t *testing.T) {
+	logger := etradelibtest.CreateNullLogger()
+	cfgFolder := NewConfigurationFolder(t.TempDir())
+	cfgStore, err := LoadCustomerConfigurationStore(strings.NewReader("{}"))
+	require.Nil(t, err)
+	cfgStore.SetCustomerConfigurationForId(
+		"alice", &CustomerConfiguration{
+			CustomerName:           "alice",
+			CustomerConsumerKey:    "consumerKeyalice",
+			CustomerConsumerSecret: "consumerSecret",
+		},
+	)
+	credentialStore := NewPlainCredentialStore(cfgFolder.GetFileCachePathForCustomer("consumerKeyalice"), logger)
+	require.Nil(t, credentialStore.SaveCredentials(NewCachedCredentials("token", "secret", nil, time.Now())))
+	outputFile, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	require.Nil(t, err)
+	commandContext := &CommandContextWithStore{
+		Logger:                     logger,
+		Renderer:                   &jsonRenderer{outputFile: outputFile},
+		ConfigurationFolder:        cfgFolder,
+		CustomerConfigurationStore: cfgStore,
+	}
+	authCmd := &cobra.Command{Use: "auth"}
+	authCmd.AddCommand((&CommandAuthClear{Context: commandContext}).Command(&globalFlags{customerId: "alice"}))
+	authCmd.SetArgs([]string{"logout", "--local-only"})
+
+	// Call the Method Under Test
+	err = authCmd.Execute()
+
+	require.Nil(t, err)
+	_, err = credentialStore.LoadCredentials()
+	assert.Error(t, err)
+	require.Nil(t, outputFile.Close())
+	output, err := os.ReadFile(outputFile.Name())
+	require.Nil(t, err)
+	assert.Contains(t, string(output), `"status":"success"`)
+}
+
+func TestCommandAuth_HasLogoutAlias(t *testing.T) {
+	authCmd := (&CommandAuth{}).Command(&globalFlags{})
+
+	// Call the Method Under Test
+	logoutCmd, _, err := authCmd.Find([]string{"logout"})
+
+	require.Nil(t, err)
+	assert.Equal(t, "clear", logoutCmd.Name())
+}
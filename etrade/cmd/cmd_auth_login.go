@@ -26,8 +26,8 @@ func (c *CommandAuthLogin) Command(globalFlags *globalFlags) *cobra.Command {
 }
 
 func (c *CommandAuthLogin) Login(customerId string) error {
-	eTradeClient, err := NewETradeClientForCustomer(
-		customerId, c.Context.ConfigurationFolder, c.Context.CustomerConfigurationStore, c.Context.Logger,
+	eTradeClient, credentialStore, err := NewETradeClientForCustomer(
+		customerId, c.Context.ConfigurationFolder, c.Context.CustomerConfigurationStore, nil, c.Context.Logger,
 	)
 	if err != nil {
 		return err
@@ -73,10 +73,8 @@ func (c *CommandAuthLogin) Login(customerId string) error {
 		statusMap = verifyStatus.AsJsonMap()
 	}
 	// Store new or renewed credentials to the cache file.
-	consumerKey, _, accessToken, accessSecret := eTradeClient.GetKeys()
-	if err = c.Context.ConfigurationFolder.SaveCachedCredentialsToFile(
-		consumerKey, &CachedCredentials{accessToken, accessSecret, time.Now()}, c.Context.Logger,
-	); err != nil {
+	_, _, accessToken, accessSecret := eTradeClient.GetKeys()
+	if err = UpdateCachedCredentials(credentialStore, accessToken, accessSecret, time.Now()); err != nil {
 		return err
 	}
 
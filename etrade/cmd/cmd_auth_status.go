@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"time"
+)
+
+type CommandAuthStatus struct {
+	Context *CommandContextWithStore
+}
+
+func (c *CommandAuthStatus) Command(globalFlags *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show authentication status for the current Customer ID",
+		Long: "Show whether the cached access token for the current Customer ID is valid, idle (it will be " +
+			"renewed automatically when next used), or expired (log in again). ETrade is not contacted.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			credentialStore, err := NewCredentialStoreForCustomer(
+				globalFlags.customerId, c.Context.ConfigurationFolder, c.Context.CustomerConfigurationStore,
+				c.Context.Logger,
+			)
+			if err != nil {
+				return err
+			}
+			if response, err := GetAuthStatus(credentialStore, time.Now()); err == nil {
+				return c.Context.Renderer.Render(response, authStatusDescriptor)
+			} else {
+				return err
+			}
+		},
+	}
+	return cmd
+}
+
+var authStatusDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: "",
+		Values: []RenderValue{
+			{Header: "Status", Path: ".status"},
+			{Header: "Message", Path: ".message"},
+			{Header: "Issued At", Path: ".issuedAt"},
+			{Header: "Last Used", Path: ".lastUsed"},
+			{Header: "Expires At", Path: ".expiresAt"},
+		},
+		DefaultValue: "",
+		SpaceAfter:   false,
+	},
+}
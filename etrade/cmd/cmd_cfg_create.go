@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/spf13/cobra"
 )
@@ -48,11 +49,18 @@ func (c *CommandCfgCreate) CreateConfig() error {
 				CustomerProduction:     true,
 				CustomerConsumerKey:    "consumer key",
 				CustomerConsumerSecret: "consumer secret",
+				CustomerRiskLimits: &CustomerRiskLimits{
+					MaxOrderNotional:     decimal.NewFromInt(10000),
+					MaxShareQuantity:     1000,
+					AllowedSymbols:       []string{},
+					TradingHoursOnly:     true,
+					ProductionKillSwitch: false,
+				},
 			},
 			"CustomerId2 - a short customer ID that you'll specify with --customer-id to use this configuration": {
 				CustomerName:           "A human-readable customer name of your choosing. For display purposes.",
 				CustomerProduction:     true,
-				CustomerConsumerKey:    "The consumer key you got from ETrade. Change the above boolean to reflect whether this is a sandbox (false) or production (true) key",
+				CustomerConsumerKey:    "The consumer key you got from ETrade. Change the above boolean to reflect whether this is a sandbox (false) or production (true) key. You may use a reference such as env:NAME, file:/path, or cmd:command instead of the key itself",
 				CustomerConsumerSecret: "The consumer secret you got from ETrade. Request a sandbox key/secret here: https://us.etrade.com/etx/ris/apikey or request a prod key/secret here: https://us.etrade.com/etx/ris/apisurvey/#/questionnaire",
 			},
 		},
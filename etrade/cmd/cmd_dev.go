@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type CommandDev struct {
+}
+
+func (c *CommandDev) Command(globalFlags *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Developer tools",
+		Long:  "Tools for developing and testing against a stand-in for the ETrade API",
+	}
+	// Add Subcommands
+	cmd.AddCommand((&CommandDevFakeServer{}).Command(globalFlags))
+	return cmd
+}
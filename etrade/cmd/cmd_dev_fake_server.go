@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/fakeetrade"
+	"github.com/spf13/cobra"
+	"net/http"
+	"os"
+	"os/signal"
+)
+
+type commandDevFakeServerFlags struct {
+	listenAddr  string
+	fixtureFile string
+}
+
+type CommandDevFakeServer struct {
+	context CommandContext
+	flags   commandDevFakeServerFlags
+}
+
+func (c *CommandDevFakeServer) Command(globalFlags *globalFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fake-server",
+		Short: "Run a fake ETrade API server",
+		Long: "Run a fake ETrade API server that serves accounts, orders, alerts, and market data from a JSON " +
+			"fixture. Point a customer's customerApiBaseUrl and customerAuthorizeUrl at it to use the CLI or " +
+			"server without an ETrade account.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmdContext, err := NewCommandContextFromFlags(globalFlags)
+			if err != nil {
+				return err
+			}
+			c.context = *cmdContext
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return c.context.Close()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state := fakeetrade.DefaultState()
+			if c.flags.fixtureFile != "" {
+				var err error
+				state, err = fakeetrade.LoadStateFromFile(c.flags.fixtureFile)
+				if err != nil {
+					return fmt.Errorf("unable to load fixture file %s: %w", c.flags.fixtureFile, err)
+				}
+			}
+
+			_, _ = fmt.Fprintf(os.Stderr, "Starting fake ETrade server on: \"%s\"\n", c.flags.listenAddr)
+			_, _ = fmt.Fprintf(
+				os.Stderr, "Authorize URL path: \"%s\" (consumer keys and access tokens come from the fixture)\n",
+				fakeetrade.AuthorizePath,
+			)
+
+			server := &http.Server{
+				Addr:    c.flags.listenAddr,
+				Handler: fakeetrade.NewServer(state, c.context.Logger),
+			}
+
+			idleConnsClosed := make(chan struct{})
+			go func() {
+				sigint := make(chan os.Signal, 1)
+				signal.Notify(sigint, os.Interrupt)
+				<-sigint
+
+				// Shut down upon receiving an interrupt signal
+				if err := server.Shutdown(context.Background()); err != nil {
+					// Error from closing listeners, or context timeout:
+					c.context.Logger.Error(fmt.Errorf("http server Shutdown() failed (%w)", err).Error())
+				}
+				close(idleConnsClosed)
+			}()
+
+			if err := server.ListenAndServe(); err != http.ErrServerClosed {
+				// Error starting or closing listener:
+				c.context.Logger.Error(fmt.Errorf("http server ListenAndServe() failed (%w)", err).Error())
+				return err
+			}
+
+			<-idleConnsClosed
+			return nil
+		},
+	}
+	// Add Flags
+	cmd.Flags().StringVarP(&c.flags.listenAddr, "addr", "a", ":8889", "server listen address:port")
+	cmd.Flags().StringVar(
+		&c.flags.fixtureFile, "fixture", "", "JSON fixture file to serve (defaults to the built-in fixture)",
+	)
+	return cmd
+}
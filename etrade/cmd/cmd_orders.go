@@ -27,5 +27,10 @@ func (c *CommandOrders) Command(globalFlags *globalFlags) *cobra.Command {
 	}
 	// Add Subcommands
 	cmd.AddCommand((&CommandOrdersList{Context: &c.context}).Command())
+	cmd.AddCommand((&CommandOrdersPreview{Context: &c.context}).Command())
+	cmd.AddCommand((&CommandOrdersPlace{Context: &c.context}).Command())
+	cmd.AddCommand((&CommandOrdersCancel{Context: &c.context}).Command())
+	cmd.AddCommand((&CommandOrdersChange{Context: &c.context}).Command())
+	cmd.AddCommand((&CommandOrdersSubmit{Context: &c.context}).Command())
 	return cmd
 }
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+type ordersCancelFlags struct {
+	allOpen bool
+	symbols []string
+}
+
+type CommandOrdersCancel struct {
+	Context *CommandContextWithClient
+	flags   ordersCancelFlags
+}
+
+func (c *CommandOrdersCancel) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel [account ID] <order ID> ...",
+		Short: "Cancel orders",
+		Long:  "Cancel one or more orders by ID, or all open orders for one or more symbols",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if c.flags.allOpen {
+				if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+					return err
+				}
+				if len(c.flags.symbols) == 0 {
+					return errors.New("--all-open requires at least one --symbol")
+				}
+				return nil
+			}
+			if len(c.flags.symbols) > 0 {
+				return errors.New("--symbol may only be used with --all-open")
+			}
+			return cobra.MinimumNArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountId := args[0]
+			var response jsonmap.JsonMap
+			var err error
+			if c.flags.allOpen {
+				response, err = CancelOpenOrders(c.Context.Client, accountId, c.flags.symbols)
+			} else {
+				orderIds := make([]int64, 0, len(args)-1)
+				for _, arg := range args[1:] {
+					orderId, err := strconv.ParseInt(arg, 10, 64)
+					if err != nil {
+						return fmt.Errorf("order ID '%s' must be a number", arg)
+					}
+					orderIds = append(orderIds, orderId)
+				}
+				response, err = CancelOrders(c.Context.Client, accountId, orderIds)
+			}
+			if err != nil {
+				return err
+			}
+			if err = c.Context.Renderer.Render(response, cancelOrdersDescriptor); err != nil {
+				return err
+			}
+			// Exit with an error if any order failed to cancel so that scripts
+			// can detect that orders may still be resting.
+			if response[cancelOrdersStatusKey] != "success" {
+				return errors.New("some orders could not be cancelled")
+			}
+			return nil
+		},
+	}
+
+	// Add Flags
+	cmd.Flags().BoolVarP(&c.flags.allOpen, "all-open", "a", false, "cancel all open orders for the given symbols")
+	cmd.Flags().StringSliceVarP(
+		&c.flags.symbols, "symbol", "s", []string{}, "symbol for which to cancel open orders (may be repeated)",
+	)
+
+	return cmd
+}
+
+var cancelOrdersDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: "",
+		Values: []RenderValue{
+			{Header: "Status", Path: ".status"},
+			{Header: "Error Message", Path: ".error"},
+		},
+		SubObjects: []RenderDescriptor{
+			{
+				ObjectPath: ".cancelledOrders",
+				Values: []RenderValue{
+					{Header: "Cancelled Order Id", Path: ".orderId"},
+					{Header: "Cancel Time", Path: ".cancelTime", Transformer: dateTimeTransformerMs},
+				},
+				SubObjects:   nil,
+				DefaultValue: "",
+				SpaceAfter:   false,
+			},
+			{
+				ObjectPath: ".failedOrders",
+				Values: []RenderValue{
+					{Header: "Failed Order Id", Path: ".orderId"},
+					{Header: "Error", Path: ".error"},
+				},
+				SubObjects:   nil,
+				DefaultValue: "",
+				SpaceAfter:   false,
+			},
+		},
+		DefaultValue: "",
+		SpaceAfter:   false,
+	},
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+type ordersChangeFlags struct {
+	limitPrice  decimalFlagValue
+	stopPrice   decimalFlagValue
+	quantity    int
+	orderTerm   enumFlagValue[constants.OrderTerm]
+	previewOnly bool
+}
+
+type CommandOrdersChange struct {
+	Context *CommandContextWithClient
+	flags   ordersChangeFlags
+}
+
+func (c *CommandOrdersChange) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "change [account ID] [order ID]",
+		Short: "Change an open order",
+		Long: "Change the limit price, stop price, quantity, or term of an open order. " +
+			"The change is previewed and then placed.",
+		Args: cobra.MatchAll(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountId := args[0]
+			orderId, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errors.New("order ID must be a number")
+			}
+			changes := OrderChanges{
+				OrderTerm: c.flags.orderTerm.Value(),
+			}
+			if cmd.Flags().Changed("limit-price") {
+				changes.LimitPrice = &c.flags.limitPrice.DecimalValue
+			}
+			if cmd.Flags().Changed("stop-price") {
+				changes.StopPrice = &c.flags.stopPrice.DecimalValue
+			}
+			if cmd.Flags().Changed("quantity") {
+				changes.Quantity = &c.flags.quantity
+			}
+			if changes.LimitPrice == nil && changes.StopPrice == nil && changes.Quantity == nil &&
+				changes.OrderTerm == constants.OrderTermNil {
+				return errors.New("no changes specified")
+			}
+
+			response, err := ChangeOrder(c.Context.Client, accountId, orderId, &changes, c.flags.previewOnly)
+			if err != nil {
+				return err
+			}
+			if c.flags.previewOnly {
+				return c.Context.Renderer.Render(response, orderPreviewDescriptor)
+			}
+			return c.Context.Renderer.Render(response, orderPlacedDescriptor)
+		},
+	}
+
+	// Add Flags
+	cmd.Flags().VarP(&c.flags.limitPrice, "limit-price", "l", "new limit price")
+	cmd.Flags().VarP(&c.flags.stopPrice, "stop-price", "p", "new stop price")
+	cmd.Flags().IntVarP(&c.flags.quantity, "quantity", "q", 0, "new quantity")
+	cmd.Flags().BoolVar(&c.flags.previewOnly, "preview-only", false, "preview the change without placing it")
+
+	// Initialize Enum Flag Values
+	c.flags.orderTerm = *newEnumFlagValue(orderTermMap, constants.OrderTermNil)
+
+	// Add Enum Flags
+	cmd.Flags().VarP(
+		&c.flags.orderTerm, "term", "t",
+		fmt.Sprintf("new order term (%s)", c.flags.orderTerm.JoinAllowedValues(", ")),
+	)
+	_ = cmd.RegisterFlagCompletionFunc(
+		"term",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return c.flags.orderTerm.AllowedValuesWithHelp(), cobra.ShellCompDirectiveDefault
+		},
+	)
+
+	return cmd
+}
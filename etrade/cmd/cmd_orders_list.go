@@ -16,6 +16,7 @@ type ordersListFlags struct {
 	transactionType enumFlagValue[constants.OrderTransactionType]
 	marketSession   enumFlagValue[constants.MarketSession]
 	symbols         string
+	maxItems        int
 }
 
 type CommandOrdersList struct {
@@ -47,11 +48,12 @@ func (c *CommandOrdersList) Command() *cobra.Command {
 					return errors.New("to date must be in format MMDDYYYY")
 				}
 			}
-			if response, err := ListOrders(
+			if stream, err := StreamOrders(
 				c.Context.Client, accountId, c.flags.status.Value(), fromDate, toDate, symbols,
 				c.flags.securityType.Value(), c.flags.transactionType.Value(), c.flags.marketSession.Value(),
+				c.flags.maxItems,
 			); err == nil {
-				return c.Context.Renderer.Render(response, orderListDescriptor)
+				return c.Context.Renderer.RenderStream(cmd.Context(), stream, orderListDescriptor)
 			} else {
 				return err
 			}
@@ -61,6 +63,7 @@ func (c *CommandOrdersList) Command() *cobra.Command {
 	// Add Flags
 	cmd.Flags().StringVarP(&c.flags.fromDate, "from-date", "f", "", "from date (MMDDYYYY)")
 	cmd.Flags().StringVarP(&c.flags.toDate, "to-date", "t", "", "to date (MMDDYYYY)")
+	cmd.Flags().IntVarP(&c.flags.maxItems, "max-items", "n", 0, "maximum number of orders to list (0 for all)")
 
 	// Initialize Enum Flag Values
 	c.flags.status = *newEnumFlagValue(orderStatusMap, constants.OrderStatusNil)
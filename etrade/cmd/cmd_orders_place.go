@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+type CommandOrdersPlace struct {
+	Context *CommandContextWithClient
+	flags   orderFlags
+}
+
+func (c *CommandOrdersPlace) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "place [account ID] [preview ID]",
+		Short: "Place an order",
+		Long: "Place a previously-previewed equity or option order. The order must be described with the same " +
+			"flags and client order ID that were used to preview it.",
+		Args: cobra.MatchAll(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountId := args[0]
+			previewId, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errors.New("preview ID must be a number")
+			}
+			order, err := c.flags.orderRequest()
+			if err != nil {
+				return err
+			}
+			if response, err := PlaceOrder(c.Context.Client, accountId, previewId, order); err == nil {
+				return c.Context.Renderer.Render(response, orderPlacedDescriptor)
+			} else {
+				return err
+			}
+		},
+	}
+
+	c.flags.addFlags(cmd)
+	_ = cmd.MarkFlagRequired("client-order-id")
+
+	return cmd
+}
+
+var orderPlacedDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: "",
+		Values: []RenderValue{
+			{Header: "Account Id", Path: ".accountId"},
+			{Header: "Client Order Id", Path: ".clientOrderId"},
+			{Header: "Order Type", Path: ".orderType"},
+			{Header: "Placed Time", Path: ".placedTime", Transformer: dateTimeTransformerMs},
+		},
+		SubObjects: []RenderDescriptor{
+			{
+				ObjectPath: ".orderIds",
+				Values: []RenderValue{
+					{Header: "Order Id", Path: ".orderId"},
+				},
+				SubObjects:   nil,
+				DefaultValue: "",
+				SpaceAfter:   false,
+			},
+			orderDetailDescriptor,
+		},
+		DefaultValue: "",
+		SpaceAfter:   false,
+	},
+}
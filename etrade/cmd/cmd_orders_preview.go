@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/spf13/cobra"
+)
+
+type CommandOrdersPreview struct {
+	Context *CommandContextWithClient
+	flags   orderFlags
+}
+
+func (c *CommandOrdersPreview) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preview [account ID]",
+		Short: "Preview an order",
+		Long: "Preview an equity or option order. The resulting preview ID and client order ID are required " +
+			"to place the order.",
+		Args: cobra.MatchAll(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountId := args[0]
+			order, err := c.flags.orderRequest()
+			if err != nil {
+				return err
+			}
+			if order.ClientOrderId == "" {
+				order.ClientOrderId, err = client.NewClientOrderId()
+				if err != nil {
+					return err
+				}
+			}
+			if response, err := PreviewOrder(c.Context.Client, accountId, order); err == nil {
+				return c.Context.Renderer.Render(response, orderPreviewDescriptor)
+			} else {
+				return err
+			}
+		},
+	}
+
+	c.flags.addFlags(cmd)
+
+	return cmd
+}
+
+var orderPreviewDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: "",
+		Values: []RenderValue{
+			{Header: "Account Id", Path: ".accountId"},
+			{Header: "Client Order Id", Path: ".clientOrderId"},
+			{Header: "Order Type", Path: ".orderType"},
+			{Header: "Total Order Value", Path: ".totalOrderValue"},
+			{Header: "Total Commission", Path: ".totalCommission"},
+			{Header: "Preview Time", Path: ".previewTime", Transformer: dateTimeTransformerMs},
+		},
+		SubObjects: []RenderDescriptor{
+			{
+				ObjectPath: ".previewIds",
+				Values: []RenderValue{
+					{Header: "Preview Id", Path: ".previewId"},
+				},
+				SubObjects:   nil,
+				DefaultValue: "",
+				SpaceAfter:   false,
+			},
+			orderDetailDescriptor,
+		},
+		DefaultValue: "",
+		SpaceAfter:   false,
+	},
+}
+
+// orderDetailDescriptor renders the order details that are common to
+// preview and place responses.
+var orderDetailDescriptor = RenderDescriptor{
+	ObjectPath: ".order",
+	Values: []RenderValue{
+		{Header: "Price Type", Path: ".priceType"},
+		{Header: "Order Term", Path: ".orderTerm"},
+		{Header: "Limit Price", Path: ".limitPrice"},
+		{Header: "Stop Price", Path: ".stopPrice"},
+		{Header: "Market Session", Path: ".marketSession"},
+		{Header: "All Or None", Path: ".allOrNone"},
+		{Header: "Estimated Commission", Path: ".estimatedCommission"},
+		{Header: "Estimated Total Amount", Path: ".estimatedTotalAmount"},
+	},
+	SubObjects: []RenderDescriptor{
+		{
+			ObjectPath: ".instrument",
+			Values: []RenderValue{
+				{Header: "Symbol", Path: ".product.symbol"},
+				{Header: "SecurityType", Path: ".product.securityType"},
+				{Header: "Call/Put", Path: ".product.callPut"},
+				{Header: "Expiry Year", Path: ".product.expiryYear"},
+				{Header: "Expiry Month", Path: ".product.expiryMonth"},
+				{Header: "Expiry Day", Path: ".product.expiryDay"},
+				{Header: "Strike Price", Path: ".product.strikePrice"},
+				{Header: "Symbol Description", Path: ".symbolDescription"},
+				{Header: "Order Action", Path: ".orderAction"},
+				{Header: "Quantity Type", Path: ".quantityType"},
+				{Header: "Quantity", Path: ".quantity"},
+			},
+			SubObjects:   nil,
+			DefaultValue: "",
+			SpaceAfter:   false,
+		},
+	},
+	DefaultValue: "",
+	SpaceAfter:   true,
+}
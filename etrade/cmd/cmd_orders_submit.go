@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+type ordersSubmitFlags struct {
+	fileName        string
+	yes             bool
+	journalFileName string
+}
+
+type CommandOrdersSubmit struct {
+	Context *CommandContextWithClient
+	flags   ordersSubmitFlags
+}
+
+func (c *CommandOrdersSubmit) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Submit orders from a file",
+		Long: "Preview and place the orders in a JSON or YAML order file. All orders are validated and " +
+			"previewed before any are placed. Orders are placed only after confirmation or with --yes, and " +
+			"each placement is recorded in a journal file.",
+		Args: cobra.MatchAll(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			submissions, err := LoadOrderFile(c.flags.fileName)
+			if err != nil {
+				return err
+			}
+
+			previews, err := PreviewOrderSubmissions(c.Context.Client, submissions)
+			if err != nil {
+				return err
+			}
+			if err = c.Context.Renderer.Render(previews, submitOrdersPreviewDescriptor); err != nil {
+				return err
+			}
+
+			if !c.flags.yes && !confirmOrderSubmission(len(submissions)) {
+				return errors.New("order submission cancelled; no orders were placed")
+			}
+
+			journalFileName := c.flags.journalFileName
+			if journalFileName == "" {
+				journalFileName = c.Context.ConfigurationFolder.GetOrderJournalFilePath()
+			}
+			journalFile, err := OpenOrderJournalFile(journalFileName)
+			if err != nil {
+				return fmt.Errorf("unable to open order journal %s (%w); no orders were placed", journalFileName, err)
+			}
+			defer func() { _ = journalFile.Close() }()
+
+			response, err := PlaceOrderSubmissions(c.Context.Client, submissions, journalFile)
+			if err != nil {
+				return err
+			}
+			if err = c.Context.Renderer.Render(response, submitOrdersPlacedDescriptor); err != nil {
+				return err
+			}
+			if response[submitOrdersStatusKey] != "success" {
+				return errors.New("some orders could not be placed")
+			}
+			return nil
+		},
+	}
+
+	// Add Flags
+	cmd.Flags().StringVarP(&c.flags.fileName, "file", "f", "", "JSON or YAML file containing the orders to submit")
+	cmd.Flags().BoolVarP(&c.flags.yes, "yes", "y", false, "place the orders without asking for confirmation")
+	cmd.Flags().StringVarP(
+		&c.flags.journalFileName, "journal", "j", "",
+		"journal file to which placed orders are appended (default ~/.etrade/orders.journal)",
+	)
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func confirmOrderSubmission(orderCount int) bool {
+	_, _ = fmt.Fprintf(os.Stderr, "Place %d order(s)? Type 'yes' to confirm: ", orderCount)
+	var response string
+	// Scanln returns an error on an empty line, which is treated the same
+	// as declining.
+	_, _ = fmt.Scanln(&response)
+	return strings.ToLower(strings.TrimSpace(response)) == "yes"
+}
+
+var submitOrdersPreviewDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: ".previews",
+		Values: []RenderValue{
+			{Header: "Account Id", Path: ".accountId"},
+			{Header: "Client Order Id", Path: ".clientOrderId"},
+			{Header: "Order Type", Path: ".orderType"},
+			{Header: "Preview Id", Path: ".previewIds[0].previewId"},
+			{Header: "Total Order Value", Path: ".totalOrderValue"},
+			{Header: "Total Commission", Path: ".totalCommission"},
+		},
+		SubObjects:   []RenderDescriptor{orderDetailDescriptor},
+		DefaultValue: "",
+		SpaceAfter:   true,
+	},
+}
+
+var submitOrdersPlacedDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: "",
+		Values: []RenderValue{
+			{Header: "Status", Path: ".status"},
+			{Header: "Error Message", Path: ".error"},
+		},
+		SubObjects: []RenderDescriptor{
+			{
+				ObjectPath: ".placedOrders",
+				Values: []RenderValue{
+					{Header: "Account Id", Path: ".accountId"},
+					{Header: "Client Order Id", Path: ".clientOrderId"},
+					{Header: "Order Id", Path: ".orderIds[0].orderId"},
+					{Header: "Placed Time", Path: ".placedTime", Transformer: dateTimeTransformerMs},
+				},
+				SubObjects:   nil,
+				DefaultValue: "",
+				SpaceAfter:   false,
+			},
+			{
+				ObjectPath: ".failedOrders",
+				Values: []RenderValue{
+					{Header: "Failed Client Order Id", Path: ".clientOrderId"},
+					{Header: "Error", Path: ".error"},
+				},
+				SubObjects:   nil,
+				DefaultValue: "",
+				SpaceAfter:   false,
+			},
+		},
+		DefaultValue: "",
+		SpaceAfter:   false,
+	},
+}
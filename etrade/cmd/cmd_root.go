@@ -23,6 +23,19 @@ func (c *RootCommand) Command() *cobra.Command {
 	cmd.PersistentFlags().StringVar(
 		&c.globalFlags.outputFileName, "output-file", "", "write output to specified file instead of stdout",
 	)
+	cmd.PersistentFlags().StringVar(
+		&c.globalFlags.apiBaseUrl, "api-base-url", "",
+		"send API requests to this base URL instead of ETrade's (e.g. a proxy or a local mock server)",
+	)
+	cmd.PersistentFlags().StringVar(
+		&c.globalFlags.recordDir, "record", "",
+		"record API requests and responses, with credentials and account IDs scrubbed, to a file in this folder",
+	)
+	cmd.PersistentFlags().StringVar(
+		&c.globalFlags.replayDir, "replay", "",
+		"replay API responses recorded with --record from a file in this folder instead of sending requests",
+	)
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
 
 	// Initialize Global Enum Flag Values
 	c.globalFlags.outputFormat = *newEnumFlagValue(outputFormatMap, outputFormatCsv)
@@ -47,6 +60,7 @@ func (c *RootCommand) Command() *cobra.Command {
 	cmd.AddCommand((&CommandAuth{}).Command(&c.globalFlags))
 	cmd.AddCommand((&CommandCfg{}).Command(&c.globalFlags))
 	cmd.AddCommand((&CommandServer{}).Command(&c.globalFlags))
+	cmd.AddCommand((&CommandDev{}).Command(&c.globalFlags))
 
 	return cmd
 }
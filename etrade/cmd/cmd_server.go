@@ -10,7 +10,13 @@ import (
 )
 
 type commandServerFlags struct {
-	listenAddr string
+	listenAddr     string
+	tlsCertFile    string
+	tlsKeyFile     string
+	clientCaFile   string
+	unixSocketPath string
+	unixSocketMode string
+	insecureNoAuth bool
 }
 
 type CommandServer struct {
@@ -35,13 +41,28 @@ func (c *CommandServer) Command(globalFlags *globalFlags) *cobra.Command {
 			return c.context.Close()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-
-			_, _ = fmt.Fprintf(os.Stderr, "Starting server on: \"%s\"\n", c.flags.listenAddr)
-
-			server := NewETradeServer(
+			tlsConfig, err := newServerTlsConfig(c.flags.tlsCertFile, c.flags.tlsKeyFile, c.flags.clientCaFile)
+			if err != nil {
+				return err
+			}
+			server, err := NewETradeServer(
 				c.flags.listenAddr, c.context.Logger, c.context.ConfigurationFolder,
-				c.context.CustomerConfigurationStore,
+				c.context.CustomerConfigurationStore, c.flags.insecureNoAuth,
 			)
+			if err != nil {
+				return err
+			}
+			server.TLSConfig = tlsConfig
+			listener, err := newServerListener(c.flags.listenAddr, c.flags.unixSocketPath, c.flags.unixSocketMode)
+			if err != nil {
+				return err
+			}
+
+			scheme := "http"
+			if tlsConfig != nil {
+				scheme = "https"
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "Starting server (%s) on: \"%s\"\n", scheme, listener.Addr().String())
 
 			idleConnsClosed := make(chan struct{})
 			go func() {
@@ -57,9 +78,16 @@ func (c *CommandServer) Command(globalFlags *globalFlags) *cobra.Command {
 				close(idleConnsClosed)
 			}()
 
-			if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			if tlsConfig != nil {
+				// The certificate and key are already in the server's TLS
+				// configuration.
+				err = server.ServeTLS(listener, "", "")
+			} else {
+				err = server.Serve(listener)
+			}
+			if err != http.ErrServerClosed {
 				// Error starting or closing listener:
-				c.context.Logger.Error(fmt.Errorf("http server ListenAndServe() failed (%w)", err).Error())
+				c.context.Logger.Error(fmt.Errorf("http server Serve() failed (%w)", err).Error())
 				return err
 			}
 
@@ -69,5 +97,23 @@ func (c *CommandServer) Command(globalFlags *globalFlags) *cobra.Command {
 	}
 	// Add Flags
 	cmd.Flags().StringVarP(&c.flags.listenAddr, "addr", "a", ":8888", "server listen address:port")
+	cmd.Flags().StringVar(&c.flags.tlsCertFile, "tls-cert", "", "serve HTTPS with this PEM certificate file")
+	cmd.Flags().StringVar(&c.flags.tlsKeyFile, "tls-key", "", "PEM private key file for --tls-cert")
+	cmd.Flags().StringVar(
+		&c.flags.clientCaFile, "client-ca", "",
+		"require clients to present a certificate signed by a CA in this PEM file (mutual TLS)",
+	)
+	cmd.Flags().StringVar(
+		&c.flags.unixSocketPath, "unix-socket", "", "listen on a Unix domain socket at this path instead of --addr",
+	)
+	cmd.Flags().StringVar(
+		&c.flags.unixSocketMode, "unix-socket-mode", defaultUnixSocketMode, "octal file mode for --unix-socket",
+	)
+	cmd.Flags().BoolVar(
+		&c.flags.insecureNoAuth, "insecure-no-auth", false,
+		"run without authentication when no customer has server keys (anyone who can connect can use every customer)",
+	)
+	cmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
+	cmd.MarkFlagsMutuallyExclusive("addr", "unix-socket")
 	return cmd
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"golang.org/x/exp/slog"
 	"os"
+	"path/filepath"
+	"time"
 )
 
 type CommandContext struct {
@@ -22,9 +24,11 @@ type CommandContextWithStore struct {
 }
 
 type CommandContextWithClient struct {
-	Logger   *slog.Logger
-	Renderer Renderer
-	Client   client.ETradeClient
+	Logger              *slog.Logger
+	Renderer            Renderer
+	ConfigurationFolder ConfigurationFolder
+	Client              client.ETradeClient
+	CredentialStore     CredentialStore
 }
 
 func NewCommandContextFromFlags(flags *globalFlags) (*CommandContext, error) {
@@ -103,6 +107,11 @@ func NewCommandContextWithStoreFromFlags(flags *globalFlags) (*CommandContextWit
 			context.ConfigurationFolder.GetConfigurationFilePath(), err,
 		)
 	}
+	if flags.apiBaseUrl != "" {
+		customerConfigurationStore.SetApiBaseUrlOverride(flags.apiBaseUrl)
+	}
+	customerConfigurationStore.SetHttpRecordDir(flags.recordDir)
+	customerConfigurationStore.SetHttpReplayDir(flags.replayDir)
 
 	return &CommandContextWithStore{
 		Logger:                     context.Logger,
@@ -122,32 +131,49 @@ func NewCommandContextWithClientFromFlags(flags *globalFlags) (*CommandContextWi
 		return nil, err
 	}
 
-	eTradeClient, err := NewETradeClientForCustomer(
-		flags.customerId, context.ConfigurationFolder, context.CustomerConfigurationStore, context.Logger,
+	eTradeClient, credentialStore, err := NewETradeClientForCustomer(
+		flags.customerId, context.ConfigurationFolder, context.CustomerConfigurationStore, nil, context.Logger,
 	)
 	if err != nil {
 		return nil, err
 	}
+	// Renew the access token if it's gone idle, so that commands don't fail
+	// with an authorization error.
+	if _, err = RenewIdleAuth(flags.customerId, eTradeClient, credentialStore, time.Now()); err != nil {
+		return nil, err
+	}
 	return &CommandContextWithClient{
-		Logger:   context.Logger,
-		Renderer: context.Renderer,
-		Client:   eTradeClient,
+		Logger:              context.Logger,
+		Renderer:            context.Renderer,
+		ConfigurationFolder: context.ConfigurationFolder,
+		Client:              eTradeClient,
+		CredentialStore:     credentialStore,
 	}, nil
 }
 
+// NewETradeClientForCustomer creates a client for a customer, using the
+// access token in the customer's credential store, and returns the client
+// along with the credential store. The client's requests are limited by the
+// given rate limiter or, if it is nil, by a new rate limiter configured from
+// the customer's rate limits.
 func NewETradeClientForCustomer(
-	customerId string, cfgFolder ConfigurationFolder, cfgStore *CustomerConfigurationStore, logger *slog.Logger,
-) (client.ETradeClient, error) {
+	customerId string, cfgFolder ConfigurationFolder, cfgStore *CustomerConfigurationStore,
+	rateLimiter *client.RateLimiter, logger *slog.Logger,
+) (client.ETradeClient, CredentialStore, error) {
 	if customerId == "" {
-		return nil, errors.New("customer id must be specified with --customer-id flag")
+		return nil, nil, errors.New("customer id must be specified with --customer-id flag")
 	}
-	customerConfig, err := cfgStore.GetCustomerConfigurationById(customerId)
+	customerConfig, err := resolveCustomerConfiguration(customerId, cfgStore)
 	if err != nil {
-		return nil, fmt.Errorf("customer id '%s' not found in config file", customerId)
+		return nil, nil, err
+	}
+	credentialStore, err := newCredentialStore(customerId, customerConfig, cfgFolder, logger)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Try loading cached credentials
-	cachedCredentials, err := cfgFolder.LoadCachedCredentialsFromFile(customerConfig.CustomerConsumerKey, logger)
+	cachedCredentials, err := credentialStore.LoadCredentials()
 	if err != nil {
 		// If loading cached credentials fails, then create a new, empty
 		// credential cache. It will yield empty strings for the cached token,
@@ -155,10 +181,52 @@ func NewETradeClientForCustomer(
 		// customer.
 		cachedCredentials = &CachedCredentials{}
 	}
-	return client.CreateETradeClient(
-		logger, customerConfig.CustomerProduction, customerConfig.CustomerConsumerKey,
+	if rateLimiter == nil {
+		rateLimiter = client.NewRateLimiter(customerConfig.ClientRateLimits())
+	}
+	urls, err := customerConfig.ClientEndpointUrls()
+	if err != nil {
+		return nil, nil, err
+	}
+	httpClient, err := newHttpClientForCustomer(customerId, cfgStore)
+	if err != nil {
+		return nil, nil, err
+	}
+	eTradeClient, err := client.CreateETradeClient(
+		logger, urls, customerConfig.CustomerConsumerKey,
 		customerConfig.CustomerConsumerSecret, cachedCredentials.AccessToken, cachedCredentials.AccessSecret,
+		customerConfig.ClientRetryPolicy(), rateLimiter, httpClient,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Every client is wrapped with the customer's risk limits so that no
+	// command or server route can place an order without them.
+	return client.NewRiskLimitedETradeClient(eTradeClient, customerConfig.ClientRiskLimits()), credentialStore, nil
+}
+
+// newHttpClientForCustomer creates an HTTP client that records to or replays
+// from the customer's cassette file, if the store was set up to record or
+// replay. Otherwise, it returns nil so that the client uses the default HTTP
+// client.
+func newHttpClientForCustomer(customerId string, cfgStore *CustomerConfigurationStore) (client.HttpClient, error) {
+	if cfgStore.httpReplayDir != "" {
+		filename := filepath.Join(cfgStore.httpReplayDir, customerId+".json")
+		replayClient, err := client.NewReplayHttpClient(filename)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load recorded responses from %s (%w)", filename, err)
+		}
+		return replayClient, nil
+	}
+	if cfgStore.httpRecordDir != "" {
+		filename := filepath.Join(cfgStore.httpRecordDir, customerId+".json")
+		recordingClient, err := client.NewRecordingHttpClient(nil, filename)
+		if err != nil {
+			return nil, fmt.Errorf("unable to record responses to %s (%w)", filename, err)
+		}
+		return recordingClient, nil
+	}
+	return nil, nil
 }
 
 func (c *CommandContextWithClient) Close() error {
@@ -53,3 +53,8 @@ func (f ConfigurationFolder) GetFileCachePathForCustomer(customerConsumerKey str
 	cacheFilePath := filepath.Join(string(f), ".etrade", cacheFileName)
 	return cacheFilePath
 }
+
+func (f ConfigurationFolder) GetOrderJournalFilePath() string {
+	journalFilePath := filepath.Join(string(f), ".etrade", "orders.journal")
+	return journalFilePath
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"golang.org/x/exp/slog"
+	"time"
+)
+
+// CredentialStore persists a customer's cached credentials between runs.
+type CredentialStore interface {
+	LoadCredentials() (*CachedCredentials, error)
+	SaveCredentials(credentials *CachedCredentials) error
+	RemoveCredentials() error
+}
+
+// Credential store types that may be configured for a customer.
+const (
+	// credentialStoreTypePlain stores credentials as plaintext JSON in the
+	// configuration folder. This is the default.
+	credentialStoreTypePlain = "plain"
+	// credentialStoreTypeEncrypted stores credentials in the configuration
+	// folder, encrypted with a passphrase.
+	credentialStoreTypeEncrypted = "encrypted"
+	// credentialStoreTypeCommand stores credentials with external commands,
+	// such as those of a password manager or secrets vault.
+	credentialStoreTypeCommand = "command"
+)
+
+// NewCredentialStoreForCustomer creates the credential store configured for
+// a customer.
+func NewCredentialStoreForCustomer(
+	customerId string, cfgFolder ConfigurationFolder, cfgStore *CustomerConfigurationStore, logger *slog.Logger,
+) (CredentialStore, error) {
+	customerConfig, err := resolveCustomerConfiguration(customerId, cfgStore)
+	if err != nil {
+		return nil, err
+	}
+	return newCredentialStore(customerId, customerConfig, cfgFolder, logger)
+}
+
+func newCredentialStore(
+	customerId string, customerConfig *CustomerConfiguration, cfgFolder ConfigurationFolder, logger *slog.Logger,
+) (CredentialStore, error) {
+	storeConfig := customerConfig.CustomerCredentialStore
+	if storeConfig == nil {
+		storeConfig = &CustomerCredentialStore{}
+	}
+	cacheFilePath := cfgFolder.GetFileCachePathForCustomer(customerConfig.CustomerConsumerKey)
+
+	switch storeConfig.Type {
+	case "", credentialStoreTypePlain:
+		return NewPlainCredentialStore(cacheFilePath, logger), nil
+	case credentialStoreTypeEncrypted:
+		if storeConfig.Passphrase == "" {
+			return nil, fmt.Errorf("the encrypted credential store for customer '%s' requires a passphrase", customerId)
+		}
+		passphrase, err := ResolveSecretReference(storeConfig.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the credential store passphrase for customer '%s' (%w)", customerId, err)
+		}
+		return NewEncryptedCredentialStore(cacheFilePath, passphrase, logger), nil
+	case credentialStoreTypeCommand:
+		if len(storeConfig.LoadCommand) == 0 || len(storeConfig.SaveCommand) == 0 ||
+			len(storeConfig.RemoveCommand) == 0 {
+			return nil, fmt.Errorf(
+				"the command credential store for customer '%s' requires load, save, and remove commands", customerId,
+			)
+		}
+		return NewCommandCredentialStore(
+			customerId, storeConfig.LoadCommand, storeConfig.SaveCommand, storeConfig.RemoveCommand,
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store type '%s' for customer '%s'", storeConfig.Type, customerId)
+	}
+}
+
+// UpdateCachedCredentials saves an access token that was just used to a
+// credential store, keeping the token's issue time if the store already
+// holds the same token.
+func UpdateCachedCredentials(
+	credentialStore CredentialStore, accessToken string, accessSecret string, now time.Time,
+) error {
+	// Missing or corrupt credentials are replaced, so a load error is
+	// ignored.
+	previous, _ := credentialStore.LoadCredentials()
+	return credentialStore.SaveCredentials(NewCachedCredentials(accessToken, accessSecret, previous, now))
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+)
+
+// commandCredentialStoreIdPlaceholder is replaced with the customer ID in
+// each credential store command argument.
+const commandCredentialStoreIdPlaceholder = "{customerId}"
+
+type commandCredentialStore struct {
+	customerId    string
+	loadCommand   []string
+	saveCommand   []string
+	removeCommand []string
+}
+
+// NewCommandCredentialStore creates a credential store that keeps
+// credentials with external commands, such as a password manager's command
+// line interface. The load command must print the credentials JSON that the
+// save command receives on its standard input. Any "{customerId}" in a
+// command argument is replaced with the customer ID. For example, with pass:
+//
+//	load:   pass show etrade/{customerId}
+//	save:   pass insert --multiline --force etrade/{customerId}
+//	remove: pass rm --force etrade/{customerId}
+func NewCommandCredentialStore(
+	customerId string, loadCommand []string, saveCommand []string, removeCommand []string,
+) CredentialStore {
+	return &commandCredentialStore{
+		customerId:    customerId,
+		loadCommand:   loadCommand,
+		saveCommand:   saveCommand,
+		removeCommand: removeCommand,
+	}
+}
+
+func (s *commandCredentialStore) LoadCredentials() (*CachedCredentials, error) {
+	output, err := runSecretCommand(s.expandCommand(s.loadCommand), nil)
+	if err != nil {
+		return nil, err
+	}
+	return LoadCachedCredentials(bytes.NewReader(output))
+}
+
+func (s *commandCredentialStore) SaveCredentials(credentials *CachedCredentials) error {
+	var input bytes.Buffer
+	if err := SaveCachedCredentials(&input, credentials); err != nil {
+		return err
+	}
+	_, err := runSecretCommand(s.expandCommand(s.saveCommand), input.Bytes())
+	return err
+}
+
+func (s *commandCredentialStore) RemoveCredentials() error {
+	_, err := runSecretCommand(s.expandCommand(s.removeCommand), nil)
+	return err
+}
+
+func (s *commandCredentialStore) expandCommand(command []string) []string {
+	expanded := make([]string, 0, len(command))
+	for _, arg := range command {
+		expanded = append(expanded, strings.ReplaceAll(arg, commandCredentialStoreIdPlaceholder, s.customerId))
+	}
+	return expanded
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/exp/slog"
+	"os"
+	"path/filepath"
+)
+
+// scrypt parameters for deriving the encryption key from the passphrase.
+// These are the parameters recommended for interactive logins as of 2017.
+// They are recorded in each file so that they may be changed in the future
+// without breaking existing files.
+const (
+	encryptedCredentialsKdfN    = 1 << 15
+	encryptedCredentialsKdfR    = 8
+	encryptedCredentialsKdfP    = 1
+	encryptedCredentialsKeyLen  = 32
+	encryptedCredentialsSaltLen = 16
+)
+
+// Limits on the scrypt parameters read from a credential file, so that a
+// corrupted or tampered file can't make loading it use an unbounded amount of
+// memory or time. scrypt uses about 128*N*r bytes of memory.
+const (
+	encryptedCredentialsMaxKdfMemory = 256 * 1024 * 1024
+	encryptedCredentialsMaxKdfP      = 16
+)
+
+// encryptedCredentialsFile is the layout of an encrypted credential file.
+// The ciphertext is the plaintext JSON credentials, encrypted with AES-256-GCM
+// using a key derived from the passphrase with scrypt. Byte slices are
+// base64-encoded in the JSON file.
+type encryptedCredentialsFile struct {
+	Kdf        string `json:"kdf"`
+	KdfN       int    `json:"kdfN"`
+	KdfR       int    `json:"kdfR"`
+	KdfP       int    `json:"kdfP"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type encryptedCredentialStore struct {
+	filename   string
+	passphrase string
+	logger     *slog.Logger
+}
+
+// NewEncryptedCredentialStore creates a credential store that keeps
+// credentials in the given file, encrypted with a passphrase.
+func NewEncryptedCredentialStore(filename string, passphrase string, logger *slog.Logger) CredentialStore {
+	return &encryptedCredentialStore{
+		filename:   filename,
+		passphrase: passphrase,
+		logger:     logger,
+	}
+}
+
+func (s *encryptedCredentialStore) LoadCredentials() (*CachedCredentials, error) {
+	fileBytes, err := os.ReadFile(s.filename)
+	if err != nil {
+		return nil, err
+	}
+	var file encryptedCredentialsFile
+	if err = json.Unmarshal(fileBytes, &file); err != nil {
+		return nil, fmt.Errorf("credential file %s is not an encrypted credential file (%w)", s.filename, err)
+	}
+	if file.Kdf != "scrypt" {
+		return nil, fmt.Errorf("credential file %s uses unsupported key derivation '%s'", s.filename, file.Kdf)
+	}
+	if err = checkKdfParameters(file.KdfN, file.KdfR, file.KdfP); err != nil {
+		return nil, fmt.Errorf("credential file %s has invalid key derivation parameters (%w)", s.filename, err)
+	}
+	aead, err := s.newAead(file.Salt, file.KdfN, file.KdfR, file.KdfP)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf(
+			"credential file %s has a %d-byte nonce, but %d bytes are required", s.filename, len(file.Nonce),
+			aead.NonceSize(),
+		)
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt credential file %s; the passphrase may be incorrect", s.filename)
+	}
+	return LoadCachedCredentials(bytes.NewReader(plaintext))
+}
+
+func (s *encryptedCredentialStore) SaveCredentials(credentials *CachedCredentials) error {
+	var plaintext bytes.Buffer
+	if err := SaveCachedCredentials(&plaintext, credentials); err != nil {
+		return err
+	}
+
+	file := encryptedCredentialsFile{
+		Kdf:  "scrypt",
+		KdfN: encryptedCredentialsKdfN,
+		KdfR: encryptedCredentialsKdfR,
+		KdfP: encryptedCredentialsKdfP,
+		Salt: make([]byte, encryptedCredentialsSaltLen),
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return err
+	}
+	aead, err := s.newAead(file.Salt, file.KdfN, file.KdfR, file.KdfP)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err = rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext.Bytes(), nil)
+
+	fileBytes, err := json.MarshalIndent(&file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(s.filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.filename, fileBytes, 0600)
+}
+
+func (s *encryptedCredentialStore) RemoveCredentials() error {
+	err := os.Remove(s.filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// checkKdfParameters checks that scrypt parameters are valid and within the
+// limits on the memory and time that deriving a key may take.
+func checkKdfParameters(n int, r int, p int) error {
+	if n <= 1 || n&(n-1) != 0 {
+		return fmt.Errorf("N must be a power of 2 greater than 1, not %d", n)
+	}
+	if r < 1 || p < 1 {
+		return fmt.Errorf("r and p must be at least 1, not %d and %d", r, p)
+	}
+	if p > encryptedCredentialsMaxKdfP {
+		return fmt.Errorf("p must be at most %d, not %d", encryptedCredentialsMaxKdfP, p)
+	}
+	if int64(n) > encryptedCredentialsMaxKdfMemory/128/int64(r) {
+		return fmt.Errorf("N=%d and r=%d require more than %d bytes of memory", n, r, encryptedCredentialsMaxKdfMemory)
+	}
+	return nil
+}
+
+func (s *encryptedCredentialStore) newAead(salt []byte, n int, r int, p int) (cipher.AEAD, error) {
+	if s.passphrase == "" {
+		return nil, errors.New("credential store passphrase not provided")
+	}
+	key, err := scrypt.Key([]byte(s.passphrase), salt, n, r, p, encryptedCredentialsKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
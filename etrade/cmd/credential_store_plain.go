@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"golang.org/x/exp/slog"
+	"os"
+)
+
+type plainCredentialStore struct {
+	filename string
+	logger   *slog.Logger
+}
+
+// NewPlainCredentialStore creates a credential store that keeps credentials
+// as plaintext JSON in the given file.
+func NewPlainCredentialStore(filename string, logger *slog.Logger) CredentialStore {
+	return &plainCredentialStore{
+		filename: filename,
+		logger:   logger,
+	}
+}
+
+func (s *plainCredentialStore) LoadCredentials() (*CachedCredentials, error) {
+	return LoadCachedCredentialsFromFile(s.filename, s.logger)
+}
+
+func (s *plainCredentialStore) SaveCredentials(credentials *CachedCredentials) error {
+	return SaveCachedCredentialsToFile(s.filename, credentials, s.logger)
+}
+
+func (s *plainCredentialStore) RemoveCredentials() error {
+	err := os.Remove(s.filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCredentialStores(t *testing.T) {
+	type createFn func(folder string) CredentialStore
+
+	tests := []struct {
+		name     string
+		createFn createFn
+	}{
+		{
+			name: "Plain Credential Store",
+			createFn: func(folder string) CredentialStore {
+				return NewPlainCredentialStore(filepath.Join(folder, "credentials"), nil)
+			},
+		},
+		{
+			name: "Encrypted Credential Store",
+			createFn: func(folder string) CredentialStore {
+				return NewEncryptedCredentialStore(filepath.Join(folder, "credentials"), "TestPassphrase", nil)
+			},
+		},
+		{
+			name: "Command Credential Store",
+			createFn: func(folder string) CredentialStore {
+				return NewCommandCredentialStore(
+					"TestCustomerId",
+					[]string{"cat", filepath.Join(folder, "{customerId}")},
+					[]string{"sh", "-c", "cat > " + filepath.Join(folder, "{customerId}")},
+					[]string{"rm", filepath.Join(folder, "{customerId}")},
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				credentialStore := tt.createFn(t.TempDir())
+				credentials := NewCachedCredentials(
+					"TestToken", "TestSecret", nil, time.Date(2023, 6, 14, 13, 0, 0, 0, time.UTC),
+				)
+
+				// Loading fails before anything is saved
+				_, err := credentialStore.LoadCredentials()
+				assert.Error(t, err)
+
+				// Saved credentials can be loaded
+				err = credentialStore.SaveCredentials(credentials)
+				assert.Nil(t, err)
+				actualCredentials, err := credentialStore.LoadCredentials()
+				assert.Nil(t, err)
+				assert.Equal(t, credentials, actualCredentials)
+
+				// Removed credentials can no longer be loaded
+				err = credentialStore.RemoveCredentials()
+				assert.Nil(t, err)
+				_, err = credentialStore.LoadCredentials()
+				assert.Error(t, err)
+			},
+		)
+	}
+}
+
+func TestEncryptedCredentialStore(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	credentialStore := NewEncryptedCredentialStore(filename, "TestPassphrase", nil)
+	err := credentialStore.SaveCredentials(NewCachedCredentials("TestToken", "TestSecret", nil, time.Now()))
+	assert.Nil(t, err)
+
+	// The file must not contain the token in plaintext and must be readable
+	// only by its owner.
+	fileBytes, err := os.ReadFile(filename)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(fileBytes), "TestToken")
+	fileInfo, err := os.Stat(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+
+	// The file can't be decrypted with the wrong passphrase.
+	_, err = NewEncryptedCredentialStore(filename, "WrongPassphrase", nil).LoadCredentials()
+	assert.Error(t, err)
+}
+
+func TestEncryptedCredentialStore_RejectsCorruptedFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		corruptFn func(file *encryptedCredentialsFile)
+		expectErr string
+	}{
+		{
+			name:      "Truncated Nonce",
+			corruptFn: func(file *encryptedCredentialsFile) { file.Nonce = file.Nonce[:4] },
+			expectErr: "has a 4-byte nonce, but 12 bytes are required",
+		},
+		{
+			name:      "Missing Nonce",
+			corruptFn: func(file *encryptedCredentialsFile) { file.Nonce = nil },
+			expectErr: "has a 0-byte nonce, but 12 bytes are required",
+		},
+		{
+			name:      "N Not A Power Of 2",
+			corruptFn: func(file *encryptedCredentialsFile) { file.KdfN = 1000 },
+			expectErr: "N must be a power of 2 greater than 1, not 1000",
+		},
+		{
+			name:      "Huge N",
+			corruptFn: func(file *encryptedCredentialsFile) { file.KdfN = 1 << 30 },
+			expectErr: "require more than 268435456 bytes of memory",
+		},
+		{
+			name:      "Huge R",
+			corruptFn: func(file *encryptedCredentialsFile) { file.KdfR = 1 << 20 },
+			expectErr: "require more than 268435456 bytes of memory",
+		},
+		{
+			name:      "Zero R",
+			corruptFn: func(file *encryptedCredentialsFile) { file.KdfR = 0 },
+			expectErr: "r and p must be at least 1, not 0 and 1",
+		},
+		{
+			name:      "Huge P",
+			corruptFn: func(file *encryptedCredentialsFile) { file.KdfP = 1 << 20 },
+			expectErr: "p must be at most 16, not 1048576",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				filename := filepath.Join(t.TempDir(), "credentials")
+				credentialStore := NewEncryptedCredentialStore(filename, "TestPassphrase", nil)
+				require.Nil(
+					t, credentialStore.SaveCredentials(NewCachedCredentials("TestToken", "TestSecret", nil, time.Now())),
+				)
+				fileBytes, err := os.ReadFile(filename)
+				require.Nil(t, err)
+				var file encryptedCredentialsFile
+				require.Nil(t, json.Unmarshal(fileBytes, &file))
+				tt.corruptFn(&file)
+				fileBytes, err = json.Marshal(&file)
+				require.Nil(t, err)
+				require.Nil(t, os.WriteFile(filename, fileBytes, 0600))
+
+				// Call the Method Under Test
+				_, err = credentialStore.LoadCredentials()
+
+				assert.ErrorContains(t, err, tt.expectErr)
+			},
+		)
+	}
+}
+
+func TestNewCredentialStoreForCustomer(t *testing.T) {
+	t.Setenv("ETRADE_TEST_PASSPHRASE", "TestPassphrase")
+
+	tests := []struct {
+		name        string
+		storeConfig *CustomerCredentialStore
+		expectErr   bool
+		expectType  CredentialStore
+	}{
+		{
+			name:        "Defaults To Plain Store",
+			storeConfig: nil,
+			expectErr:   false,
+			expectType:  &plainCredentialStore{},
+		},
+		{
+			name:        "Creates Encrypted Store",
+			storeConfig: &CustomerCredentialStore{Type: "encrypted", Passphrase: "env:ETRADE_TEST_PASSPHRASE"},
+			expectErr:   false,
+			expectType:  &encryptedCredentialStore{},
+		},
+		{
+			name:        "Encrypted Store Fails Without Passphrase",
+			storeConfig: &CustomerCredentialStore{Type: "encrypted"},
+			expectErr:   true,
+			expectType:  nil,
+		},
+		{
+			name: "Creates Command Store",
+			storeConfig: &CustomerCredentialStore{
+				Type:          "command",
+				LoadCommand:   []string{"pass", "show", "etrade/{customerId}"},
+				SaveCommand:   []string{"pass", "insert", "--multiline", "--force", "etrade/{customerId}"},
+				RemoveCommand: []string{"pass", "rm", "--force", "etrade/{customerId}"},
+			},
+			expectErr:  false,
+			expectType: &commandCredentialStore{},
+		},
+		{
+			name:        "Command Store Fails Without Commands",
+			storeConfig: &CustomerCredentialStore{Type: "command"},
+			expectErr:   true,
+			expectType:  nil,
+		},
+		{
+			name:        "Fails With Unknown Type",
+			storeConfig: &CustomerCredentialStore{Type: "unknown"},
+			expectErr:   true,
+			expectType:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfgStore := &CustomerConfigurationStore{
+					customerConfigMap: map[string]CustomerConfiguration{
+						"TestCustomerId": {
+							CustomerConsumerKey:     "TestConsumerKey",
+							CustomerConsumerSecret:  "TestConsumerSecret",
+							CustomerCredentialStore: tt.storeConfig,
+						},
+					},
+				}
+				// Call the Method Under Test
+				credentialStore, err := NewCredentialStoreForCustomer(
+					"TestCustomerId", NewConfigurationFolder(t.TempDir()), cfgStore, nil,
+				)
+				if tt.expectErr {
+					assert.Error(t, err)
+					assert.Nil(t, credentialStore)
+				} else {
+					assert.Nil(t, err)
+					assert.IsType(t, tt.expectType, credentialStore)
+				}
+			},
+		)
+	}
+}
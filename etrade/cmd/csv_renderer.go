@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"os"
 )
@@ -19,6 +22,52 @@ func (c *csvRenderer) Render(jsonMap jsonmap.JsonMap, descriptors []RenderDescri
 	return renderObject(writer, jsonMap, descriptors)
 }
 
+func (c *csvRenderer) RenderStream(
+	ctx context.Context, stream *ListStream, descriptors []RenderDescriptor,
+) error {
+	writer := csv.NewWriter(c.outputFile)
+	defer writer.Flush()
+
+	for _, descriptor := range descriptors {
+		if descriptor.ObjectPath != stream.ListPath {
+			rest, err := stream.rest()
+			if err != nil {
+				return err
+			}
+			err = renderObject(writer, rest, []RenderDescriptor{descriptor})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for i := 0; ; i++ {
+			element, err := stream.Elements.Next(ctx)
+			if errors.Is(err, etradelib.ErrIteratorDone) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			err = renderSliceElement(writer, i, element, descriptor)
+			if err != nil {
+				return err
+			}
+			// Flush each element so that it's output as soon as it arrives.
+			writer.Flush()
+			if err = writer.Error(); err != nil {
+				return err
+			}
+		}
+		if descriptor.SpaceAfter {
+			err := writer.Write(nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (c *csvRenderer) Close() error {
 	return c.outputFile.Close()
 }
@@ -47,31 +96,15 @@ func renderObject(writer *csv.Writer, jsonMap jsonmap.JsonMap, descriptors []Ren
 					}
 				}
 			case jsonmap.JsonSlice:
-				var err error
 				for i := range o {
-					// Always write the header before the first element, but also repeat
-					// the header for each element if there are sub-objects.
-					if i == 0 || len(descriptor.SubObjects) > 0 {
-						err = writer.Write(getHeadersForRenderValues(descriptor.Values))
-					}
-					var element jsonmap.JsonMap
-					element, err = o.GetMap(i)
+					element, err := o.GetMap(i)
 					if err != nil {
 						return err
 					}
-					err = writer.Write(
-						getValuesForRenderValues(element, descriptor.Values, descriptor.DefaultValue),
-					)
+					err = renderSliceElement(writer, i, element, descriptor)
 					if err != nil {
 						return err
 					}
-					// Render any sub-objects
-					if len(descriptor.SubObjects) > 0 {
-						err = renderObject(writer, element, descriptor.SubObjects)
-						if err != nil {
-							return err
-						}
-					}
 				}
 			}
 			if descriptor.SpaceAfter {
@@ -85,6 +118,32 @@ func renderObject(writer *csv.Writer, jsonMap jsonmap.JsonMap, descriptors []Ren
 	return nil
 }
 
+// renderSliceElement renders the element at the given index of a slice.
+func renderSliceElement(writer *csv.Writer, index int, element jsonmap.JsonMap, descriptor RenderDescriptor) error {
+	// Always write the header before the first element, but also repeat the
+	// header for each element if there are sub-objects.
+	if index == 0 || len(descriptor.SubObjects) > 0 {
+		err := writer.Write(getHeadersForRenderValues(descriptor.Values))
+		if err != nil {
+			return err
+		}
+	}
+	err := writer.Write(
+		getValuesForRenderValues(element, descriptor.Values, descriptor.DefaultValue),
+	)
+	if err != nil {
+		return err
+	}
+	// Render any sub-objects
+	if len(descriptor.SubObjects) > 0 {
+		err = renderObject(writer, element, descriptor.SubObjects)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getValuesForRenderValues(
 	jsonMap jsonmap.JsonMap, renderValues []RenderValue, defaultValue string,
 ) []string {
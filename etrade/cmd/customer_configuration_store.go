@@ -4,20 +4,179 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"golang.org/x/exp/slog"
 	"io"
 	"os"
+	"time"
 )
 
 type CustomerConfiguration struct {
-	CustomerName           string `json:"customerName"`
-	CustomerProduction     bool   `json:"customerProduction"`
-	CustomerConsumerKey    string `json:"customerConsumerKey"`
-	CustomerConsumerSecret string `json:"customerConsumerSecret"`
+	CustomerName           string              `json:"customerName"`
+	CustomerProduction     bool                `json:"customerProduction"`
+	CustomerConsumerKey    string              `json:"customerConsumerKey"`
+	CustomerConsumerSecret string              `json:"customerConsumerSecret"`
+	CustomerRiskLimits     *CustomerRiskLimits `json:"customerRiskLimits,omitempty"`
+	// CustomerCredentialStore selects where the customer's access token is
+	// cached. If nil, the token is cached as plaintext in the configuration
+	// folder.
+	CustomerCredentialStore *CustomerCredentialStore `json:"customerCredentialStore,omitempty"`
+	// CustomerRetryPolicy controls how failed requests are retried. If nil,
+	// the default retry policy is used.
+	CustomerRetryPolicy *CustomerRetryPolicy `json:"customerRetryPolicy,omitempty"`
+	// CustomerRateLimits limits the rate of requests to each ETrade API
+	// module. If nil, requests are not rate limited.
+	CustomerRateLimits *CustomerRateLimits `json:"customerRateLimits,omitempty"`
+	// CustomerApiBaseUrl, if not empty, replaces the base URL of ETrade's
+	// API (e.g. to use a proxy or a local stand-in for ETrade).
+	CustomerApiBaseUrl string `json:"customerApiBaseUrl,omitempty"`
+	// CustomerAuthorizeUrl, if not empty, replaces the URL of ETrade's
+	// application authorization page.
+	CustomerAuthorizeUrl string `json:"customerAuthorizeUrl,omitempty"`
+	// CustomerServerKeys are the API keys that may access this customer in
+	// server mode. If no customer has any keys, the server doesn't require
+	// authentication.
+	CustomerServerKeys []CustomerServerKey `json:"customerServerKeys,omitempty"`
+}
+
+// CustomerCredentialStore configures where a customer's access token is
+// cached. Type is "plain", "encrypted", or "command". The encrypted store
+// requires a passphrase, which is usually a secret reference such as
+// "env:ETRADE_PASSPHRASE". The command store requires load, save, and remove
+// commands (see NewCommandCredentialStore).
+type CustomerCredentialStore struct {
+	Type          string   `json:"type"`
+	Passphrase    string   `json:"passphrase,omitempty"`
+	LoadCommand   []string `json:"loadCommand,omitempty"`
+	SaveCommand   []string `json:"saveCommand,omitempty"`
+	RemoveCommand []string `json:"removeCommand,omitempty"`
+}
+
+// CustomerServerKey is an API key that may access a customer in server mode.
+// Key is usually a secret reference such as "env:ETRADE_SERVER_KEY".
+// Capabilities are what the key may do for the customer: "market" (read
+// market data), "accounts" (read accounts, orders, and alerts), and "trade"
+// (change the account, e.g. by deleting alerts). The same key may be given to
+// several customers, with different capabilities for each.
+type CustomerServerKey struct {
+	Key          string   `json:"key"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// CustomerRiskLimits are the pre-trade checks applied to every order placed
+// for a customer. A zero value for any limit disables that limit.
+type CustomerRiskLimits struct {
+	MaxOrderNotional     decimal.Decimal `json:"maxOrderNotional"`
+	MaxShareQuantity     int             `json:"maxShareQuantity"`
+	AllowedSymbols       []string        `json:"allowedSymbols"`
+	TradingHoursOnly     bool            `json:"tradingHoursOnly"`
+	ProductionKillSwitch bool            `json:"productionKillSwitch"`
+}
+
+// ClientRiskLimits returns the risk limits to apply to a client for this
+// customer. The production kill switch disables trading only when the
+// customer is configured for production.
+func (c *CustomerConfiguration) ClientRiskLimits() client.RiskLimits {
+	if c.CustomerRiskLimits == nil {
+		return client.RiskLimits{}
+	}
+	return client.RiskLimits{
+		MaxOrderNotional: c.CustomerRiskLimits.MaxOrderNotional,
+		MaxShareQuantity: c.CustomerRiskLimits.MaxShareQuantity,
+		AllowedSymbols:   c.CustomerRiskLimits.AllowedSymbols,
+		TradingHoursOnly: c.CustomerRiskLimits.TradingHoursOnly,
+		TradingDisabled:  c.CustomerProduction && c.CustomerRiskLimits.ProductionKillSwitch,
+	}
+}
+
+// CustomerRetryPolicy controls how requests that fail with a rate limit,
+// server, or network error are retried. Setting maxRetries to 0 disables
+// retries. A zero backoff uses the default backoff.
+type CustomerRetryPolicy struct {
+	MaxRetries       int `json:"maxRetries"`
+	InitialBackoffMs int `json:"initialBackoffMs"`
+	MaxBackoffMs     int `json:"maxBackoffMs"`
+}
+
+// ClientRetryPolicy returns the retry policy to apply to a client for this
+// customer.
+func (c *CustomerConfiguration) ClientRetryPolicy() client.RetryPolicy {
+	retryPolicy := client.DefaultRetryPolicy()
+	if c.CustomerRetryPolicy == nil {
+		return retryPolicy
+	}
+	retryPolicy.MaxRetries = c.CustomerRetryPolicy.MaxRetries
+	if c.CustomerRetryPolicy.InitialBackoffMs > 0 {
+		retryPolicy.InitialBackoff = time.Duration(c.CustomerRetryPolicy.InitialBackoffMs) * time.Millisecond
+	}
+	if c.CustomerRetryPolicy.MaxBackoffMs > 0 {
+		retryPolicy.MaxBackoff = time.Duration(c.CustomerRetryPolicy.MaxBackoffMs) * time.Millisecond
+	}
+	return retryPolicy
+}
+
+// CustomerRateLimits limits the rate of requests to each ETrade API module.
+// A nil limit or a zero requestsPerSecond disables the limit for a module.
+type CustomerRateLimits struct {
+	Accounts *CustomerRateLimit `json:"accounts,omitempty"`
+	Alerts   *CustomerRateLimit `json:"alerts,omitempty"`
+	Market   *CustomerRateLimit `json:"market,omitempty"`
+	Orders   *CustomerRateLimit `json:"orders,omitempty"`
+}
+
+// CustomerRateLimit is a token bucket limit: requests may be made at
+// requestsPerSecond on average, with up to burst requests at once.
+type CustomerRateLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Burst             int     `json:"burst"`
+}
+
+func (l *CustomerRateLimit) clientRateLimit() client.RateLimit {
+	if l == nil {
+		return client.RateLimit{}
+	}
+	return client.RateLimit{RequestsPerSecond: l.RequestsPerSecond, Burst: l.Burst}
+}
+
+// ClientRateLimits returns the rate limits to apply to a client for this
+// customer.
+func (c *CustomerConfiguration) ClientRateLimits() client.RateLimits {
+	if c.CustomerRateLimits == nil {
+		return client.RateLimits{}
+	}
+	return client.RateLimits{
+		Accounts: c.CustomerRateLimits.Accounts.clientRateLimit(),
+		Alerts:   c.CustomerRateLimits.Alerts.clientRateLimit(),
+		Market:   c.CustomerRateLimits.Market.clientRateLimit(),
+		Orders:   c.CustomerRateLimits.Orders.clientRateLimit(),
+	}
+}
+
+// ClientEndpointUrls returns the endpoint URLs to use for this customer's
+// client.
+func (c *CustomerConfiguration) ClientEndpointUrls() (client.EndpointUrls, error) {
+	if c.CustomerApiBaseUrl == "" && c.CustomerAuthorizeUrl == "" {
+		return client.GetEndpointUrls(c.CustomerProduction), nil
+	}
+	baseUrl := c.CustomerApiBaseUrl
+	if baseUrl == "" {
+		baseUrl = client.DefaultApiBaseUrl(c.CustomerProduction)
+	}
+	return client.NewEndpointUrls(baseUrl, c.CustomerAuthorizeUrl)
 }
 
 type CustomerConfigurationStore struct {
 	customerConfigMap map[string]CustomerConfiguration
+	// apiBaseUrlOverride, if not empty, replaces every customer's API base
+	// URL. It is never saved.
+	apiBaseUrlOverride string
+	// httpRecordDir, if not empty, is the folder where clients record their
+	// requests and responses. It is never saved.
+	httpRecordDir string
+	// httpReplayDir, if not empty, is the folder that clients replay recorded
+	// responses from instead of sending requests. It is never saved.
+	httpReplayDir string
 }
 
 func LoadCustomerConfigurationStore(reader io.Reader) (*CustomerConfigurationStore, error) {
@@ -81,14 +240,62 @@ func SaveCustomerConfigurationStoreToFile(
 	return SaveCustomerConfigurationStore(file, cc)
 }
 
+// ErrCustomerNotFound is returned when a customer isn't in the configuration.
+var ErrCustomerNotFound = errors.New("customer not found")
+
 func (c *CustomerConfigurationStore) GetCustomerConfigurationById(customerId string) (*CustomerConfiguration, error) {
 	configItem, exists := c.customerConfigMap[customerId]
 	if !exists {
-		return nil, errors.New("customer not found")
+		return nil, ErrCustomerNotFound
+	}
+	if c.apiBaseUrlOverride != "" {
+		configItem.CustomerApiBaseUrl = c.apiBaseUrlOverride
 	}
 	return &configItem, nil
 }
 
+// SetApiBaseUrlOverride replaces the API base URL of every customer returned
+// by the store (e.g. with the URL given by the --api-base-url flag). The
+// override is not saved with the store.
+func (c *CustomerConfigurationStore) SetApiBaseUrlOverride(apiBaseUrl string) {
+	c.apiBaseUrlOverride = apiBaseUrl
+}
+
+// SetHttpRecordDir makes clients created for the store's customers record
+// their requests and responses to a cassette file for each customer in the
+// given folder (e.g. the folder given by the --record flag).
+func (c *CustomerConfigurationStore) SetHttpRecordDir(dir string) {
+	c.httpRecordDir = dir
+}
+
+// SetHttpReplayDir makes clients created for the store's customers replay
+// responses from a cassette file for each customer in the given folder
+// instead of sending requests (e.g. the folder given by the --replay flag).
+func (c *CustomerConfigurationStore) SetHttpReplayDir(dir string) {
+	c.httpReplayDir = dir
+}
+
+// resolveCustomerConfiguration gets the configuration for a customer, with
+// any secret references in the consumer key and secret replaced by the
+// secrets they refer to.
+func resolveCustomerConfiguration(customerId string, cfgStore *CustomerConfigurationStore) (
+	*CustomerConfiguration, error,
+) {
+	customerConfig, err := cfgStore.GetCustomerConfigurationById(customerId)
+	if err != nil {
+		return nil, fmt.Errorf("customer id '%s' not found in config file", customerId)
+	}
+	if customerConfig.CustomerConsumerKey, err = ResolveSecretReference(customerConfig.CustomerConsumerKey); err != nil {
+		return nil, fmt.Errorf("unable to get the consumer key for customer '%s' (%w)", customerId, err)
+	}
+	if customerConfig.CustomerConsumerSecret, err = ResolveSecretReference(
+		customerConfig.CustomerConsumerSecret,
+	); err != nil {
+		return nil, fmt.Errorf("unable to get the consumer secret for customer '%s' (%w)", customerId, err)
+	}
+	return customerConfig, nil
+}
+
 func (c *CustomerConfigurationStore) SetCustomerConfigurationForId(
 	customerId string, configuration *CustomerConfiguration,
 ) {
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadCustomerConfigurationStore(t *testing.T) {
@@ -35,6 +38,42 @@ func TestLoadCustomerConfigurationStore(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Can Load Store With Risk Limits",
+			testJson: `{
+  "TestCustomerId": {
+    "customerName": "TestName",
+    "customerProduction": true,
+    "customerConsumerKey": "TestKey",
+    "customerConsumerSecret": "TestSecret",
+    "customerRiskLimits": {
+      "maxOrderNotional": 10000,
+      "maxShareQuantity": 100,
+      "allowedSymbols": ["GOOG", "AAPL"],
+      "tradingHoursOnly": true,
+      "productionKillSwitch": true
+    }
+  }
+}`,
+			expectErr: false,
+			expectValue: &CustomerConfigurationStore{
+				customerConfigMap: map[string]CustomerConfiguration{
+					"TestCustomerId": {
+						CustomerName:           "TestName",
+						CustomerProduction:     true,
+						CustomerConsumerKey:    "TestKey",
+						CustomerConsumerSecret: "TestSecret",
+						CustomerRiskLimits: &CustomerRiskLimits{
+							MaxOrderNotional:     decimal.NewFromInt(10000),
+							MaxShareQuantity:     100,
+							AllowedSymbols:       []string{"GOOG", "AAPL"},
+							TradingHoursOnly:     true,
+							ProductionKillSwitch: true,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Load Succeeds With Missing Fields",
 			testJson: `{
@@ -179,3 +218,258 @@ func TestCustomerConfigurationStore_GetAllConfigurations(t *testing.T) {
 	actualMap := testStore.GetAllConfigurations()
 	assert.Equal(t, expectedMap, actualMap)
 }
+
+func TestCustomerConfiguration_ClientRiskLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CustomerConfiguration
+		expectValue client.RiskLimits
+	}{
+		{
+			name:        "No Risk Limits",
+			config:      CustomerConfiguration{CustomerProduction: true},
+			expectValue: client.RiskLimits{},
+		},
+		{
+			name: "Kill Switch Disables Production Trading",
+			config: CustomerConfiguration{
+				CustomerProduction: true,
+				CustomerRiskLimits: &CustomerRiskLimits{
+					MaxOrderNotional:     decimal.NewFromInt(10000),
+					MaxShareQuantity:     100,
+					AllowedSymbols:       []string{"GOOG"},
+					TradingHoursOnly:     true,
+					ProductionKillSwitch: true,
+				},
+			},
+			expectValue: client.RiskLimits{
+				MaxOrderNotional: decimal.NewFromInt(10000),
+				MaxShareQuantity: 100,
+				AllowedSymbols:   []string{"GOOG"},
+				TradingHoursOnly: true,
+				TradingDisabled:  true,
+			},
+		},
+		{
+			name: "Kill Switch Does Not Affect Sandbox",
+			config: CustomerConfiguration{
+				CustomerProduction: false,
+				CustomerRiskLimits: &CustomerRiskLimits{ProductionKillSwitch: true},
+			},
+			expectValue: client.RiskLimits{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue := tt.config.ClientRiskLimits()
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestCustomerConfiguration_ClientRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CustomerConfiguration
+		expectValue client.RetryPolicy
+	}{
+		{
+			name:        "No Retry Policy Uses Default",
+			config:      CustomerConfiguration{},
+			expectValue: client.DefaultRetryPolicy(),
+		},
+		{
+			name: "Retry Policy Overrides Default",
+			config: CustomerConfiguration{
+				CustomerRetryPolicy: &CustomerRetryPolicy{
+					MaxRetries:       5,
+					InitialBackoffMs: 100,
+					MaxBackoffMs:     2000,
+				},
+			},
+			expectValue: client.RetryPolicy{
+				MaxRetries:     5,
+				InitialBackoff: 100 * time.Millisecond,
+				MaxBackoff:     2 * time.Second,
+			},
+		},
+		{
+			name: "Zero Retries Disables Retry With Default Backoff",
+			config: CustomerConfiguration{
+				CustomerRetryPolicy: &CustomerRetryPolicy{},
+			},
+			expectValue: client.RetryPolicy{
+				MaxRetries:     0,
+				InitialBackoff: client.DefaultRetryPolicy().InitialBackoff,
+				MaxBackoff:     client.DefaultRetryPolicy().MaxBackoff,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue := tt.config.ClientRetryPolicy()
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestCustomerConfiguration_ClientRateLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CustomerConfiguration
+		expectValue client.RateLimits
+	}{
+		{
+			name:        "No Rate Limits",
+			config:      CustomerConfiguration{},
+			expectValue: client.RateLimits{},
+		},
+		{
+			name: "Rate Limits For Some Modules",
+			config: CustomerConfiguration{
+				CustomerRateLimits: &CustomerRateLimits{
+					Market: &CustomerRateLimit{RequestsPerSecond: 4, Burst: 8},
+					Orders: &CustomerRateLimit{RequestsPerSecond: 2, Burst: 1},
+				},
+			},
+			expectValue: client.RateLimits{
+				Market: client.RateLimit{RequestsPerSecond: 4, Burst: 8},
+				Orders: client.RateLimit{RequestsPerSecond: 2, Burst: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue := tt.config.ClientRateLimits()
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestCustomerConfiguration_ClientEndpointUrls(t *testing.T) {
+	tests := []struct {
+		name                  string
+		config                CustomerConfiguration
+		expectListAccountsUrl string
+		expectAuthorizeUrl    string
+		expectErr             bool
+	}{
+		{
+			name:                  "Sandbox",
+			config:                CustomerConfiguration{CustomerProduction: false},
+			expectListAccountsUrl: "https://apisb.etrade.com/v1/accounts/list",
+			expectAuthorizeUrl:    "https://us.etrade.com/e/t/etws/authorize",
+		},
+		{
+			name:                  "Production",
+			config:                CustomerConfiguration{CustomerProduction: true},
+			expectListAccountsUrl: "https://api.etrade.com/v1/accounts/list",
+			expectAuthorizeUrl:    "https://us.etrade.com/e/t/etws/authorize",
+		},
+		{
+			name: "Custom Base URL And Authorize URL",
+			config: CustomerConfiguration{
+				CustomerApiBaseUrl:   "http://localhost:8080",
+				CustomerAuthorizeUrl: "http://localhost:8080/authorize",
+			},
+			expectListAccountsUrl: "http://localhost:8080/v1/accounts/list",
+			expectAuthorizeUrl:    "http://localhost:8080/authorize",
+		},
+		{
+			name: "Custom Authorize URL Only",
+			config: CustomerConfiguration{
+				CustomerProduction:   true,
+				CustomerAuthorizeUrl: "http://localhost:8080/authorize",
+			},
+			expectListAccountsUrl: "https://api.etrade.com/v1/accounts/list",
+			expectAuthorizeUrl:    "http://localhost:8080/authorize",
+		},
+		{
+			name:      "Invalid Base URL",
+			config:    CustomerConfiguration{CustomerApiBaseUrl: "localhost:8080"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualUrls, err := tt.config.ClientEndpointUrls()
+				if tt.expectErr {
+					assert.Error(t, err)
+					return
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectListAccountsUrl, actualUrls.ListAccountsUrl())
+				assert.Equal(t, tt.expectAuthorizeUrl, actualUrls.AuthorizeApplicationUrl())
+			},
+		)
+	}
+}
+
+func TestCustomerConfigurationStore_SetApiBaseUrlOverride(t *testing.T) {
+	testStore := CustomerConfigurationStore{
+		customerConfigMap: map[string]CustomerConfiguration{
+			"TestCustomerId": {
+				CustomerName:       "TestName",
+				CustomerApiBaseUrl: "http://localhost:8080",
+			},
+		},
+	}
+	// Call the Method Under Test
+	testStore.SetApiBaseUrlOverride("http://localhost:9090")
+
+	actualConfig, err := testStore.GetCustomerConfigurationById("TestCustomerId")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:9090", actualConfig.CustomerApiBaseUrl)
+	// The override is not saved with the store.
+	var writer strings.Builder
+	assert.Nil(t, SaveCustomerConfigurationStore(&writer, &testStore))
+	assert.Contains(t, writer.String(), `"customerApiBaseUrl": "http://localhost:8080"`)
+}
+
+func TestResolveCustomerConfiguration(t *testing.T) {
+	t.Setenv("ETRADE_TEST_CONSUMER_SECRET", "TestSecret")
+	testStore := CustomerConfigurationStore{
+		customerConfigMap: map[string]CustomerConfiguration{
+			"TestCustomerId": {
+				CustomerName:           "TestName",
+				CustomerConsumerKey:    "TestKey",
+				CustomerConsumerSecret: "env:ETRADE_TEST_CONSUMER_SECRET",
+			},
+			"BadCustomerId": {
+				CustomerName:           "TestName",
+				CustomerConsumerKey:    "TestKey",
+				CustomerConsumerSecret: "env:ETRADE_TEST_UNSET_SECRET",
+			},
+		},
+	}
+
+	actualConfig, err := resolveCustomerConfiguration("TestCustomerId", &testStore)
+	assert.Nil(t, err)
+	assert.Equal(t, "TestKey", actualConfig.CustomerConsumerKey)
+	assert.Equal(t, "TestSecret", actualConfig.CustomerConsumerSecret)
+	// The stored configuration keeps the reference
+	assert.Equal(
+		t, "env:ETRADE_TEST_CONSUMER_SECRET",
+		testStore.customerConfigMap["TestCustomerId"].CustomerConsumerSecret,
+	)
+
+	_, err = resolveCustomerConfiguration("BadCustomerId", &testStore)
+	assert.Error(t, err)
+	_, err = resolveCustomerConfiguration("UnknownCustomerId", &testStore)
+	assert.Error(t, err)
+}
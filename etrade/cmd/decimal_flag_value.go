@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+)
+
+// decimalFlagValue holds a flag value as an exact decimal, so that a price
+// given on the command line is sent to ETrade exactly as it was typed.
+type decimalFlagValue struct {
+	DecimalValue decimal.Decimal
+}
+
+func (m *decimalFlagValue) String() string {
+	return m.DecimalValue.String()
+}
+
+func (m *decimalFlagValue) Set(value string) error {
+	decimalValue, err := decimal.NewFromString(value)
+	if err != nil {
+		return fmt.Errorf("%s is not a number", value)
+	}
+	m.DecimalValue = decimalValue
+	return nil
+}
+
+func (m *decimalFlagValue) Type() string {
+	return "decimal"
+}
+
+func (m *decimalFlagValue) Value() decimal.Decimal {
+	return m.DecimalValue
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecimalFlagValue_Set(t *testing.T) {
+	testValue := decimalFlagValue{}
+
+	err := testValue.Set("152.10")
+
+	assert.Nil(t, err)
+	assert.Equal(t, decimal.MustNewFromString("152.10"), testValue.Value())
+	assert.Equal(t, "152.10", testValue.String())
+}
+
+func TestDecimalFlagValue_Set_FailsWithInvalidValue(t *testing.T) {
+	testValue := decimalFlagValue{DecimalValue: decimal.MustNewFromString("1.5")}
+
+	err := testValue.Set("abc")
+
+	assert.Error(t, err)
+	assert.Equal(t, decimal.MustNewFromString("1.5"), testValue.Value())
+}
@@ -139,6 +139,45 @@ var orderTransactionTypeMap = enumValueWithHelpMap[constants.OrderTransactionTyp
 	"mutualFundExchange": {constants.OrderTransactionTypeMutualFundExchange, "only mutual fund exchange orders"},
 }
 
+var orderTypeMap = enumValueWithHelpMap[constants.OrderType]{
+	"equity":        {constants.OrderTypeEquity, "equity order"},
+	"option":        {constants.OrderTypeOption, "single-leg option order"},
+	"spreads":       {constants.OrderTypeSpreads, "multi-leg option spread (vertical, calendar, straddle, etc.)"},
+	"buyWrites":     {constants.OrderTypeBuyWrites, "buy an equity and write a call against it"},
+	"butterfly":     {constants.OrderTypeButterfly, "three-leg butterfly"},
+	"ironButterfly": {constants.OrderTypeIronButterfly, "four-leg iron butterfly"},
+	"condor":        {constants.OrderTypeCondor, "four-leg condor"},
+	"ironCondor":    {constants.OrderTypeIronCondor, "four-leg iron condor"},
+}
+
+var orderActionMap = enumValueWithHelpMap[constants.OrderAction]{
+	"buy":        {constants.OrderActionBuy, "buy a security"},
+	"sell":       {constants.OrderActionSell, "sell a security"},
+	"sellShort":  {constants.OrderActionSellShort, "sell a security short"},
+	"buyToCover": {constants.OrderActionBuyToCover, "buy a security to cover a short position"},
+	"buyOpen":    {constants.OrderActionBuyOpen, "buy an option to open a position"},
+	"sellOpen":   {constants.OrderActionSellOpen, "sell (write) an option to open a position"},
+	"buyClose":   {constants.OrderActionBuyClose, "buy an option to close a short position"},
+	"sellClose":  {constants.OrderActionSellClose, "sell an option to close a long position"},
+}
+
+var orderPriceTypeMap = enumValueWithHelpMap[constants.OrderPriceType]{
+	"market":    {constants.OrderPriceTypeMarket, "execute at the market price"},
+	"limit":     {constants.OrderPriceTypeLimit, "execute at the limit price or better"},
+	"stop":      {constants.OrderPriceTypeStop, "become a market order at the stop price"},
+	"stopLimit": {constants.OrderPriceTypeStopLimit, "become a limit order at the stop price"},
+	"netDebit":  {constants.OrderPriceTypeNetDebit, "execute a multi-leg order for no more than the limit price"},
+	"netCredit": {constants.OrderPriceTypeNetCredit, "execute a multi-leg order for no less than the limit price"},
+	"netEven":   {constants.OrderPriceTypeNetEven, "execute a multi-leg order for a net price of zero"},
+}
+
+var orderTermMap = enumValueWithHelpMap[constants.OrderTerm]{
+	"goodForDay":        {constants.OrderTermGoodForDay, "order is good until the end of the day"},
+	"goodUntilCancel":   {constants.OrderTermGoodUntilCancel, "order is good until cancelled"},
+	"immediateOrCancel": {constants.OrderTermImmediateOrCancel, "fill what is possible immediately and cancel the rest"},
+	"fillOrKill":        {constants.OrderTermFillOrKill, "fill the entire order immediately or cancel it"},
+}
+
 var alertCategoryMap = enumValueWithHelpMap[constants.AlertCategory]{
 	"stock":   {constants.AlertCategoryStock, "only stock-related alerts"},
 	"account": {constants.AlertCategoryAccount, "only account-related alerts"},
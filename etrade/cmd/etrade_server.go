@@ -17,101 +17,224 @@ import (
 )
 
 type eTradeServer struct {
-	logger        *slog.Logger
-	cfgFolder     ConfigurationFolder
-	cfgStore      *CustomerConfigurationStore
-	eTradeClients map[string]client.ETradeClient
+	logger     *slog.Logger
+	cfgFolder  ConfigurationFolder
+	cfgStore   *CustomerConfigurationStore
+	authorizer *serverAuthorizer
+	// clients caches each customer's client. Handlers run concurrently, so
+	// it must only be accessed through its methods.
+	clients *clientRegistry
 }
 
+// NewETradeServer returns a server for the customers in cfgStore. The server
+// requires API keys from its clients, so it fails to start if no customer has
+// any server keys, unless insecureNoAuth is set to run without authentication.
 func NewETradeServer(
 	addr string, logger *slog.Logger, cfgFolder ConfigurationFolder, cfgStore *CustomerConfigurationStore,
-) *http.Server {
+	insecureNoAuth bool,
+) (*http.Server, error) {
+	authorizer, err := newServerAuthorizer(cfgStore)
+	if err != nil {
+		return nil, err
+	}
+	if !authorizer.Enabled() {
+		if !insecureNoAuth {
+			return nil, errors.New(
+				"no server keys are configured; add customerServerKeys to the configuration, or pass " +
+					"--insecure-no-auth to run the server without authentication",
+			)
+		}
+		logger.Warn("no server keys are configured, so the server does not require authentication")
+	}
 	server := &eTradeServer{
-		logger:        logger,
-		cfgFolder:     cfgFolder,
-		cfgStore:      cfgStore,
-		eTradeClients: map[string]client.ETradeClient{},
+		logger:     logger,
+		cfgFolder:  cfgFolder,
+		cfgStore:   cfgStore,
+		authorizer: authorizer,
 	}
+	server.clients = newClientRegistry(server.newRateLimiterForCustomer, server.newClientForCustomer)
 
 	r := chi.NewRouter()
-	r.Get("/customers", server.GetCustomerList)
-	r.Route(
-		"/customers/{customerId}", func(r chi.Router) {
-			r.Use(server.CustomerCtx)
-			r.Post("/auth", server.Login)
-			r.Delete("/auth", server.Logout)
-			r.Get("/accounts", server.ListAccounts)
+	// The API document is public so that client generators can fetch it.
+	r.Get("/openapi.json", server.GetOpenApiDocument)
+	r.Group(
+		func(r chi.Router) {
+			r.Use(server.AuthenticationCtx)
+			r.Get("/customers", server.GetCustomerList)
 			r.Route(
-				"/accounts/{accountId}", func(r chi.Router) {
-					r.Get("/balance", server.GetAccountBalances)
-					r.Get("/portfolio", server.ViewPortfolio)
-					r.Get("/transactions", server.ListTransactions)
-					r.Get("/transactions/{transactionId}", server.ListTransactionDetails)
-					r.Get("/transactions/orders", server.ListOrders)
+				"/customers/{customerId}", func(r chi.Router) {
+					// Check the API key before looking up the customer so that a
+					// key can't be used to discover which customers exist.
+					r.Use(server.RequireCapability(""))
+					r.Use(server.CustomerCtx)
+					r.Get("/auth", server.GetAuthStatus)
+					r.Group(
+						func(r chi.Router) {
+							r.Use(server.RequireCapability(serverCapabilityAuth))
+							r.Post("/auth", server.Login)
+							r.Delete("/auth", server.Logout)
+						},
+					)
+					r.Group(
+						func(r chi.Router) {
+							r.Use(server.AuthRenewalCtx)
+							r.Group(
+								func(r chi.Router) {
+									r.Use(server.RequireCapability(serverCapabilityAccounts))
+									r.Get("/accounts", server.ListAccounts)
+									r.Get("/accounts/{accountId}/balance", server.GetAccountBalances)
+									r.Get("/accounts/{accountId}/portfolio", server.ViewPortfolio)
+									r.Get("/accounts/{accountId}/positions/{positionId}/lots", server.ListPositionLots)
+									r.Get("/accounts/{accountId}/transactions", server.ListTransactions)
+									r.Get(
+										"/accounts/{accountId}/transactions/{transactionId}", server.ListTransactionDetails,
+									)
+									r.Get("/accounts/{accountId}/orders", server.ListOrders)
+									r.Get("/alerts", server.ListAlerts)
+									r.Get("/alerts/{alertId}", server.GetAlertDetails)
+								},
+							)
+							r.Group(
+								func(r chi.Router) {
+									r.Use(server.RequireCapability(serverCapabilityTrade))
+									r.Post("/accounts/{accountId}/orders/preview", server.PreviewOrder)
+									r.Post("/accounts/{accountId}/orders", server.PlaceOrder)
+									r.Put("/accounts/{accountId}/orders/{orderId}", server.ChangeOrder)
+									r.Delete("/accounts/{accountId}/orders/{orderId}", server.CancelOrder)
+									r.Delete("/alerts/{alertId}", server.DeleteAlert)
+								},
+							)
+							r.Group(
+								func(r chi.Router) {
+									r.Use(server.RequireCapability(serverCapabilityMarket))
+									r.Get("/market/lookup", server.Lookup)
+									r.Get("/market/quote", server.GetQuote)
+									r.Get("/market/optionchains", server.GetOptionChains)
+									r.Get("/market/optionexpire", server.GetOptionExpire)
+								},
+							)
+						},
+					)
 				},
 			)
-			r.Get("/alerts", server.ListAlerts)
-			r.Route(
-				"/alerts/{alertId}", func(r chi.Router) {
-					r.Get("/", server.GetAlertDetails)
-					r.Delete("/", server.DeleteAlert)
-				},
-			)
-			r.Get("/market/lookup", server.Lookup)
-			r.Get("/market/quote", server.GetQuote)
-			r.Get("/market/optionchains", server.GetOptionChains)
-			r.Get("/market/optionexpire", server.GetOptionExpire)
 		},
 	)
 	return &http.Server{
 		Addr:    addr,
 		Handler: r,
-	}
+	}, nil
 }
 
 func (s *eTradeServer) CustomerCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			customerId := chi.URLParam(r, "customerId")
-			eTradeClient, err := s.GetClientForCustomer(customerId)
+			eTradeClient, credentialStore, err := s.GetClientForCustomer(customerId)
 			if err != nil {
-				http.Error(w, http.StatusText(404), 404)
+				s.WriteError(w, err)
 				return
 			}
-			ctx := context.WithValue(r.Context(), "eTradeClient", eTradeClient)
+			// Make upstream requests with the request's context so that they
+			// are cancelled if the server's client disconnects.
+			ctx := context.WithValue(r.Context(), "eTradeClient", eTradeClient.WithContext(r.Context()))
+			ctx = context.WithValue(ctx, "credentialStore", credentialStore)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		},
 	)
 }
 
-func (s *eTradeServer) GetClientForCustomer(customerId string) (client.ETradeClient, error) {
-	// See if there's already a cached client for this customerId
-	if eTradeClient, ok := s.eTradeClients[customerId]; ok {
-		return eTradeClient, nil
-	}
-	// If there's not a cached client, create a new one
-	if eTradeClient, err := NewETradeClientForCustomer(customerId, s.cfgFolder, s.cfgStore, s.logger); err == nil {
-		// Add the new client to the cache and return it
-		s.eTradeClients[customerId] = eTradeClient
-		return eTradeClient, nil
-	} else {
-		return nil, err
-	}
+// AuthRenewalCtx renews the customer's access token if it has gone idle
+// before passing the request on. See clientRegistry.UseAuth.
+func (s *eTradeServer) AuthRenewalCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient)
+			if !ok {
+				s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+				return
+			}
+			credentialStore, ok := r.Context().Value("credentialStore").(CredentialStore)
+			if !ok {
+				s.WriteError(w, errors.New("unable to find credential store for customer"))
+				return
+			}
+			err := s.clients.UseAuth(chi.URLParam(r, "customerId"), eTradeClient, credentialStore, time.Now())
+			if err != nil {
+				s.WriteError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		},
+	)
+}
+
+func (s *eTradeServer) GetClientForCustomer(customerId string) (client.ETradeClient, CredentialStore, error) {
+	return s.clients.Get(customerId)
 }
 
 func (s *eTradeServer) RemoveClientForCustomer(customerId string) {
-	delete(s.eTradeClients, customerId)
+	s.clients.Remove(customerId)
+}
+
+func (s *eTradeServer) newClientForCustomer(customerId string, rateLimiter *client.RateLimiter) (
+	client.ETradeClient, CredentialStore, error,
+) {
+	return NewETradeClientForCustomer(customerId, s.cfgFolder, s.cfgStore, rateLimiter, s.logger)
+}
+
+func (s *eTradeServer) newRateLimiterForCustomer(customerId string) (*client.RateLimiter, error) {
+	customerConfig, err := s.cfgStore.GetCustomerConfigurationById(customerId)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewRateLimiter(customerConfig.ClientRateLimits()), nil
 }
 
-func (s *eTradeServer) GetCustomerList(w http.ResponseWriter, _ *http.Request) {
+func (s *eTradeServer) GetCustomerList(w http.ResponseWriter, r *http.Request) {
 	responseMap := GetCustomerList(s.cfgStore)
+	if s.authorizer.Enabled() {
+		// List only the customers that the request's API key may access.
+		grants, _ := r.Context().Value("serverKeyGrants").(serverKeyGrants)
+		customers, err := responseMap.GetSlice("customers")
+		if err != nil {
+			s.WriteError(w, err)
+			return
+		}
+		allowedCustomers := jsonmap.JsonSlice{}
+		for _, customer := range customers {
+			if customerMap, ok := customer.(jsonmap.JsonMap); ok {
+				if customerId, err := customerMap.GetString("customerId"); err == nil && grants[customerId] != nil {
+					allowedCustomers = append(allowedCustomers, customerMap)
+				}
+			}
+		}
+		responseMap["customers"] = allowedCustomers
+	}
 	s.WriteJsonMap(w, responseMap)
 }
 
+// GetOpenApiDocument responds with the OpenAPI document that describes the
+// server's API.
+func (s *eTradeServer) GetOpenApiDocument(w http.ResponseWriter, _ *http.Request) {
+	s.WriteJsonMap(w, newServerOpenApiDocument())
+}
+
+func (s *eTradeServer) GetAuthStatus(w http.ResponseWriter, r *http.Request) {
+	if credentialStore, ok := r.Context().Value("credentialStore").(CredentialStore); ok {
+		if response, err := GetAuthStatus(credentialStore, time.Now()); err == nil {
+			s.WriteJsonMap(w, response)
+		} else {
+			s.WriteError(w, err)
+		}
+	} else {
+		s.WriteError(w, errors.New("unable to find credential store for customer"))
+	}
+}
+
 func (s *eTradeServer) Login(w http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
-		s.WriteError(w, err)
+		s.WriteError(w, newInvalidRequestError(err))
 		return
 	}
 	eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient)
@@ -119,6 +242,17 @@ func (s *eTradeServer) Login(w http.ResponseWriter, r *http.Request) {
 		s.WriteError(w, errors.New("unable to find ETrade client for customer"))
 		return
 	}
+	credentialStore, ok := r.Context().Value("credentialStore").(CredentialStore)
+	if !ok {
+		s.WriteError(w, errors.New("unable to find credential store for customer"))
+		return
+	}
+	unlockAuth, err := s.clients.LockAuth(chi.URLParam(r, "customerId"))
+	if err != nil {
+		s.WriteError(w, err)
+		return
+	}
+	defer unlockAuth()
 
 	if !r.Form.Has("verifyCode") {
 		// If the form does not include "verifyCode" then begin authentication.
@@ -134,11 +268,9 @@ func (s *eTradeServer) Login(w http.ResponseWriter, r *http.Request) {
 		}
 		if !authStatus.NeedAuthorization() {
 			// Authentication has succeeded, so update the credential cache
-			consumerKey, _, accessToken, accessSecret := eTradeClient.GetKeys()
-			if err = s.cfgFolder.SaveCachedCredentialsToFile(
-				consumerKey, &CachedCredentials{accessToken, accessSecret, time.Now()}, s.logger,
-			); err != nil {
-				s.logger.Error(fmt.Errorf("saving credential cache to file failed (%w)", err).Error())
+			_, _, accessToken, accessSecret := eTradeClient.GetKeys()
+			if err = UpdateCachedCredentials(credentialStore, accessToken, accessSecret, time.Now()); err != nil {
+				s.logger.Error(fmt.Errorf("saving credential cache failed (%w)", err).Error())
 			}
 		}
 		// Respond with the authentication status. If authentication requires
@@ -160,11 +292,9 @@ func (s *eTradeServer) Login(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Verification has succeeded, so update the credential cache
-		consumerKey, _, accessToken, accessSecret := eTradeClient.GetKeys()
-		if err = s.cfgFolder.SaveCachedCredentialsToFile(
-			consumerKey, &CachedCredentials{accessToken, accessSecret, time.Now()}, s.logger,
-		); err != nil {
-			s.logger.Error(fmt.Errorf("saving credential cache to file failed (%w)", err).Error())
+		_, _, accessToken, accessSecret := eTradeClient.GetKeys()
+		if err = UpdateCachedCredentials(credentialStore, accessToken, accessSecret, time.Now()); err != nil {
+			s.logger.Error(fmt.Errorf("saving credential cache failed (%w)", err).Error())
 		}
 		// Respond with the verification status.
 		s.WriteJsonMap(w, verifyStatus.AsJsonMap())
@@ -173,16 +303,43 @@ func (s *eTradeServer) Login(w http.ResponseWriter, r *http.Request) {
 
 func (s *eTradeServer) Logout(w http.ResponseWriter, r *http.Request) {
 	customerId := chi.URLParam(r, "customerId")
-	// Remove cached ETradeClient
-	s.RemoveClientForCustomer(customerId)
-	// Remove credential cache
-	if response, err := ClearAuth(
-		customerId, s.cfgFolder, s.cfgStore,
-	); err == nil {
-		s.WriteJsonMap(w, response)
-	} else {
+	err := r.ParseForm()
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(err))
+		return
+	}
+	localOnly, err := getBoolWithDefaultFromValues(r.Form, "localOnly", false)
+	if err != nil {
+		s.WriteError(w, err)
+		return
+	}
+	credentialStore, ok := r.Context().Value("credentialStore").(CredentialStore)
+	if !ok {
+		s.WriteError(w, errors.New("unable to find credential store for customer"))
+		return
+	}
+	var eTradeClient client.ETradeClient
+	if !localOnly {
+		if eTradeClient, ok = r.Context().Value("eTradeClient").(client.ETradeClient); !ok {
+			s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+			return
+		}
+	}
+	unlockAuth, err := s.clients.LockAuth(customerId)
+	if err != nil {
+		s.WriteError(w, err)
+		return
+	}
+	defer unlockAuth()
+	// Revoke the access token and remove the credential cache
+	response, err := ClearAuth(customerId, eTradeClient, credentialStore)
+	if err != nil {
 		s.WriteError(w, err)
+		return
 	}
+	// Remove cached ETradeClient
+	s.RemoveClientForCustomer(customerId)
+	s.WriteJsonMap(w, response)
 }
 
 func (s *eTradeServer) ListAccounts(w http.ResponseWriter, r *http.Request) {
@@ -225,6 +382,14 @@ func (s *eTradeServer) ViewPortfolio(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	concurrency, err := getConcurrencyWithDefaultFromValues(
+		r.URL.Query(), "concurrency", etradelib.DefaultLotsConcurrency,
+	)
+	if err != nil {
+		s.WriteError(w, err)
+		return
+	}
+
 	totalsRequired, err := getBoolWithDefaultFromValues(r.URL.Query(), "totalsRequired", true)
 	if err != nil {
 		s.WriteError(w, err)
@@ -265,7 +430,8 @@ func (s *eTradeServer) ViewPortfolio(w http.ResponseWriter, r *http.Request) {
 
 	if eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient); ok {
 		if response, err := ViewPortfolio(
-			eTradeClient, accountId, sortBy, sortOrder, marketSession, totalsRequired, portfolioView, withLots,
+			r.Context(), eTradeClient, accountId, sortBy, sortOrder, marketSession, totalsRequired, portfolioView, withLots,
+			concurrency,
 		); err == nil {
 			s.WriteJsonMap(w, response)
 		} else {
@@ -276,6 +442,25 @@ func (s *eTradeServer) ViewPortfolio(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *eTradeServer) ListPositionLots(w http.ResponseWriter, r *http.Request) {
+	accountId := chi.URLParam(r, "accountId")
+	positionId, err := strconv.ParseInt(chi.URLParam(r, "positionId"), 10, 64)
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(errors.New("position ID must be a number")))
+		return
+	}
+
+	if eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient); ok {
+		if response, err := ListPositionLots(eTradeClient, accountId, positionId); err == nil {
+			s.WriteJsonMap(w, response)
+		} else {
+			s.WriteError(w, err)
+		}
+	} else {
+		s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+	}
+}
+
 func (s *eTradeServer) ListTransactions(w http.ResponseWriter, r *http.Request) {
 	accountId := chi.URLParam(r, "accountId")
 
@@ -382,6 +567,87 @@ func (s *eTradeServer) ListOrders(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *eTradeServer) PreviewOrder(w http.ResponseWriter, r *http.Request) {
+	accountId := chi.URLParam(r, "accountId")
+	order, _, err := readServerOrderRequest(r.Body, accountId, false)
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(err))
+		return
+	}
+
+	if eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient); ok {
+		if response, err := PreviewOrder(eTradeClient, accountId, order); err == nil {
+			s.WriteJsonMap(w, response)
+		} else {
+			s.WriteError(w, err)
+		}
+	} else {
+		s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+	}
+}
+
+func (s *eTradeServer) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	accountId := chi.URLParam(r, "accountId")
+	order, previewId, err := readServerOrderRequest(r.Body, accountId, true)
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(err))
+		return
+	}
+
+	if eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient); ok {
+		if response, err := PlaceOrder(eTradeClient, accountId, previewId, order); err == nil {
+			s.WriteJsonMap(w, response)
+		} else {
+			s.WriteError(w, err)
+		}
+	} else {
+		s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+	}
+}
+
+func (s *eTradeServer) ChangeOrder(w http.ResponseWriter, r *http.Request) {
+	accountId := chi.URLParam(r, "accountId")
+	orderId, err := strconv.ParseInt(chi.URLParam(r, "orderId"), 10, 64)
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(errors.New("order ID must be a number")))
+		return
+	}
+	changes, previewOnly, err := readServerOrderChanges(r.Body)
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(err))
+		return
+	}
+
+	if eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient); ok {
+		if response, err := ChangeOrder(eTradeClient, accountId, orderId, changes, previewOnly); err == nil {
+			s.WriteJsonMap(w, response)
+		} else {
+			s.WriteError(w, err)
+		}
+	} else {
+		s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+	}
+}
+
+func (s *eTradeServer) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	accountId := chi.URLParam(r, "accountId")
+	orderId, err := strconv.ParseInt(chi.URLParam(r, "orderId"), 10, 64)
+	if err != nil {
+		s.WriteError(w, newInvalidRequestError(errors.New("order ID must be a number")))
+		return
+	}
+
+	if eTradeClient, ok := r.Context().Value("eTradeClient").(client.ETradeClient); ok {
+		if response, err := CancelOrder(eTradeClient, accountId, orderId); err == nil {
+			s.WriteJsonMap(w, response)
+		} else {
+			s.WriteError(w, err)
+		}
+	} else {
+		s.WriteError(w, errors.New("unable to find ETrade client for customer"))
+	}
+}
+
 func (s *eTradeServer) ListAlerts(w http.ResponseWriter, r *http.Request) {
 	count, err := getIntWithDefaultFromValues(r.URL.Query(), "count", -1)
 	if err != nil {
@@ -457,7 +723,7 @@ func (s *eTradeServer) DeleteAlert(w http.ResponseWriter, r *http.Request) {
 func (s *eTradeServer) Lookup(w http.ResponseWriter, r *http.Request) {
 	search := getStringWithDefaultFromValues(r.URL.Query(), "search", "")
 	if search == "" {
-		s.WriteError(w, errors.New("missing search query"))
+		s.WriteError(w, newInvalidRequestError(errors.New("missing search query")))
 		return
 	}
 
@@ -511,7 +777,7 @@ func (s *eTradeServer) GetQuote(w http.ResponseWriter, r *http.Request) {
 func (s *eTradeServer) GetOptionChains(w http.ResponseWriter, r *http.Request) {
 	symbol := getStringWithDefaultFromValues(r.URL.Query(), "symbol", "")
 	if symbol == "" {
-		s.WriteError(w, errors.New("missing symbol"))
+		s.WriteError(w, newInvalidRequestError(errors.New("missing symbol")))
 		return
 	}
 
@@ -598,7 +864,7 @@ func (s *eTradeServer) GetOptionChains(w http.ResponseWriter, r *http.Request) {
 func (s *eTradeServer) GetOptionExpire(w http.ResponseWriter, r *http.Request) {
 	symbol := getStringWithDefaultFromValues(r.URL.Query(), "symbol", "")
 	if symbol == "" {
-		s.WriteError(w, errors.New("missing symbol"))
+		s.WriteError(w, newInvalidRequestError(errors.New("missing symbol")))
 		return
 	}
 	expiryType, err := getEnumFlagWithDefaultFromValues(
@@ -634,9 +900,12 @@ func (s *eTradeServer) WriteJsonMap(w http.ResponseWriter, jsonMap jsonmap.JsonM
 	}
 }
 
+// WriteError responds with the error's JSON error envelope and the HTTP status
+// that classifyServerError chooses for it.
 func (s *eTradeServer) WriteError(w http.ResponseWriter, err error) {
+	statusCode, code := classifyServerError(err)
 	s.logger.Error(fmt.Errorf("server encountered an error processing request (%w)", err).Error())
-	responseMap := client.NewStatusMap("error", "error", err.Error())
+	responseMap := newServerErrorJsonMap(code, err)
 	responseBytes, err := responseMap.ToJsonBytes(false, false)
 	if err != nil {
 		s.logger.Error(fmt.Errorf("marshaling JSON error response failed (%w)", err).Error())
@@ -644,7 +913,7 @@ func (s *eTradeServer) WriteError(w http.ResponseWriter, err error) {
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusInternalServerError)
+	w.WriteHeader(statusCode)
 	if _, err = w.Write(responseBytes); err != nil {
 		s.logger.Error(fmt.Errorf("writing JSON error response failed (%w)", err).Error())
 	}
@@ -662,11 +931,31 @@ func getIntWithDefaultFromValues(v url.Values, key string, defaultValue int) (in
 		return defaultValue, nil
 	}
 	stringValue := v.Get(key)
-	if value, err := strconv.Atoi(stringValue); err != nil {
+	if value, err := strconv.Atoi(stringValue); err == nil {
 		return value, nil
 	} else {
-		return 0, fmt.Errorf("%s is not a valid integer (%w)", stringValue, err)
+		return 0, newInvalidRequestError(fmt.Errorf("%s is not a valid integer (%w)", stringValue, err))
+	}
+}
+
+// maxServerConcurrency is the most requests that one server request may make
+// to ETrade at once, whatever concurrency the client asks for.
+const maxServerConcurrency = 16
+
+// getConcurrencyWithDefaultFromValues returns a concurrency, which must be at
+// least 1 and is limited to maxServerConcurrency.
+func getConcurrencyWithDefaultFromValues(v url.Values, key string, defaultValue int) (int, error) {
+	value, err := getIntWithDefaultFromValues(v, key, defaultValue)
+	if err != nil {
+		return 0, err
+	}
+	if value < 1 {
+		return 0, newInvalidRequestError(fmt.Errorf("%s must be at least 1", key))
+	}
+	if value > maxServerConcurrency {
+		return maxServerConcurrency, nil
 	}
+	return value, nil
 }
 
 func getBoolWithDefaultFromValues(v url.Values, key string, defaultValue bool) (bool, error) {
@@ -677,7 +966,7 @@ func getBoolWithDefaultFromValues(v url.Values, key string, defaultValue bool) (
 	if value, err := strconv.ParseBool(stringValue); err == nil {
 		return value, nil
 	} else {
-		return false, fmt.Errorf("%s is not a valid boolean (%w)", stringValue, err)
+		return false, newInvalidRequestError(fmt.Errorf("%s is not a valid boolean (%w)", stringValue, err))
 	}
 }
 
@@ -691,7 +980,7 @@ func getDateWithDefaultFromValues(v url.Values, key string, layout string, defau
 	if value, err := time.Parse(layout, stringValue); err == nil {
 		return &value, nil
 	} else {
-		return nil, fmt.Errorf("%s is not a valid time/date (%w)", stringValue, err)
+		return nil, newInvalidRequestError(fmt.Errorf("%s is not a valid time/date (%w)", stringValue, err))
 	}
 }
 
@@ -706,6 +995,8 @@ func getEnumFlagWithDefaultFromValues[T comparable](
 		return value, nil
 	} else {
 		var retVal T
-		return retVal, fmt.Errorf("%s is not a valid value for %s (%w)", enumString, key, err)
+		return retVal, newInvalidRequestError(
+			fmt.Errorf("%s is not a valid value for %s (%w)", enumString, key, err),
+		)
 	}
 }
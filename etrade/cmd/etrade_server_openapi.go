@@ -0,0 +1,631 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// serverOperation documents one of the server's routes in its OpenAPI
+// document. Every route that the server registers must have one (see
+// TestServerOpenApi_DocumentsEveryRoute).
+type serverOperation struct {
+	method      string
+	path        string
+	operationId string
+	summary     string
+	// capability is the capability that the API key must have for the
+	// customer, or empty if any key for the customer will do. It is ignored
+	// for routes that aren't under a customer.
+	capability string
+	// public routes don't require an API key.
+	public bool
+	// parameters are the query parameters. Path parameters are documented
+	// from serverPathParameters.
+	parameters []serverParameter
+	// formParameters are sent as a URL-encoded form body.
+	formParameters []serverParameter
+	// body is the schema of a JSON request body, or nil if there isn't one.
+	body jsonmap.JsonMap
+}
+
+type serverParameter struct {
+	name        string
+	description string
+	schema      jsonmap.JsonMap
+	required    bool
+	// repeated parameters may be given more than once.
+	repeated bool
+}
+
+// serverPathParameters describes each parameter that appears in a route's
+// path.
+var serverPathParameters = map[string]string{
+	"customerId":    "The customer's ID in the configuration file",
+	"accountId":     "The account ID (not the account ID key)",
+	"positionId":    "The position ID, as listed in the account's portfolio",
+	"transactionId": "The transaction ID",
+	"orderId":       "The order ID",
+	"alertId":       "The alert ID",
+}
+
+var serverPathParameterRegexp = regexp.MustCompile(`\{([^}]+)}`)
+
+func stringSchema() jsonmap.JsonMap {
+	return jsonmap.JsonMap{"type": "string"}
+}
+
+func integerSchema() jsonmap.JsonMap {
+	return jsonmap.JsonMap{"type": "integer"}
+}
+
+func numberSchema() jsonmap.JsonMap {
+	return jsonmap.JsonMap{"type": "number"}
+}
+
+func booleanSchema(defaultValue bool) jsonmap.JsonMap {
+	return jsonmap.JsonMap{"type": "boolean", "default": defaultValue}
+}
+
+// dateSchema is the schema of a date formatted as MMDDYYYY.
+func dateSchema() jsonmap.JsonMap {
+	return jsonmap.JsonMap{"type": "string", "pattern": "^[0-9]{8}$"}
+}
+
+// enumSchema is the schema of a string that must be one of an enum map's
+// values.
+func enumSchema[T comparable](enumMap enumValueWithHelpMap[T]) jsonmap.JsonMap {
+	values := make([]string, 0, len(enumMap))
+	for value := range enumMap {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return jsonmap.JsonMap{"type": "string", "enum": values}
+}
+
+func withDefault(schema jsonmap.JsonMap, defaultValue interface{}) jsonmap.JsonMap {
+	schema["default"] = defaultValue
+	return schema
+}
+
+// objectSchema is the schema of an object with the given properties, which
+// rejects any others.
+func objectSchema(properties jsonmap.JsonMap, required ...string) jsonmap.JsonMap {
+	schema := jsonmap.JsonMap{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// orderRequestSchema is the schema of a serverOrderRequest. If forPlacement
+// is set, the preview ID and client order ID are required.
+func orderRequestSchema(forPlacement bool) jsonmap.JsonMap {
+	properties := jsonmap.JsonMap{
+		"accountId":     stringSchema(),
+		"clientOrderId": stringSchema(),
+		"orderType":     enumSchema(orderTypeMap),
+		"priceType":     withDefault(enumSchema(orderPriceTypeMap), "limit"),
+		"orderTerm":     withDefault(enumSchema(orderTermMap), "goodForDay"),
+		"marketSession": withDefault(enumSchema(marketSessionMap), "regular"),
+		"limitPrice":    numberSchema(),
+		"stopPrice":     numberSchema(),
+		"allOrNone":     booleanSchema(false),
+		"symbol":        stringSchema(),
+		"action":        enumSchema(orderActionMap),
+		"quantity":      integerSchema(),
+		"legs": jsonmap.JsonMap{
+			"type": "array",
+			"items": objectSchema(
+				jsonmap.JsonMap{
+					"action":   enumSchema(orderActionMap),
+					"quantity": integerSchema(),
+					"symbol":   stringSchema(),
+				}, "action", "quantity", "symbol",
+			),
+		},
+	}
+	if !forPlacement {
+		return objectSchema(properties)
+	}
+	properties["previewId"] = integerSchema()
+	return objectSchema(properties, "previewId", "clientOrderId")
+}
+
+var serverOperations = []serverOperation{
+	{
+		method:      http.MethodGet,
+		path:        "/openapi.json",
+		operationId: "getOpenApiDocument",
+		summary:     "Get this OpenAPI document",
+		public:      true,
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers",
+		operationId: "listCustomers",
+		summary:     "List the customers that the API key may access",
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/auth",
+		operationId: "getAuthStatus",
+		summary:     "Get the status of the customer's cached access token without contacting ETrade",
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/customers/{customerId}/auth",
+		operationId: "login",
+		summary: "Begin authentication, or complete it with a verification code. Beginning authentication " +
+			"either succeeds (if the cached access token is still valid) or returns a URL at which to " +
+			"authorize the application and get a verification code.",
+		capability: serverCapabilityAuth,
+		formParameters: []serverParameter{
+			{name: "verifyCode", description: "The code from the authorization URL", schema: stringSchema()},
+		},
+	},
+	{
+		method:      http.MethodDelete,
+		path:        "/customers/{customerId}/auth",
+		operationId: "logout",
+		summary:     "Revoke the access token with ETrade and clear the cached credentials",
+		capability:  serverCapabilityAuth,
+		formParameters: []serverParameter{
+			{
+				name:        "localOnly",
+				description: "Clear the cached credentials without revoking the access token",
+				schema:      booleanSchema(false),
+			},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts",
+		operationId: "listAccounts",
+		summary:     "List the customer's accounts",
+		capability:  serverCapabilityAccounts,
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts/{accountId}/balance",
+		operationId: "getAccountBalances",
+		summary:     "Get an account's balances",
+		capability:  serverCapabilityAccounts,
+		parameters: []serverParameter{
+			{name: "realTimeBalance", description: "Include the real time balance", schema: booleanSchema(true)},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts/{accountId}/portfolio",
+		operationId: "viewPortfolio",
+		summary:     "Get an account's portfolio",
+		capability:  serverCapabilityAccounts,
+		parameters: []serverParameter{
+			{name: "totalsRequired", description: "Include totals", schema: booleanSchema(true)},
+			{
+				name:        "view",
+				description: "The portfolio view to return",
+				schema:      withDefault(enumSchema(portfolioViewMap), "quick"),
+			},
+			{name: "sortBy", description: "The value by which to sort positions", schema: enumSchema(portfolioSortByMap)},
+			{name: "sortOrder", description: "The sort order", schema: enumSchema(sortOrderMap)},
+			{
+				name:        "marketSession",
+				description: "The market session from which to return results",
+				schema:      enumSchema(marketSessionMap),
+			},
+			{
+				name: "withLots",
+				description: "Include the lots for each position. This takes significantly longer, because the " +
+					"lots for each position require a separate request.",
+				schema: booleanSchema(false),
+			},
+			{
+				name: "concurrency",
+				description: "The number of positions to fetch lots for at once. Requests still respect the " +
+					"customer's rate limits, and larger values are reduced to the maximum.",
+				schema: withDefault(
+					jsonmap.JsonMap{"type": "integer", "minimum": 1, "maximum": maxServerConcurrency},
+					etradelib.DefaultLotsConcurrency,
+				),
+			},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts/{accountId}/positions/{positionId}/lots",
+		operationId: "listPositionLots",
+		summary:     "List the lots that make up a position",
+		capability:  serverCapabilityAccounts,
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts/{accountId}/transactions",
+		operationId: "listTransactions",
+		summary:     "List an account's transactions",
+		capability:  serverCapabilityAccounts,
+		parameters: []serverParameter{
+			{
+				name:        "startDate",
+				description: "The earliest date to include (MMDDYYYY). History is available for two years.",
+				schema:      dateSchema(),
+			},
+			{name: "endDate", description: "The latest date to include (MMDDYYYY)", schema: dateSchema()},
+			{name: "sortOrder", description: "The sort order", schema: enumSchema(sortOrderMap)},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts/{accountId}/transactions/{transactionId}",
+		operationId: "getTransactionDetails",
+		summary:     "Get the details of a transaction",
+		capability:  serverCapabilityAccounts,
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/accounts/{accountId}/orders",
+		operationId: "listOrders",
+		summary:     "List an account's orders",
+		capability:  serverCapabilityAccounts,
+		parameters: []serverParameter{
+			{
+				name:        "symbol",
+				description: "List only orders for these symbols (up to 25)",
+				schema:      stringSchema(),
+				repeated:    true,
+			},
+			{
+				name: "fromDate",
+				description: "The earliest date to include (MMDDYYYY). History is available for two years. " +
+					"If given, toDate must also be given.",
+				schema: dateSchema(),
+			},
+			{
+				name:        "toDate",
+				description: "The latest date to include (MMDDYYYY). If given, fromDate must also be given.",
+				schema:      dateSchema(),
+			},
+			{name: "status", description: "List only orders with this status", schema: enumSchema(orderStatusMap)},
+			{
+				name:        "securityType",
+				description: "List only orders for securities of this type",
+				schema:      enumSchema(orderSecurityTypeMap),
+			},
+			{
+				name:        "transactionType",
+				description: "List only orders with this transaction type",
+				schema:      enumSchema(orderTransactionTypeMap),
+			},
+			{
+				name:        "marketSession",
+				description: "The market session from which to return results",
+				schema:      enumSchema(marketSessionMap),
+			},
+		},
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/customers/{customerId}/accounts/{accountId}/orders/preview",
+		operationId: "previewOrder",
+		summary: "Preview an order. The order has the same layout as an order in an order file. If it " +
+			"doesn't have a client order ID, one is generated and returned in the preview.",
+		capability: serverCapabilityTrade,
+		body:       orderRequestSchema(false),
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/customers/{customerId}/accounts/{accountId}/orders",
+		operationId: "placeOrder",
+		summary: "Place a previewed order. The order must be the same as the one that was previewed, " +
+			"including its client order ID, and must include the preview ID.",
+		capability: serverCapabilityTrade,
+		body:       orderRequestSchema(true),
+	},
+	{
+		method:      http.MethodPut,
+		path:        "/customers/{customerId}/accounts/{accountId}/orders/{orderId}",
+		operationId: "changeOrder",
+		summary:     "Change an open order. Fields that are left out aren't changed.",
+		capability:  serverCapabilityTrade,
+		body: objectSchema(
+			jsonmap.JsonMap{
+				"limitPrice":  numberSchema(),
+				"stopPrice":   numberSchema(),
+				"quantity":    integerSchema(),
+				"orderTerm":   enumSchema(orderTermMap),
+				"previewOnly": booleanSchema(false),
+			},
+		),
+	},
+	{
+		method:      http.MethodDelete,
+		path:        "/customers/{customerId}/accounts/{accountId}/orders/{orderId}",
+		operationId: "cancelOrder",
+		summary:     "Cancel an open order",
+		capability:  serverCapabilityTrade,
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/alerts",
+		operationId: "listAlerts",
+		summary:     "List the customer's alerts",
+		capability:  serverCapabilityAccounts,
+		parameters: []serverParameter{
+			{name: "count", description: "The maximum number of alerts to list", schema: integerSchema()},
+			{name: "search", description: "List only alerts whose subjects include this string", schema: stringSchema()},
+			{name: "category", description: "List only alerts in this category", schema: enumSchema(alertCategoryMap)},
+			{name: "status", description: "List only alerts with this status", schema: enumSchema(alertStatusMap)},
+			{name: "sortOrder", description: "The sort order", schema: enumSchema(sortOrderMap)},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/alerts/{alertId}",
+		operationId: "getAlertDetails",
+		summary:     "Get the details of an alert",
+		capability:  serverCapabilityAccounts,
+	},
+	{
+		method:      http.MethodDelete,
+		path:        "/customers/{customerId}/alerts/{alertId}",
+		operationId: "deleteAlert",
+		summary:     "Delete an alert",
+		capability:  serverCapabilityTrade,
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/market/lookup",
+		operationId: "lookup",
+		summary:     "Search for a company and get matching symbols",
+		capability:  serverCapabilityMarket,
+		parameters: []serverParameter{
+			{name: "search", description: "The string to search for", schema: stringSchema(), required: true},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/market/quote",
+		operationId: "getQuotes",
+		summary:     "Get quotes for one or more symbols",
+		capability:  serverCapabilityMarket,
+		parameters: []serverParameter{
+			{
+				name:        "symbol",
+				description: "The symbols to quote (up to 25)",
+				schema:      stringSchema(),
+				required:    true,
+				repeated:    true,
+			},
+			{
+				name:        "detail",
+				description: "The quote detail to return",
+				schema:      withDefault(enumSchema(quoteDetailMap), "all"),
+			},
+			{
+				name:        "requireEarningsDate",
+				description: "Include the next earnings date",
+				schema:      booleanSchema(true),
+			},
+			{
+				name:        "skipMiniOptionsCheck",
+				description: "Skip checking whether the symbol has mini options",
+				schema:      booleanSchema(false),
+			},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/market/optionchains",
+		operationId: "getOptionChains",
+		summary:     "Get the option chains for a symbol",
+		capability:  serverCapabilityMarket,
+		parameters: []serverParameter{
+			{name: "symbol", description: "The symbol", schema: stringSchema(), required: true},
+			{name: "expiryYear", description: "Get options that expire in this year", schema: integerSchema()},
+			{name: "expiryMonth", description: "Get options that expire in this month (1-12)", schema: integerSchema()},
+			{name: "expiryDay", description: "Get options that expire on this day (1-31)", schema: integerSchema()},
+			{
+				name:        "strikePriceNear",
+				description: "Get options with strike prices near this price",
+				schema:      integerSchema(),
+			},
+			{name: "noOfStrikes", description: "The number of strikes to get", schema: integerSchema()},
+			{name: "includeWeekly", description: "Include weekly options", schema: booleanSchema(true)},
+			{name: "skipAdjusted", description: "Skip adjusted options", schema: booleanSchema(false)},
+			{
+				name:        "optionCategory",
+				description: "Get only options in this category",
+				schema:      enumSchema(optionCategoryMap),
+			},
+			{name: "chainType", description: "Get only this type of chain", schema: enumSchema(optionChainTypeMap)},
+			{
+				name:        "priceType",
+				description: "Get only options with this price type",
+				schema:      enumSchema(optionPriceTypeMap),
+			},
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/customers/{customerId}/market/optionexpire",
+		operationId: "getOptionExpireDates",
+		summary:     "Get the option expiration dates for a symbol",
+		capability:  serverCapabilityMarket,
+		parameters: []serverParameter{
+			{name: "symbol", description: "The symbol", schema: stringSchema(), required: true},
+			{
+				name:        "expiryType",
+				description: "Get only this type of expiration",
+				schema:      enumSchema(optionExpiryTypeMap),
+			},
+		},
+	},
+}
+
+// newServerOpenApiDocument returns the OpenAPI 3 document that describes the
+// server's API.
+func newServerOpenApiDocument() jsonmap.JsonMap {
+	paths := jsonmap.JsonMap{}
+	for _, operation := range serverOperations {
+		pathItem, ok := paths[operation.path].(jsonmap.JsonMap)
+		if !ok {
+			pathItem = jsonmap.JsonMap{}
+			paths[operation.path] = pathItem
+		}
+		pathItem[strings.ToLower(operation.method)] = operation.asJsonMap()
+	}
+	return jsonmap.JsonMap{
+		"openapi": "3.0.3",
+		"info": jsonmap.JsonMap{
+			"title": "E*TRADE CLI Server",
+			"description": "Access to ETrade accounts and market data for the customers in the server's " +
+				"configuration file.",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"security": jsonmap.JsonSlice{
+			jsonmap.JsonMap{"bearerAuth": jsonmap.JsonSlice{}},
+			jsonmap.JsonMap{"apiKeyAuth": jsonmap.JsonSlice{}},
+		},
+		"components": jsonmap.JsonMap{
+			"securitySchemes": jsonmap.JsonMap{
+				"bearerAuth": jsonmap.JsonMap{"type": "http", "scheme": "bearer"},
+				"apiKeyAuth": jsonmap.JsonMap{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+			"schemas": jsonmap.JsonMap{
+				"Error": jsonmap.JsonMap{
+					"type": "object",
+					"properties": jsonmap.JsonMap{
+						"status": jsonmap.JsonMap{"type": "string", "enum": []string{"error"}},
+						"code": jsonmap.JsonMap{
+							"type": "string",
+							"enum": serverErrorCodes,
+							"description": "What went wrong. `invalidApiKey` (401) means the API key is missing or " +
+								"unknown, `loginRequired` (401) means the customer must log in again, " +
+								"`forbidden` (403) means the API key may not make the request, `invalidRequest` " +
+								"(400) means a parameter or the body is invalid, `riskLimitExceeded` (400) means the " +
+								"customer's risk limits rejected an order, `customerNotFound` (404) means the " +
+								"customer isn't configured, `accountNotFound` (404) and `orderNotFound` (404) " +
+								"mean the account or open order doesn't exist, `upstreamError` (502) means ETrade failed or " +
+								"couldn't be reached, and `internalError` (500) means the server failed.",
+						},
+						"message": stringSchema(),
+						"upstreamStatus": jsonmap.JsonMap{
+							"type":        "integer",
+							"description": "ETrade's HTTP status, if ETrade responded with an error",
+						},
+						"etradeError": jsonmap.JsonMap{
+							"type":        "object",
+							"description": "The error response from ETrade, if ETrade responded with an error",
+							"properties": jsonmap.JsonMap{
+								"httpStatus": integerSchema(),
+								"code":       integerSchema(),
+								"message":    stringSchema(),
+								"body":       stringSchema(),
+							},
+						},
+					},
+					"required": []string{"status", "code", "message"},
+				},
+			},
+			"responses": jsonmap.JsonMap{
+				"Error": jsonmap.JsonMap{
+					"description": "The request failed",
+					"content": jsonmap.JsonMap{
+						"application/json": jsonmap.JsonMap{
+							"schema": jsonmap.JsonMap{"$ref": "#/components/schemas/Error"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (o *serverOperation) asJsonMap() jsonmap.JsonMap {
+	description := o.summary
+	if o.capability != "" {
+		description += fmt.Sprintf(" Requires the `%s` capability for the customer.", o.capability)
+	}
+	parameters := jsonmap.JsonSlice{}
+	for _, match := range serverPathParameterRegexp.FindAllStringSubmatch(o.path, -1) {
+		parameters = append(
+			parameters, jsonmap.JsonMap{
+				"name":        match[1],
+				"in":          "path",
+				"required":    true,
+				"description": serverPathParameters[match[1]],
+				"schema":      stringSchema(),
+			},
+		)
+	}
+	for _, parameter := range o.parameters {
+		parameterMap := jsonmap.JsonMap{
+			"name":        parameter.name,
+			"in":          "query",
+			"required":    parameter.required,
+			"description": parameter.description,
+			"schema":      parameter.schema,
+		}
+		if parameter.repeated {
+			parameterMap["schema"] = jsonmap.JsonMap{"type": "array", "items": parameter.schema}
+			parameterMap["style"] = "form"
+			parameterMap["explode"] = true
+		}
+		parameters = append(parameters, parameterMap)
+	}
+
+	operationMap := jsonmap.JsonMap{
+		"operationId": o.operationId,
+		"summary":     o.summary,
+		"description": description,
+		"parameters":  parameters,
+		"responses": jsonmap.JsonMap{
+			"200": jsonmap.JsonMap{
+				"description": "Success",
+				"content": jsonmap.JsonMap{
+					"application/json": jsonmap.JsonMap{"schema": jsonmap.JsonMap{"type": "object"}},
+				},
+			},
+			"default": jsonmap.JsonMap{"$ref": "#/components/responses/Error"},
+		},
+	}
+	if o.public {
+		operationMap["security"] = jsonmap.JsonSlice{}
+	}
+	if o.body != nil {
+		operationMap["requestBody"] = jsonmap.JsonMap{
+			"required": true,
+			"content":  jsonmap.JsonMap{"application/json": jsonmap.JsonMap{"schema": o.body}},
+		}
+	}
+	if len(o.formParameters) > 0 {
+		properties := jsonmap.JsonMap{}
+		for _, parameter := range o.formParameters {
+			schema := jsonmap.JsonMap{}
+			for k, v := range parameter.schema {
+				schema[k] = v
+			}
+			schema["description"] = parameter.description
+			properties[parameter.name] = schema
+		}
+		operationMap["requestBody"] = jsonmap.JsonMap{
+			"required": false,
+			"content": jsonmap.JsonMap{
+				"application/x-www-form-urlencoded": jsonmap.JsonMap{
+					"schema": jsonmap.JsonMap{"type": "object", "properties": properties},
+				},
+			},
+		}
+	}
+	return operationMap
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestServerOpenApi_DocumentsEveryRoute(t *testing.T) {
+	cfgStore, err := LoadCustomerConfigurationStore(strings.NewReader("{}"))
+	require.Nil(t, err)
+	eTradeServer, err := NewETradeServer(
+		"", etradelibtest.CreateNullLogger(), NewConfigurationFolder(t.TempDir()), cfgStore, true,
+	)
+	require.Nil(t, err)
+	routes, ok := eTradeServer.Handler.(chi.Routes)
+	require.True(t, ok)
+
+	var serverRoutes []string
+	require.Nil(
+		t, chi.Walk(
+			routes,
+			func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+				// Routes declared inside r.Route() end in "/*" until they're
+				// flattened, and the routes beneath them have a trailing slash.
+				route = strings.TrimSuffix(strings.ReplaceAll(route, "/*/", "/"), "/")
+				serverRoutes = append(serverRoutes, method+" "+route)
+				return nil
+			},
+		),
+	)
+
+	// Call the Method Under Test
+	document := newServerOpenApiDocument()
+
+	var documentedRoutes []string
+	paths, err := document.GetMap("paths")
+	require.Nil(t, err)
+	for path, pathItem := range paths {
+		for method := range pathItem.(jsonmap.JsonMap) {
+			documentedRoutes = append(documentedRoutes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(serverRoutes)
+	sort.Strings(documentedRoutes)
+	assert.Equal(t, serverRoutes, documentedRoutes, "every server route must be in the OpenAPI document")
+}
+
+func TestServerOpenApi_DocumentsPathParameters(t *testing.T) {
+	for _, operation := range serverOperations {
+		for _, match := range serverPathParameterRegexp.FindAllStringSubmatch(operation.path, -1) {
+			assert.Contains(
+				t, serverPathParameters, match[1], "%s %s has an undescribed path parameter",
+				operation.method, operation.path,
+			)
+		}
+	}
+}
+
+func TestETradeServer_ServesOpenApiDocumentWithoutKey(t *testing.T) {
+	serverUrl := newTestETradeServer(
+		t, []string{"alice"}, map[string][]CustomerServerKey{
+			"alice": {{Key: "aliceReader", Capabilities: []string{"accounts"}}},
+		},
+	)
+
+	// Call the Method Under Test
+	response, err := http.Get(serverUrl + "/openapi.json")
+	require.Nil(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var document struct {
+		OpenApi string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	require.Nil(t, json.NewDecoder(response.Body).Decode(&document))
+	assert.Equal(t, "3.0.3", document.OpenApi)
+	assert.Contains(t, document.Paths, "/customers/{customerId}/accounts/{accountId}/orders")
+	assert.Contains(t, document.Paths, "/customers/{customerId}/accounts/{accountId}/positions/{positionId}/lots")
+}
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/fakeetrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestETradeServer returns the URL of a server whose customers are logged
+// in to a fake ETrade server seeded with the default fixture. serverKeys gives
+// the server keys for each customer.
+func newTestETradeServer(t *testing.T, customerIds []string, serverKeys map[string][]CustomerServerKey) string {
+	logger := etradelibtest.CreateNullLogger()
+	state := fakeetrade.DefaultState()
+	cfgFolder := NewConfigurationFolder(t.TempDir())
+	cfgStore, err := LoadCustomerConfigurationStore(strings.NewReader("{}"))
+	require.Nil(t, err)
+
+	// Credentials are cached by consumer key, so each customer needs its own
+	// key.
+	for _, customerId := range customerIds {
+		consumerKey := "consumerKey" + customerId
+		state.Consumers = append(state.Consumers, fakeetrade.Consumer{Key: consumerKey, Secret: "consumerSecret"})
+		state.AccessTokens = append(
+			state.AccessTokens,
+			fakeetrade.AccessToken{ConsumerKey: consumerKey, Token: "token" + customerId, Secret: "secret"},
+		)
+	}
+	fakeServer := httptest.NewServer(fakeetrade.NewServer(state, logger))
+	t.Cleanup(fakeServer.Close)
+
+	for _, customerId := range customerIds {
+		consumerKey := "consumerKey" + customerId
+		cfgStore.SetCustomerConfigurationForId(
+			customerId, &CustomerConfiguration{
+				CustomerName:           customerId,
+				CustomerConsumerKey:    consumerKey,
+				CustomerConsumerSecret: "consumerSecret",
+				CustomerApiBaseUrl:     fakeServer.URL,
+				CustomerAuthorizeUrl:   fakeServer.URL + fakeetrade.AuthorizePath,
+				CustomerServerKeys:     serverKeys[customerId],
+			},
+		)
+		require.Nil(
+			t, cfgFolder.SaveCachedCredentialsToFile(
+				consumerKey, NewCachedCredentials("token"+customerId, "secret", nil, time.Now()), logger,
+			),
+		)
+	}
+
+	eTradeServer, err := NewETradeServer("", logger, cfgFolder, cfgStore, len(serverKeys) == 0)
+	require.Nil(t, err)
+	server := httptest.NewServer(eTradeServer.Handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestETradeServer_HandlesConcurrentRequests(t *testing.T) {
+	customerIds := []string{"alice", "bob"}
+	serverUrl := newTestETradeServer(t, customerIds, nil)
+
+	type testRequest struct {
+		method string
+		path   string
+	}
+	requests := []testRequest{
+		{http.MethodGet, "/accounts"},
+		{http.MethodGet, "/accounts/84910001/balance"},
+		{http.MethodGet, "/accounts/84910001/portfolio?withLots=true"},
+		{http.MethodPost, "/auth"},
+	}
+
+	// Call the Method Under Test
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+			request := requests[i%len(requests)]
+			url := fmt.Sprintf("%s/customers/%s%s", serverUrl, customerIds[i%len(customerIds)], request.path)
+			httpRequest, err := http.NewRequest(request.method, url, nil)
+			if !assert.Nil(t, err) {
+				return
+			}
+			response, err := http.DefaultClient.Do(httpRequest)
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, response.StatusCode, "%s %s: %s", request.method, url, body)
+		}(i)
+	}
+	waitGroup.Wait()
+}
+
+func TestETradeServer_ErrorResponses(t *testing.T) {
+	serverUrl := newTestETradeServer(t, []string{"alice", "bob"}, nil)
+	// Log bob out so that his requests fail for lack of credentials.
+	request, err := http.NewRequest(
+		http.MethodDelete, serverUrl+"/customers/bob/auth", strings.NewReader("localOnly=true"),
+	)
+	require.Nil(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := http.DefaultClient.Do(request)
+	require.Nil(t, err)
+	_ = response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	tests := []struct {
+		name                 string
+		path                 string
+		expectStatus         int
+		expectCode           string
+		expectUpstreamStatus int
+	}{
+		{
+			name:         "Unknown Customer",
+			path:         "/customers/mallory/accounts",
+			expectStatus: http.StatusNotFound,
+			expectCode:   "customerNotFound",
+		},
+		{
+			name:         "Invalid Parameter",
+			path:         "/customers/alice/accounts/84910001/portfolio?withLots=maybe",
+			expectStatus: http.StatusBadRequest,
+			expectCode:   "invalidRequest",
+		},
+		{
+			name:         "Concurrency Below 1",
+			path:         "/customers/alice/accounts/84910001/portfolio?concurrency=0",
+			expectStatus: http.StatusBadRequest,
+			expectCode:   "invalidRequest",
+		},
+		{
+			name:         "No Quote Symbols",
+			path:         "/customers/alice/market/quote",
+			expectStatus: http.StatusBadRequest,
+			expectCode:   "invalidRequest",
+		},
+		{
+			name:         "Too Many Quote Symbols",
+			path:         "/customers/alice/market/quote?symbol=" + strings.Repeat("AAPL&symbol=", 50) + "AAPL",
+			expectStatus: http.StatusBadRequest,
+			expectCode:   "invalidRequest",
+		},
+		{
+			name:         "Alerts Count Above Maximum",
+			path:         "/customers/alice/alerts?count=1000",
+			expectStatus: http.StatusBadRequest,
+			expectCode:   "invalidRequest",
+		},
+		{
+			name:         "Invalid Path Parameter",
+			path:         "/customers/alice/accounts/84910001/positions/abc/lots",
+			expectStatus: http.StatusBadRequest,
+			expectCode:   "invalidRequest",
+		},
+		{
+			name:         "Unknown Account",
+			path:         "/customers/alice/accounts/99999999/balance",
+			expectStatus: http.StatusNotFound,
+			expectCode:   "accountNotFound",
+		},
+		{
+			name:         "Customer Not Logged In",
+			path:         "/customers/bob/accounts",
+			expectStatus: http.StatusUnauthorized,
+			expectCode:   "loginRequired",
+		},
+		{
+			name:                 "ETrade Error",
+			path:                 "/customers/alice/accounts/84910001/transactions/1",
+			expectStatus:         http.StatusBadGateway,
+			expectCode:           "upstreamError",
+			expectUpstreamStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				response, err := http.Get(serverUrl + tt.path)
+				require.Nil(t, err)
+				defer response.Body.Close()
+
+				assert.Equal(t, tt.expectStatus, response.StatusCode)
+				assert.Equal(t, "application/json; charset=utf-8", response.Header.Get("Content-Type"))
+				var errorResponse struct {
+					Status         string `json:"status"`
+					Code           string `json:"code"`
+					Message        string `json:"message"`
+					UpstreamStatus int    `json:"upstreamStatus"`
+				}
+				require.Nil(t, json.NewDecoder(response.Body).Decode(&errorResponse))
+				assert.Equal(t, "error", errorResponse.Status)
+				assert.Equal(t, tt.expectCode, errorResponse.Code)
+				assert.NotEmpty(t, errorResponse.Message)
+				assert.Equal(t, tt.expectUpstreamStatus, errorResponse.UpstreamStatus)
+			},
+		)
+	}
+}
+
+func TestGetIntWithDefaultFromValues(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expectValue int
+		expectErr   bool
+	}{
+		{
+			name:        "Returns Default Without Value",
+			query:       "",
+			expectValue: 4,
+		},
+		{
+			name:        "Returns Value",
+			query:       "concurrency=8",
+			expectValue: 8,
+		},
+		{
+			name:      "Fails With Invalid Value",
+			query:     "concurrency=many",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				values, err := url.ParseQuery(tt.query)
+				require.Nil(t, err)
+
+				// Call the Method Under Test
+				value, err := getIntWithDefaultFromValues(values, "concurrency", 4)
+				if tt.expectErr {
+					statusCode, _ := classifyServerError(err)
+					assert.Equal(t, http.StatusBadRequest, statusCode)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.expectValue, value)
+				}
+			},
+		)
+	}
+}
+
+func TestGetConcurrencyWithDefaultFromValues(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expectValue int
+		expectErr   bool
+	}{
+		{
+			name:        "Returns Default Without Value",
+			query:       "",
+			expectValue: 4,
+		},
+		{
+			name:        "Returns Value",
+			query:       "concurrency=8",
+			expectValue: 8,
+		},
+		{
+			name:        "Limits Value To Maximum",
+			query:       "concurrency=1000000",
+			expectValue: maxServerConcurrency,
+		},
+		{
+			name:      "Fails With Zero",
+			query:     "concurrency=0",
+			expectErr: true,
+		},
+		{
+			name:      "Fails With Negative Value",
+			query:     "concurrency=-1",
+			expectErr: true,
+		},
+		{
+			name:      "Fails With Invalid Value",
+			query:     "concurrency=many",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				values, err := url.ParseQuery(tt.query)
+				require.Nil(t, err)
+
+				// Call the Method Under Test
+				value, err := getConcurrencyWithDefaultFromValues(values, "concurrency", 4)
+				if tt.expectErr {
+					statusCode, _ := classifyServerError(err)
+					assert.Equal(t, http.StatusBadRequest, statusCode)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.expectValue, value)
+				}
+			},
+		)
+	}
+}
+
+func TestETradeServer_OrderAndLotsRoutes(t *testing.T) {
+	serverUrl := newTestETradeServer(t, []string{"alice"}, nil)
+	accountUrl := serverUrl + "/customers/alice/accounts/84910001"
+	order := `{"clientOrderId": "serverorder", "orderType": "equity", "priceType": "limit", "limitPrice": 170, ` +
+		`"symbol": "AAPL", "action": "buy", "quantity": 5`
+	doRequest := func(method string, url string, body string) map[string]interface{} {
+		request, err := http.NewRequest(method, url, strings.NewReader(body))
+		require.Nil(t, err)
+		response, err := http.DefaultClient.Do(request)
+		require.Nil(t, err)
+		defer response.Body.Close()
+		responseBody, err := io.ReadAll(response.Body)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, response.StatusCode, "%s %s: %s", method, url, responseBody)
+		var responseMap map[string]interface{}
+		require.Nil(t, json.Unmarshal(responseBody, &responseMap))
+		return responseMap
+	}
+
+	// Call the Methods Under Test
+	lots := doRequest(http.MethodGet, accountUrl+"/positions/10001/lots", "")
+	assert.Equal(t, float64(10001), lots["positionId"])
+	assert.Len(t, lots["lots"], 2)
+
+	preview := doRequest(http.MethodPost, accountUrl+"/orders/preview", order+"}")
+	previewIds := preview["previewIds"].([]interface{})
+	require.Len(t, previewIds, 1)
+	previewId := int64(previewIds[0].(map[string]interface{})["previewId"].(float64))
+
+	placed := doRequest(http.MethodPost, accountUrl+"/orders", fmt.Sprintf(`%s, "previewId": %d}`, order, previewId))
+	orderIds := placed["orderIds"].([]interface{})
+	require.Len(t, orderIds, 1)
+	orderId := int64(orderIds[0].(map[string]interface{})["orderId"].(float64))
+	orderUrl := fmt.Sprintf("%s/orders/%d", accountUrl, orderId)
+
+	orders := doRequest(http.MethodGet, accountUrl+"/orders?status=open", "")
+	assert.Contains(t, fmt.Sprint(orders), fmt.Sprint(orderId))
+
+	doRequest(http.MethodPut, orderUrl, `{"limitPrice": 171}`)
+	doRequest(http.MethodDelete, orderUrl, "")
+
+	// ETrade rejects cancelling an order that is no longer open.
+	request, err := http.NewRequest(http.MethodDelete, orderUrl, nil)
+	require.Nil(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	var errorResponse struct {
+		Code           string `json:"code"`
+		UpstreamStatus int    `json:"upstreamStatus"`
+	}
+	require.Nil(t, json.NewDecoder(response.Body).Decode(&errorResponse))
+	assert.Equal(t, "upstreamError", errorResponse.Code)
+	assert.Equal(t, http.StatusBadRequest, errorResponse.UpstreamStatus)
+}
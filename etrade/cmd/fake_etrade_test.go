@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/fakeetrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"testing"
+)
+
+// newFakeETradeClient returns a client that is logged in to a fake ETrade
+// server seeded with the default fixture.
+func newFakeETradeClient(t *testing.T) client.ETradeClient {
+	httpServer := httptest.NewServer(fakeetrade.NewServer(fakeetrade.DefaultState(), etradelibtest.CreateNullLogger()))
+	t.Cleanup(httpServer.Close)
+	urls, err := client.NewEndpointUrls(httpServer.URL, httpServer.URL+fakeetrade.AuthorizePath)
+	require.Nil(t, err)
+	eTradeClient, err := client.CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "fakeConsumerKey", "fakeConsumerSecret", "fakeAccessToken",
+		"fakeAccessSecret", client.RetryPolicy{}, nil, nil,
+	)
+	require.Nil(t, err)
+	return eTradeClient
+}
+
+func TestFakeETrade_ViewPortfolio(t *testing.T) {
+	eTradeClient := newFakeETradeClient(t)
+
+	// Call the Method Under Test
+	portfolio, err := ViewPortfolio(
+		context.Background(), eTradeClient, "84910001", constants.PortfolioSortByNil, constants.SortOrderNil, constants.MarketSessionNil,
+		false, constants.PortfolioViewNil, true, 4,
+	)
+	require.Nil(t, err)
+
+	positions, err := portfolio.GetSlice("positions")
+	require.Nil(t, err)
+	require.Len(t, positions, 3)
+	symbol, err := portfolio.GetStringAtPath(".positions[0].product.symbol")
+	assert.Nil(t, err)
+	assert.Equal(t, "GOOG", symbol)
+	lots, err := portfolio.GetSliceAtPath(".positions[0].lots")
+	assert.Nil(t, err)
+	assert.Len(t, lots, 2)
+}
+
+func TestFakeETrade_ListOrders(t *testing.T) {
+	eTradeClient := newFakeETradeClient(t)
+
+	// Call the Method Under Test
+	orders, err := ListOrders(
+		eTradeClient, "84910001", constants.OrderStatusOpen, nil, nil, nil, constants.OrderSecurityTypeNil,
+		constants.OrderTransactionTypeNil, constants.MarketSessionNil,
+	)
+	require.Nil(t, err)
+
+	orderList, err := orders.GetSlice("orders")
+	require.Nil(t, err)
+	require.Len(t, orderList, 1)
+	orderId, err := orders.GetIntAtPath(".orders[0].orderId")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(501), orderId)
+}
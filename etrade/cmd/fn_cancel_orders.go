@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+const (
+	// The CancelOrders() map looks like this:
+	// {
+	//   "status": "error",
+	//   "error": "some orders could not be cancelled",
+	//   "cancelledOrders": [
+	//     {
+	//       <cancel order info>
+	//     }
+	//   ],
+	//   "failedOrders": [
+	//     {
+	//       "orderId": 1234,
+	//       "error": "<error message>"
+	//     }
+	//   ]
+	// }
+
+	// cancelOrdersStatusKey is the key for the status
+	cancelOrdersStatusKey = "status"
+
+	// cancelOrdersErrorKey is the key for the error message
+	cancelOrdersErrorKey = "error"
+
+	// cancelOrdersCancelledOrdersKey is the key for a slice of cancelled
+	// orders
+	cancelOrdersCancelledOrdersKey = "cancelledOrders"
+
+	// cancelOrdersFailedOrdersKey is the key for a slice of orders that
+	// could not be cancelled
+	cancelOrdersFailedOrdersKey = "failedOrders"
+)
+
+// CancelOrders cancels each of the given orders. A failure to cancel one
+// order does not prevent the others from being cancelled; failures are
+// reported in the returned map.
+func CancelOrders(eTradeClient client.ETradeClient, accountId string, orderIds []int64) (jsonmap.JsonMap, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	return cancelOrders(eTradeClient, account.GetIdKey(), orderIds), nil
+}
+
+// CancelOrder cancels a single order. Unlike CancelOrders, it returns the
+// error if the order can't be cancelled.
+func CancelOrder(eTradeClient client.ETradeClient, accountId string, orderId int64) (jsonmap.JsonMap, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	cancelledOrder, err := cancelOrder(eTradeClient, account.GetIdKey(), orderId)
+	if err != nil {
+		return nil, err
+	}
+	return jsonmap.JsonMap{
+		cancelOrdersStatusKey:          "success",
+		cancelOrdersCancelledOrdersKey: jsonmap.JsonSlice{cancelledOrder.AsJsonMap()},
+	}, nil
+}
+
+// CancelOpenOrders cancels every open order for the given symbols. At least
+// one symbol is required, so that a missing symbol can't cancel every open
+// order in the account.
+func CancelOpenOrders(eTradeClient client.ETradeClient, accountId string, symbols []string) (
+	jsonmap.JsonMap, error,
+) {
+	if len(symbols) == 0 {
+		return nil, errors.New("at least one symbol is required to cancel open orders")
+	}
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	orderList, err := listOrders(
+		eTradeClient, account.GetIdKey(), constants.OrderStatusOpen, nil, nil, symbols,
+		constants.OrderSecurityTypeNil, constants.OrderTransactionTypeNil, constants.MarketSessionNil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	orderIds := make([]int64, 0, len(orderList.GetAllOrders()))
+	for _, order := range orderList.GetAllOrders() {
+		orderIds = append(orderIds, order.GetId())
+	}
+	return cancelOrders(eTradeClient, account.GetIdKey(), orderIds), nil
+}
+
+func cancelOrders(eTradeClient client.ETradeClient, accountIdKey string, orderIds []int64) jsonmap.JsonMap {
+	cancelledOrders := jsonmap.JsonSlice{}
+	failedOrders := jsonmap.JsonSlice{}
+	for _, orderId := range orderIds {
+		cancelOrder, err := cancelOrder(eTradeClient, accountIdKey, orderId)
+		if err != nil {
+			failedOrders = append(
+				failedOrders, jsonmap.JsonMap{
+					"orderId":            orderId,
+					cancelOrdersErrorKey: err.Error(),
+				},
+			)
+			continue
+		}
+		cancelledOrders = append(cancelledOrders, cancelOrder.AsJsonMap())
+	}
+
+	if len(failedOrders) > 0 {
+		return jsonmap.JsonMap{
+			cancelOrdersStatusKey:          "error",
+			cancelOrdersErrorKey:           "some orders could not be cancelled",
+			cancelOrdersCancelledOrdersKey: cancelledOrders,
+			cancelOrdersFailedOrdersKey:    failedOrders,
+		}
+	}
+	return jsonmap.JsonMap{
+		cancelOrdersStatusKey:          "success",
+		cancelOrdersCancelledOrdersKey: cancelledOrders,
+	}
+}
+
+func cancelOrder(eTradeClient client.ETradeClient, accountIdKey string, orderId int64) (
+	etradelib.ETradeCancelOrder, error,
+) {
+	response, err := eTradeClient.CancelOrder(accountIdKey, orderId)
+	if err != nil {
+		return nil, err
+	}
+	return etradelib.CreateETradeCancelOrderFromResponse(response)
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestCancelOrders(t *testing.T) {
+	testAccountList := []byte(`
+{
+  "AccountListResponse": {
+    "Accounts": {
+      "Account": [
+        {
+          "accountId": "test id",
+          "accountIdKey": "test key"
+        }
+      ]
+    }
+  }
+}`)
+	testCancelResponse1 := []byte(`
+{
+  "CancelOrderResponse": {
+    "orderId": 1234
+  }
+}`)
+	testCancelResponse2 := []byte(`
+{
+  "CancelOrderResponse": {
+    "orderId": 5678
+  }
+}`)
+
+	type testFn func(mockClient *client.ETradeClientMock) (interface{}, error)
+	tests := []struct {
+		name        string
+		testFn      testFn
+		expectErr   bool
+		expectValue interface{}
+	}{
+		{
+			name: "Cancels Orders",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("CancelOrder", "test key", int64(1234)).Return(testCancelResponse1, nil)
+				mockClient.On("CancelOrder", "test key", int64(5678)).Return(testCancelResponse2, nil)
+				return CancelOrders(mockClient, "test id", []int64{1234, 5678})
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status": "success",
+				"cancelledOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{"orderId": json.Number("1234")},
+					jsonmap.JsonMap{"orderId": json.Number("5678")},
+				},
+			},
+		},
+		{
+			name: "Continues After Failed Cancel",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("CancelOrder", "test key", int64(1234)).Return([]byte{}, errors.New("test error"))
+				mockClient.On("CancelOrder", "test key", int64(5678)).Return(testCancelResponse2, nil)
+				return CancelOrders(mockClient, "test id", []int64{1234, 5678})
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status": "error",
+				"error":  "some orders could not be cancelled",
+				"cancelledOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{"orderId": json.Number("5678")},
+				},
+				"failedOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{"orderId": int64(1234), "error": "test error"},
+				},
+			},
+		},
+		{
+			name: "Fails On Bad Account Id",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				return CancelOrders(mockClient, "bad id", []int64{1234})
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Cancels Order",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("CancelOrder", "test key", int64(1234)).Return(testCancelResponse1, nil)
+				return CancelOrder(mockClient, "test id", 1234)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status": "success",
+				"cancelledOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{"orderId": json.Number("1234")},
+				},
+			},
+		},
+		{
+			name: "Cancel Order Fails On CancelOrder Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("CancelOrder", "test key", int64(1234)).Return([]byte{}, errors.New("test error"))
+				return CancelOrder(mockClient, "test id", 1234)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Cancels Open Orders With Pagination",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testOrdersResponse1 := []byte(`
+{
+  "OrdersResponse": {
+    "marker": "test marker",
+    "Order": [
+      {
+        "orderId": 1234
+      }
+    ]
+  }
+}`)
+				testOrdersResponse2 := []byte(`
+{
+  "OrdersResponse": {
+    "Order": [
+      {
+        "orderId": 5678
+      }
+    ]
+  }
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On(
+					"ListOrders", "test key", "", 100, constants.OrderStatusOpen, (*time.Time)(nil), (*time.Time)(nil),
+					[]string{"TestSymbol"}, constants.OrderSecurityTypeNil, constants.OrderTransactionTypeNil,
+					constants.MarketSessionNil,
+				).Return(testOrdersResponse1, nil)
+				mockClient.On(
+					"ListOrders", "test key", "test marker", 100, constants.OrderStatusOpen, (*time.Time)(nil),
+					(*time.Time)(nil), []string{"TestSymbol"}, constants.OrderSecurityTypeNil,
+					constants.OrderTransactionTypeNil, constants.MarketSessionNil,
+				).Return(testOrdersResponse2, nil)
+				mockClient.On("CancelOrder", "test key", int64(1234)).Return(testCancelResponse1, nil)
+				mockClient.On("CancelOrder", "test key", int64(5678)).Return(testCancelResponse2, nil)
+				return CancelOpenOrders(mockClient, "test id", []string{"TestSymbol"})
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status": "success",
+				"cancelledOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{"orderId": json.Number("1234")},
+					jsonmap.JsonMap{"orderId": json.Number("5678")},
+				},
+			},
+		},
+		{
+			name: "Cancel Open Orders Fails On ListOrders Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On(
+					"ListOrders", "test key", "", 100, constants.OrderStatusOpen, (*time.Time)(nil), (*time.Time)(nil),
+					[]string{"TestSymbol"}, constants.OrderSecurityTypeNil, constants.OrderTransactionTypeNil,
+					constants.MarketSessionNil,
+				).Return([]byte{}, errors.New("test error"))
+				return CancelOpenOrders(mockClient, "test id", []string{"TestSymbol"})
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Cancel Open Orders Fails Without Symbols",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				return CancelOpenOrders(mockClient, "test id", nil)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockClient := client.ETradeClientMock{}
+				// The iterators make their requests with the iteration's context.
+				mockClient.On("WithContext", mock.Anything).Return(&mockClient).Maybe()
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+// OrderChanges holds the fields to override when changing an order. Nil
+// pointers and nil enum values leave the existing order's value unchanged.
+type OrderChanges struct {
+	LimitPrice *decimal.Decimal
+	StopPrice  *decimal.Decimal
+	Quantity   *int
+	OrderTerm  constants.OrderTerm
+}
+
+// ErrOrderNotFound is wrapped by the error returned when an order ID isn't one
+// of the account's open orders.
+var ErrOrderNotFound = errors.New("order not found")
+
+// ChangeOrder loads an existing open order, applies the given changes to it,
+// and previews the changed order. Unless previewOnly is set, the previewed
+// change is then placed.
+func ChangeOrder(
+	eTradeClient client.ETradeClient, accountId string, orderId int64, changes *OrderChanges, previewOnly bool,
+) (jsonmap.JsonMap, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	orderList, err := listOrders(
+		eTradeClient, account.GetIdKey(), constants.OrderStatusOpen, nil, nil, nil,
+		constants.OrderSecurityTypeNil, constants.OrderTransactionTypeNil, constants.MarketSessionNil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	existingOrder := orderList.GetOrderById(orderId)
+	if existingOrder == nil {
+		return nil, fmt.Errorf("%w: no open order with ID %d", ErrOrderNotFound, orderId)
+	}
+
+	order, err := newOrderRequestFromOrder(existingOrder)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyOrderChanges(order, changes); err != nil {
+		return nil, err
+	}
+	order.ClientOrderId, err = client.NewClientOrderId()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := eTradeClient.PreviewChangedOrder(account.GetIdKey(), orderId, order)
+	if err != nil {
+		return nil, err
+	}
+	orderPreview, err := etradelib.CreateETradeOrderPreviewFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	if previewOnly {
+		return orderPreview.AsJsonMap(), nil
+	}
+	if len(orderPreview.GetPreviewIds()) < 1 {
+		return nil, errors.New("order change preview did not return a preview ID")
+	}
+
+	response, err = eTradeClient.PlaceChangedOrder(
+		account.GetIdKey(), orderId, orderPreview.GetPreviewIds()[0], order,
+	)
+	if err != nil {
+		return nil, err
+	}
+	orderPlaced, err := etradelib.CreateETradeOrderPlacedFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return orderPlaced.AsJsonMap(), nil
+}
+
+const (
+	// The order JSON (as returned in an order list) looks like this:
+	// {
+	//   "orderId": 1234,
+	//   "orderType": "EQ",
+	//   "orderDetail": [
+	//     {
+	//       "priceType": "LIMIT",
+	//       "orderTerm": "GOOD_FOR_DAY",
+	//       "marketSession": "REGULAR",
+	//       "limitPrice": 123.45,
+	//       "stopPrice": 0,
+	//       "allOrNone": false,
+	//       "instrument": [
+	//         {
+	//           "product": {
+	//             "symbol": "GOOG",
+	//             "securityType": "EQ"
+	//           },
+	//           "orderAction": "BUY",
+	//           "orderedQuantity": 10
+	//         }
+	//       ]
+	//     }
+	//   ]
+	// }
+
+	// existingOrderTypePath is the path to the order type
+	existingOrderTypePath = ".orderType"
+
+	// existingOrderDetailPath is the path to the details of the order
+	existingOrderDetailPath = ".orderDetail[0]"
+
+	// existingOrderInstrumentsPath is the path to the instrument slice within the
+	// order details
+	existingOrderInstrumentsPath = ".instrument"
+)
+
+// newOrderRequestFromOrder creates an order request that describes an
+// existing order so that it can be modified and resubmitted.
+func newOrderRequestFromOrder(order etradelib.ETradeOrder) (*client.OrderRequest, error) {
+	orderMap := order.AsJsonMap()
+	orderTypeString, err := orderMap.GetStringAtPath(existingOrderTypePath)
+	if err != nil {
+		return nil, err
+	}
+	orderType, err := constants.OrderTypeFromString(orderTypeString)
+	if err != nil {
+		return nil, fmt.Errorf("order %d has unsupported order type: %w", order.GetId(), err)
+	}
+	detailMap, err := orderMap.GetMapAtPath(existingOrderDetailPath)
+	if err != nil {
+		return nil, err
+	}
+
+	priceTypeString, err := detailMap.GetString("priceType")
+	if err != nil {
+		return nil, err
+	}
+	priceType, err := constants.OrderPriceTypeFromString(priceTypeString)
+	if err != nil {
+		return nil, fmt.Errorf("order %d has unsupported price type: %w", order.GetId(), err)
+	}
+	orderTermString, err := detailMap.GetString("orderTerm")
+	if err != nil {
+		return nil, err
+	}
+	orderTerm, err := constants.OrderTermFromString(orderTermString)
+	if err != nil {
+		return nil, fmt.Errorf("order %d has unsupported order term: %w", order.GetId(), err)
+	}
+	marketSessionString, err := detailMap.GetString("marketSession")
+	if err != nil {
+		return nil, err
+	}
+	marketSession, err := constants.MarketSessionFromString(marketSessionString)
+	if err != nil {
+		return nil, fmt.Errorf("order %d has unsupported market session: %w", order.GetId(), err)
+	}
+	limitPrice, err := getDecimalWithDefault(detailMap, "limitPrice", decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+	stopPrice, err := getDecimalWithDefault(detailMap, "stopPrice", decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+	allOrNone, err := detailMap.GetBoolWithDefault("allOrNone", false)
+	if err != nil {
+		return nil, err
+	}
+
+	instrumentMaps, err := detailMap.GetSliceOfMapsAtPath(existingOrderInstrumentsPath)
+	if err != nil {
+		return nil, err
+	}
+	instruments := make([]client.OrderInstrument, 0, len(instrumentMaps))
+	for _, instrumentMap := range instrumentMaps {
+		productMap, err := instrumentMap.GetMap("product")
+		if err != nil {
+			return nil, err
+		}
+		instrument, err := newOrderInstrumentFromProduct(productMap)
+		if err != nil {
+			return nil, fmt.Errorf("order %d has an unsupported instrument: %w", order.GetId(), err)
+		}
+		orderActionString, err := instrumentMap.GetString("orderAction")
+		if err != nil {
+			return nil, err
+		}
+		orderAction, err := constants.OrderActionFromString(orderActionString)
+		if err != nil {
+			return nil, fmt.Errorf("order %d has unsupported order action: %w", order.GetId(), err)
+		}
+		quantity, err := getDecimal(instrumentMap, "orderedQuantity")
+		if err != nil {
+			return nil, err
+		}
+		intQuantity, err := quantity.IntPart()
+		if err != nil || !decimal.NewFromInt(intQuantity).Equal(quantity) || int64(int(intQuantity)) != intQuantity {
+			return nil, fmt.Errorf("order %d has unsupported quantity %s", order.GetId(), quantity)
+		}
+		instrument.OrderAction = orderAction
+		instrument.Quantity = int(intQuantity)
+		instruments = append(instruments, instrument)
+	}
+
+	return &client.OrderRequest{
+		OrderType:     orderType,
+		PriceType:     priceType,
+		OrderTerm:     orderTerm,
+		MarketSession: marketSession,
+		LimitPrice:    limitPrice,
+		StopPrice:     stopPrice,
+		AllOrNone:     allOrNone,
+		Instruments:   instruments,
+	}, nil
+}
+
+// newOrderInstrumentFromProduct creates an instrument (without an action or
+// quantity) from the product map of an existing order's instrument.
+func newOrderInstrumentFromProduct(productMap jsonmap.JsonMap) (client.OrderInstrument, error) {
+	symbol, err := productMap.GetString("symbol")
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	securityTypeString, err := productMap.GetString("securityType")
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	securityType, err := constants.OrderSecurityTypeFromString(securityTypeString)
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	instrument := client.OrderInstrument{
+		SecurityType: securityType,
+		Symbol:       symbol,
+	}
+	if securityType != constants.OrderSecurityTypeOption {
+		return instrument, nil
+	}
+
+	callPutString, err := productMap.GetString("callPut")
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	if instrument.CallPut, err = constants.OptionCallPutFromString(callPutString); err != nil {
+		return client.OrderInstrument{}, err
+	}
+	expiryYear, err := productMap.GetInt("expiryYear")
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	expiryMonth, err := productMap.GetInt("expiryMonth")
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	expiryDay, err := productMap.GetInt("expiryDay")
+	if err != nil {
+		return client.OrderInstrument{}, err
+	}
+	if instrument.StrikePrice, err = getDecimal(productMap, "strikePrice"); err != nil {
+		return client.OrderInstrument{}, err
+	}
+	instrument.ExpiryYear = int(expiryYear)
+	instrument.ExpiryMonth = int(expiryMonth)
+	instrument.ExpiryDay = int(expiryDay)
+	return instrument, nil
+}
+
+// getDecimal returns the exact value of the number at a key in a map.
+func getDecimal(m jsonmap.JsonMap, key string) (decimal.Decimal, error) {
+	value, err := m.GetValue(key)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%s is not a number", key)
+	}
+	return decimal.NewFromJsonNumber(number)
+}
+
+// getDecimalWithDefault returns the exact value of the number at a key in a
+// map, or defaultValue if the map doesn't have the key.
+func getDecimalWithDefault(m jsonmap.JsonMap, key string, defaultValue decimal.Decimal) (decimal.Decimal, error) {
+	if _, ok := m[key]; !ok {
+		return defaultValue, nil
+	}
+	return getDecimal(m, key)
+}
+
+func applyOrderChanges(order *client.OrderRequest, changes *OrderChanges) error {
+	if changes == nil {
+		return nil
+	}
+	if changes.LimitPrice != nil {
+		order.LimitPrice = *changes.LimitPrice
+	}
+	if changes.StopPrice != nil {
+		order.StopPrice = *changes.StopPrice
+	}
+	if changes.Quantity != nil {
+		if len(order.Instruments) != 1 {
+			return fmt.Errorf(
+				"%w: quantity can only be changed for orders with a single instrument", client.ErrInvalidOrder,
+			)
+		}
+		order.Instruments[0].Quantity = *changes.Quantity
+	}
+	if changes.OrderTerm != constants.OrderTermNil {
+		order.OrderTerm = changes.OrderTerm
+	}
+	return nil
+}
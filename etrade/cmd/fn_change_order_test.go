@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChangeOrder(t *testing.T) {
+	testAccountList := []byte(`
+{
+  "AccountListResponse": {
+    "Accounts": {
+      "Account": [
+        {
+          "accountId": "test id",
+          "accountIdKey": "test key"
+        }
+      ]
+    }
+  }
+}`)
+	testOrdersResponse := []byte(`
+{
+  "OrdersResponse": {
+    "Order": [
+      {
+        "orderId": 1234,
+        "orderType": "EQ",
+        "OrderDetail": [
+          {
+            "priceType": "LIMIT",
+            "orderTerm": "GOOD_FOR_DAY",
+            "marketSession": "REGULAR",
+            "limitPrice": 100.5,
+            "allOrNone": false,
+            "Instrument": [
+              {
+                "Product": {
+                  "symbol": "GOOG",
+                  "securityType": "EQ"
+                },
+                "orderAction": "BUY",
+                "orderedQuantity": 10
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}`)
+	testPreviewResponse := []byte(`
+{
+  "PreviewOrderResponse": {
+    "PreviewIds": [
+      {
+        "previewId": 5678
+      }
+    ]
+  }
+}`)
+	testPlacedResponse := []byte(`
+{
+  "PlaceOrderResponse": {
+    "OrderIds": [
+      {
+        "orderId": 1234
+      }
+    ]
+  }
+}`)
+	expectListOrders := func(mockClient *client.ETradeClientMock) {
+		mockClient.On("ListAccounts").Return(testAccountList, nil)
+		mockClient.On(
+			"ListOrders", "test key", "", 100, constants.OrderStatusOpen, (*time.Time)(nil), (*time.Time)(nil),
+			[]string(nil), constants.OrderSecurityTypeNil, constants.OrderTransactionTypeNil,
+			constants.MarketSessionNil,
+		).Return(testOrdersResponse, nil)
+	}
+	expectListOrdersWithQuantity := func(mockClient *client.ETradeClientMock, quantity string) {
+		mockClient.On("ListAccounts").Return(testAccountList, nil)
+		mockClient.On(
+			"ListOrders", "test key", "", 100, constants.OrderStatusOpen, (*time.Time)(nil), (*time.Time)(nil),
+			[]string(nil), constants.OrderSecurityTypeNil, constants.OrderTransactionTypeNil,
+			constants.MarketSessionNil,
+		).Return(
+			[]byte(strings.Replace(string(testOrdersResponse), `"orderedQuantity": 10`, `"orderedQuantity": `+quantity, 1)),
+			nil,
+		)
+	}
+	// matchChangedOrder matches an order request that has the existing
+	// order's values with a new limit price and quantity.
+	matchChangedOrder := mock.MatchedBy(
+		func(order *client.OrderRequest) bool {
+			return order.ClientOrderId != "" &&
+				order.OrderType == constants.OrderTypeEquity &&
+				order.PriceType == constants.OrderPriceTypeLimit &&
+				order.OrderTerm == constants.OrderTermGoodForDay &&
+				order.MarketSession == constants.MarketSessionRegular &&
+				order.LimitPrice.Equal(decimal.MustNewFromString("101.25")) &&
+				len(order.Instruments) == 1 &&
+				order.Instruments[0].Symbol == "GOOG" &&
+				order.Instruments[0].OrderAction == constants.OrderActionBuy &&
+				order.Instruments[0].Quantity == 20
+		},
+	)
+	newLimitPrice := decimal.MustNewFromString("101.25")
+	newQuantity := 20
+	testChanges := &OrderChanges{
+		LimitPrice: &newLimitPrice,
+		Quantity:   &newQuantity,
+	}
+
+	type testFn func(mockClient *client.ETradeClientMock) (interface{}, error)
+	tests := []struct {
+		name        string
+		testFn      testFn
+		expectErr   bool
+		expectValue interface{}
+	}{
+		{
+			name: "Changes Order",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrders(mockClient)
+				mockClient.On("PreviewChangedOrder", "test key", int64(1234), matchChangedOrder).Return(
+					testPreviewResponse, nil,
+				)
+				mockClient.On(
+					"PlaceChangedOrder", "test key", int64(1234), int64(5678), matchChangedOrder,
+				).Return(testPlacedResponse, nil)
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, false)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"orderIds": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"orderId": json.Number("1234"),
+					},
+				},
+			},
+		},
+		{
+			name: "Previews Order Change Only",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrders(mockClient)
+				mockClient.On("PreviewChangedOrder", "test key", int64(1234), matchChangedOrder).Return(
+					testPreviewResponse, nil,
+				)
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, true)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"previewIds": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"previewId": json.Number("5678"),
+					},
+				},
+			},
+		},
+		{
+			name: "Fails On Unknown Order",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrders(mockClient)
+				return ChangeOrder(mockClient, "test id", 9999, testChanges, false)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On Fractional Quantity",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrdersWithQuantity(mockClient, "10.5")
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, false)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On Out Of Range Quantity",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrdersWithQuantity(mockClient, "1e30")
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, false)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Changes Order With Whole Quantity In Decimal Form",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrdersWithQuantity(mockClient, "10.0")
+				mockClient.On("PreviewChangedOrder", "test key", int64(1234), matchChangedOrder).Return(
+					testPreviewResponse, nil,
+				)
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, true)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"previewIds": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"previewId": json.Number("5678"),
+					},
+				},
+			},
+		},
+		{
+			name: "Fails On PreviewChangedOrder Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrders(mockClient)
+				mockClient.On("PreviewChangedOrder", "test key", int64(1234), matchChangedOrder).Return(
+					[]byte{}, errors.New("test error"),
+				)
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, false)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On PlaceChangedOrder Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				expectListOrders(mockClient)
+				mockClient.On("PreviewChangedOrder", "test key", int64(1234), matchChangedOrder).Return(
+					testPreviewResponse, nil,
+				)
+				mockClient.On(
+					"PlaceChangedOrder", "test key", int64(1234), int64(5678), matchChangedOrder,
+				).Return([]byte{}, errors.New("test error"))
+				return ChangeOrder(mockClient, "test id", 1234, testChanges, false)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockClient := client.ETradeClientMock{}
+				// The iterators make their requests with the iteration's context.
+				mockClient.On("WithContext", mock.Anything).Return(&mockClient).Maybe()
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
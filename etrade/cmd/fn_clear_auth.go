@@ -2,21 +2,39 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 )
 
-func ClearAuth(customerId string, cfgFolder ConfigurationFolder, cfgStore *CustomerConfigurationStore) (
+// ClearAuth revokes the customer's access token with ETrade and then removes
+// the customer's cached credentials. If eTradeClient is nil, the token is not
+// revoked and only the cached credentials are removed.
+func ClearAuth(customerId string, eTradeClient client.ETradeClient, credentialStore CredentialStore) (
 	jsonmap.JsonMap, error,
 ) {
-	customerConfig, err := cfgStore.GetCustomerConfigurationById(customerId)
-	if err != nil {
-		return nil, fmt.Errorf("customer id '%s' not found in config file", customerId)
+	var err error
+	tokenRevoked := false
+	if eTradeClient != nil {
+		if _, _, accessToken, _ := eTradeClient.GetKeys(); accessToken != "" {
+			_, err = eTradeClient.RevokeAccessToken()
+			// An auth failure means that the token is already invalid (e.g.
+			// because it expired), so there's nothing left to revoke.
+			if err != nil && !client.IsAuthFailed(err) {
+				return nil, fmt.Errorf(
+					"unable to revoke access token for %s (%w); cached credentials were not removed", customerId,
+					err,
+				)
+			}
+			tokenRevoked = err == nil
+		}
 	}
-	err = cfgFolder.RemoveCachedCredentialsFile(customerConfig.CustomerConsumerKey)
+
+	err = credentialStore.RemoveCredentials()
 	if err != nil {
 		return nil, fmt.Errorf("unable to remove auth cache for %s (%w)", customerId, err)
 	}
 	return jsonmap.JsonMap{
-		"status": "success",
+		"status":       "success",
+		"tokenRevoked": tokenRevoked,
 	}, nil
 }
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClearAuth(t *testing.T) {
+	type testFn func(mockClient *client.ETradeClientMock, credentialStore CredentialStore) (jsonmap.JsonMap, error)
+
+	tests := []struct {
+		name              string
+		testFn            testFn
+		expectErr         bool
+		expectValue       jsonmap.JsonMap
+		expectCacheExists bool
+	}{
+		{
+			name: "Revokes Token And Removes Cache",
+			testFn: func(mockClient *client.ETradeClientMock, credentialStore CredentialStore) (
+				jsonmap.JsonMap, error,
+			) {
+				mockClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "TestToken", "TestSecret")
+				mockClient.On("RevokeAccessToken").Return([]byte(`{"status":"success"}`), nil)
+				return ClearAuth("TestCustomerId", mockClient, credentialStore)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status":       "success",
+				"tokenRevoked": true,
+			},
+			expectCacheExists: false,
+		},
+		{
+			name: "Removes Cache Without Revoking When Local Only",
+			testFn: func(mockClient *client.ETradeClientMock, credentialStore CredentialStore) (
+				jsonmap.JsonMap, error,
+			) {
+				return ClearAuth("TestCustomerId", nil, credentialStore)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status":       "success",
+				"tokenRevoked": false,
+			},
+			expectCacheExists: false,
+		},
+		{
+			name: "Removes Cache Without Revoking When There Is No Token",
+			testFn: func(mockClient *client.ETradeClientMock, credentialStore CredentialStore) (
+				jsonmap.JsonMap, error,
+			) {
+				mockClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "", "")
+				return ClearAuth("TestCustomerId", mockClient, credentialStore)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status":       "success",
+				"tokenRevoked": false,
+			},
+			expectCacheExists: false,
+		},
+		{
+			name: "Removes Cache When Token Is Already Invalid",
+			testFn: func(mockClient *client.ETradeClientMock, credentialStore CredentialStore) (
+				jsonmap.JsonMap, error,
+			) {
+				mockClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "TestToken", "TestSecret")
+				mockClient.On("RevokeAccessToken").Return([]byte(nil), client.ErrETradeAuthFailed)
+				return ClearAuth("TestCustomerId", mockClient, credentialStore)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status":       "success",
+				"tokenRevoked": false,
+			},
+			expectCacheExists: false,
+		},
+		{
+			name: "Keeps Cache When Revoke Fails",
+			testFn: func(mockClient *client.ETradeClientMock, credentialStore CredentialStore) (
+				jsonmap.JsonMap, error,
+			) {
+				mockClient.On("GetKeys").Return("TestConsumerKey", "TestConsumerSecret", "TestToken", "TestSecret")
+				mockClient.On("RevokeAccessToken").Return([]byte(nil), errors.New("test error"))
+				return ClearAuth("TestCustomerId", mockClient, credentialStore)
+			},
+			expectErr:         true,
+			expectValue:       nil,
+			expectCacheExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				credentialStore := NewPlainCredentialStore(filepath.Join(t.TempDir(), "credentials"), nil)
+				err := credentialStore.SaveCredentials(NewCachedCredentials("TestToken", "TestSecret", nil, time.Now()))
+				assert.Nil(t, err)
+				mockClient := client.ETradeClientMock{}
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient, credentialStore)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				_, err = credentialStore.LoadCredentials()
+				assert.Equal(t, tt.expectCacheExists, err == nil)
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 )
 
+// ErrAccountNotFound is wrapped by the error returned when an account ID isn't
+// one of the customer's accounts.
+var ErrAccountNotFound = errors.New("account not found")
+
 func GetAccountById(client client.ETradeClient, accountId string) (etradelib.ETradeAccount, error) {
 	response, err := client.ListAccounts()
 	if err != nil {
@@ -17,7 +22,7 @@ func GetAccountById(client client.ETradeClient, accountId string) (etradelib.ETr
 	}
 	account := accountList.GetAccountById(accountId)
 	if account == nil {
-		return nil, fmt.Errorf("account with id %s not found", accountId)
+		return nil, fmt.Errorf("%w: no account with id %s", ErrAccountNotFound, accountId)
 	}
 	return account, nil
 }
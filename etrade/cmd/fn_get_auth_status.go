@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
+)
+
+var authStatusMessages = map[string]string{
+	tokenStatusNone:    "not logged in",
+	tokenStatusValid:   "access token is valid",
+	tokenStatusIdle:    "access token is idle and will be renewed when next used",
+	tokenStatusExpired: "access token has expired; log in again",
+}
+
+// GetAuthStatus reports the status of the customer's cached access token
+// without contacting ETrade.
+func GetAuthStatus(credentialStore CredentialStore, now time.Time) (jsonmap.JsonMap, error) {
+	cachedCredentials, err := credentialStore.LoadCredentials()
+	if err != nil {
+		// Missing or unreadable credentials mean there is no usable token.
+		cachedCredentials = &CachedCredentials{}
+	}
+
+	tokenStatus := cachedCredentials.GetTokenStatus(now)
+	response := jsonmap.JsonMap{
+		"status":  tokenStatus,
+		"message": authStatusMessages[tokenStatus],
+	}
+	if tokenStatus != tokenStatusNone {
+		response["issuedAt"] = cachedCredentials.GetIssuedAt().Format(time.RFC3339)
+		response["lastUsed"] = cachedCredentials.GetLastUsed().Format(time.RFC3339)
+		response["expiresAt"] = cachedCredentials.GetExpiresAt().Format(time.RFC3339)
+	}
+	return response, nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetAuthStatus(t *testing.T) {
+	// Wednesday, June 14, 2023 at 9:00am US Eastern
+	issuedAt := time.Date(2023, 6, 14, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		cached      *CachedCredentials
+		now         time.Time
+		expectErr   bool
+		expectValue jsonmap.JsonMap
+	}{
+		{
+			name:      "Reports Idle Token",
+			cached:    &CachedCredentials{AccessToken: "TestToken", IssuedAt: issuedAt, LastUsed: issuedAt},
+			now:       issuedAt.Add(3 * time.Hour),
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status":    "idle",
+				"message":   "access token is idle and will be renewed when next used",
+				"issuedAt":  issuedAt.Format(time.RFC3339),
+				"lastUsed":  issuedAt.Format(time.RFC3339),
+				"expiresAt": time.Date(2023, 6, 15, 4, 0, 0, 0, time.UTC).In(client.EasternTime).Format(time.RFC3339),
+			},
+		},
+		{
+			name:      "Reports Missing Token",
+			cached:    nil,
+			now:       issuedAt,
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status":  "none",
+				"message": "not logged in",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				credentialStore := NewPlainCredentialStore(filepath.Join(t.TempDir(), "credentials"), nil)
+				if tt.cached != nil {
+					err := credentialStore.SaveCredentials(tt.cached)
+					assert.Nil(t, err)
+				}
+				// Call the Method Under Test
+				actualValue, err := GetAuthStatus(credentialStore, tt.now)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
@@ -13,18 +14,51 @@ func ListOrders(
 	toDate *time.Time, symbols []string, securityType constants.OrderSecurityType,
 	transactionType constants.OrderTransactionType, marketSession constants.MarketSession,
 ) (jsonmap.JsonMap, error) {
-	// This determines how many order items will be retrieved in each request.
-	// This should normally be set to the max for efficiency, but can be
-	// lowered to test the pagination logic.
-	const countPerRequest = constants.OrdersMaxCount
+	stream, err := StreamOrders(
+		eTradeClient, accountId, status, fromDate, toDate, symbols, securityType, transactionType, marketSession, 0,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stream.Collect(context.Background())
+}
 
+// StreamOrders returns a stream of an account's orders that retrieves each
+// page of orders as it is needed. If maxItems is greater than zero, the stream
+// ends after that many orders.
+func StreamOrders(
+	eTradeClient client.ETradeClient, accountId string, status constants.OrderStatus, fromDate *time.Time,
+	toDate *time.Time, symbols []string, securityType constants.OrderSecurityType,
+	transactionType constants.OrderTransactionType, marketSession constants.MarketSession, maxItems int,
+) (*ListStream, error) {
 	account, err := GetAccountById(eTradeClient, accountId)
 	if err != nil {
 		return nil, err
 	}
+	iterator := etradelib.NewOrderIterator(
+		eTradeClient, account.GetIdKey(), status, fromDate, toDate, symbols, securityType, transactionType,
+		marketSession, maxItems,
+	)
+	return &ListStream{
+		ListPath: etradelib.OrderListOrdersPath,
+		Elements: etradelib.NewJsonMapIterator(iterator),
+	}, nil
+}
+
+// listOrders retrieves every page of orders that match the given filters.
+func listOrders(
+	eTradeClient client.ETradeClient, accountIdKey string, status constants.OrderStatus, fromDate *time.Time,
+	toDate *time.Time, symbols []string, securityType constants.OrderSecurityType,
+	transactionType constants.OrderTransactionType, marketSession constants.MarketSession,
+) (etradelib.ETradeOrderList, error) {
+	// This determines how many order items will be retrieved in each request.
+	// This should normally be set to the max for efficiency, but can be
+	// lowered to test the pagination logic.
+	const countPerRequest = constants.OrdersMaxCount
+
 	response, err := eTradeClient.ListOrders(
-		account.GetIdKey(), "", countPerRequest, status, fromDate, toDate, symbols, securityType,
-		transactionType, marketSession,
+		accountIdKey, "", countPerRequest, status, fromDate, toDate, symbols, securityType, transactionType,
+		marketSession,
 	)
 	if err != nil {
 		return nil, err
@@ -36,8 +70,8 @@ func ListOrders(
 
 	for orderList.NextPage() != "" {
 		response, err = eTradeClient.ListOrders(
-			account.GetIdKey(), orderList.NextPage(), countPerRequest, status, fromDate, toDate,
-			symbols, securityType, transactionType, marketSession,
+			accountIdKey, orderList.NextPage(), countPerRequest, status, fromDate, toDate, symbols, securityType,
+			transactionType, marketSession,
 		)
 		if err != nil {
 			return nil, err
@@ -47,5 +81,5 @@ func ListOrders(
 			return nil, err
 		}
 	}
-	return orderList.AsJsonMap(), nil
+	return orderList, nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 )
@@ -294,6 +295,8 @@ func TestListOrders(t *testing.T) {
 		t.Run(
 			tt.name, func(t *testing.T) {
 				mockClient := client.ETradeClientMock{}
+				// The iterators make their requests with the iteration's context.
+				mockClient.On("WithContext", mock.Anything).Return(&mockClient).Maybe()
 				// Call the Method Under Test
 				actualValue, err := tt.testFn(&mockClient)
 				if tt.expectErr {
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+// ListPositionLots lists the lots that make up a position. The result holds
+// the position ID and the position's lots, in the same layout as a position
+// in a portfolio fetched with lots.
+func ListPositionLots(eTradeClient client.ETradeClient, accountId string, positionId int64) (
+	jsonmap.JsonMap, error,
+) {
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := eTradeClient.ListPositionLotsDetails(account.GetIdKey(), positionId)
+	if err != nil {
+		return nil, err
+	}
+	position, err := etradelib.CreateETradePosition(jsonmap.JsonMap{"positionId": positionId})
+	if err != nil {
+		return nil, err
+	}
+	if err = position.AddLotsFromResponse(response); err != nil {
+		return nil, err
+	}
+	return position.AsJsonMap(), nil
+}
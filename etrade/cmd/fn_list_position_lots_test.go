@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListPositionLots(t *testing.T) {
+	testAccountList := []byte(`
+{
+  "AccountListResponse": {
+    "Accounts": {
+      "Account": [
+        {
+          "accountId": "test id",
+          "accountIdKey": "test key"
+        }
+      ]
+    }
+  }
+}`)
+
+	type testFn func(mockClient *client.ETradeClientMock) (interface{}, error)
+	tests := []struct {
+		name        string
+		testFn      testFn
+		expectErr   bool
+		expectValue interface{}
+	}{
+		{
+			name: "Lists Position Lots",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testLotsResponse := []byte(`
+{
+  "PositionLotsResponse": {
+    "PositionLot": [
+      {
+        "positionLotId": 5678,
+        "remainingQty": 10
+      }
+    ]
+  }
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(testLotsResponse, nil)
+				return ListPositionLots(mockClient, "test id", 1234)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"positionId": int64(1234),
+				"lots": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"positionLotId": json.Number("5678"),
+						"remainingQty":  json.Number("10"),
+					},
+				},
+			},
+		},
+		{
+			name: "Fails With Bad Account ID",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				return ListPositionLots(mockClient, "bad id", 1234)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails With ListPositionLotsDetails Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(
+					[]byte{}, errors.New("test error"),
+				)
+				return ListPositionLots(mockClient, "test id", 1234)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On Bad Response",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testLotsResponse := []byte(`
+{
+  "PositionLotsResponse": {
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(testLotsResponse, nil)
+				return ListPositionLots(mockClient, "test id", 1234)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockClient := client.ETradeClientMock{}
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
@@ -12,40 +13,29 @@ func ListTransactions(
 	eTradeClient client.ETradeClient, accountId string, startDate *time.Time, endDate *time.Time,
 	sortOrder constants.SortOrder,
 ) (jsonmap.JsonMap, error) {
-	// This determines how many transaction items will be retrieved in each
-	// request. This should normally be set to the max for efficiency, but can
-	// be lowered to test the pagination logic.
-	const countPerRequest = constants.TransactionsMaxCount
-
-	account, err := GetAccountById(eTradeClient, accountId)
+	stream, err := StreamTransactions(eTradeClient, accountId, startDate, endDate, sortOrder, 0)
 	if err != nil {
 		return nil, err
 	}
-	response, err := eTradeClient.ListTransactions(
-		account.GetIdKey(),
-		startDate, endDate, sortOrder, "", countPerRequest,
-	)
-	if err != nil {
-		return nil, err
-	}
-	transactionList, err := etradelib.CreateETradeTransactionListFromResponse(response)
+	return stream.Collect(context.Background())
+}
+
+// StreamTransactions returns a stream of an account's transactions that
+// retrieves each page of transactions as it is needed. If maxItems is greater
+// than zero, the stream ends after that many transactions.
+func StreamTransactions(
+	eTradeClient client.ETradeClient, accountId string, startDate *time.Time, endDate *time.Time,
+	sortOrder constants.SortOrder, maxItems int,
+) (*ListStream, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
 	if err != nil {
 		return nil, err
 	}
-
-	for transactionList.NextPage() != "" {
-		response, err = eTradeClient.ListTransactions(
-			account.GetIdKey(),
-			startDate, endDate, sortOrder, transactionList.NextPage(), countPerRequest,
-		)
-		if err != nil {
-			return nil, err
-		}
-		err = transactionList.AddPageFromResponse(response)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return transactionList.AsJsonMap(), nil
+	iterator := etradelib.NewTransactionIterator(
+		eTradeClient, account.GetIdKey(), startDate, endDate, sortOrder, maxItems,
+	)
+	return &ListStream{
+		ListPath: etradelib.TransactionListTransactionsPath,
+		Elements: etradelib.NewJsonMapIterator(iterator),
+	}, nil
 }
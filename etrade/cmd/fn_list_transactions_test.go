@@ -6,6 +6,7 @@ import (
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 )
@@ -270,6 +271,8 @@ func TestListTransactions(t *testing.T) {
 		t.Run(
 			tt.name, func(t *testing.T) {
 				mockClient := client.ETradeClientMock{}
+				// The iterators make their requests with the iteration's context.
+				mockClient.On("WithContext", mock.Anything).Return(&mockClient).Maybe()
 				// Call the Method Under Test
 				actualValue, err := tt.testFn(&mockClient)
 				if tt.expectErr {
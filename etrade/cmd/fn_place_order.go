@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+func PlaceOrder(
+	eTradeClient client.ETradeClient, accountId string, previewId int64, order *client.OrderRequest,
+) (jsonmap.JsonMap, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := eTradeClient.PlaceOrder(account.GetIdKey(), previewId, order)
+	if err != nil {
+		return nil, err
+	}
+	orderPlaced, err := etradelib.CreateETradeOrderPlacedFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return orderPlaced.AsJsonMap(), nil
+}
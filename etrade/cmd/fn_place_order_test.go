@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPlaceOrder(t *testing.T) {
+	testAccountList := []byte(`
+{
+  "AccountListResponse": {
+    "Accounts": {
+      "Account": [
+        {
+          "accountId": "TestId",
+          "accountIdKey": "TestKey"
+        }
+      ]
+    }
+  }
+}`)
+	testOrder := createTestOrderRequest()
+
+	type testFn func(mockClient *client.ETradeClientMock) (interface{}, error)
+	tests := []struct {
+		name        string
+		testFn      testFn
+		expectErr   bool
+		expectValue interface{}
+	}{
+		{
+			name: "Places Order",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testPlaced := []byte(`
+{
+  "PlaceOrderResponse": {
+    "OrderIds": [
+      {
+        "orderId": 5678
+      }
+    ]
+  }
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PlaceOrder", "TestKey", int64(1234), testOrder).Return(testPlaced, nil)
+				return PlaceOrder(mockClient, "TestId", 1234, testOrder)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"orderIds": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"orderId": json.Number("5678"),
+					},
+				},
+			},
+		},
+		{
+			name: "Fails On Bad Account Id",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				return PlaceOrder(mockClient, "BadId", 1234, testOrder)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On PlaceOrder Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PlaceOrder", "TestKey", int64(1234), testOrder).Return(
+					[]byte{}, errors.New("test error"),
+				)
+				return PlaceOrder(mockClient, "TestId", 1234, testOrder)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On Bad Response",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testPlaced := []byte(`
+{
+  "PlaceOrderResponse": {
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PlaceOrder", "TestKey", int64(1234), testOrder).Return(testPlaced, nil)
+				return PlaceOrder(mockClient, "TestId", 1234, testOrder)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockClient := client.ETradeClientMock{}
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
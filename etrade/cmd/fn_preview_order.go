@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+func PreviewOrder(
+	eTradeClient client.ETradeClient, accountId string, order *client.OrderRequest,
+) (jsonmap.JsonMap, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := eTradeClient.PreviewOrder(account.GetIdKey(), order)
+	if err != nil {
+		return nil, err
+	}
+	orderPreview, err := etradelib.CreateETradeOrderPreviewFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return orderPreview.AsJsonMap(), nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func createTestOrderRequest() *client.OrderRequest {
+	return &client.OrderRequest{
+		ClientOrderId: "TestClientOrderId",
+		OrderType:     constants.OrderTypeEquity,
+		PriceType:     constants.OrderPriceTypeMarket,
+		OrderTerm:     constants.OrderTermGoodForDay,
+		MarketSession: constants.MarketSessionRegular,
+		Instruments: []client.OrderInstrument{
+			{
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Symbol:       "GOOG",
+				OrderAction:  constants.OrderActionBuy,
+				Quantity:     1,
+			},
+		},
+	}
+}
+
+func TestPreviewOrder(t *testing.T) {
+	testAccountList := []byte(`
+{
+  "AccountListResponse": {
+    "Accounts": {
+      "Account": [
+        {
+          "accountId": "TestId",
+          "accountIdKey": "TestKey"
+        }
+      ]
+    }
+  }
+}`)
+	testOrder := createTestOrderRequest()
+
+	type testFn func(mockClient *client.ETradeClientMock) (interface{}, error)
+	tests := []struct {
+		name        string
+		testFn      testFn
+		expectErr   bool
+		expectValue interface{}
+	}{
+		{
+			name: "Previews Order",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testPreview := []byte(`
+{
+  "PreviewOrderResponse": {
+    "PreviewIds": [
+      {
+        "previewId": 1234
+      }
+    ]
+  }
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PreviewOrder", "TestKey", testOrder).Return(testPreview, nil)
+				return PreviewOrder(mockClient, "TestId", testOrder)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"previewIds": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"previewId": json.Number("1234"),
+					},
+				},
+			},
+		},
+		{
+			name: "Fails On Bad Account Id",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				return PreviewOrder(mockClient, "BadId", testOrder)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On PreviewOrder Error",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PreviewOrder", "TestKey", testOrder).Return([]byte{}, errors.New("test error"))
+				return PreviewOrder(mockClient, "TestId", testOrder)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails On Bad Response",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				testPreview := []byte(`
+{
+  "PreviewOrderResponse": {
+}`)
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PreviewOrder", "TestKey", testOrder).Return(testPreview, nil)
+				return PreviewOrder(mockClient, "TestId", testOrder)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockClient := client.ETradeClientMock{}
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				mockClient.AssertExpectations(t)
+			},
+		)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"io"
+)
+
+const (
+	// The PreviewOrderSubmissions() map looks like this:
+	// {
+	//   "previews": [
+	//     {
+	//       <order preview info>
+	//     }
+	//   ]
+	// }
+	//
+	// The PlaceOrderSubmissions() map looks like this:
+	// {
+	//   "status": "error",
+	//   "error": "some orders could not be placed",
+	//   "placedOrders": [
+	//     {
+	//       <placed order info>
+	//     }
+	//   ],
+	//   "failedOrders": [
+	//     {
+	//       "clientOrderId": "0123456789abcdef",
+	//       "error": "<error message>"
+	//     }
+	//   ]
+	// }
+
+	// submitOrdersPreviewsKey is the key for a slice of order previews
+	submitOrdersPreviewsKey = "previews"
+
+	// submitOrdersStatusKey is the key for the status
+	submitOrdersStatusKey = "status"
+
+	// submitOrdersErrorKey is the key for the error message
+	submitOrdersErrorKey = "error"
+
+	// submitOrdersPlacedOrdersKey is the key for a slice of placed orders
+	submitOrdersPlacedOrdersKey = "placedOrders"
+
+	// submitOrdersFailedOrdersKey is the key for a slice of orders that
+	// could not be placed
+	submitOrdersFailedOrdersKey = "failedOrders"
+)
+
+// PreviewOrderSubmissions previews every submission and records each
+// submission's preview ID. It stops at the first failure so that no order
+// is placed unless every order previews successfully.
+func PreviewOrderSubmissions(
+	eTradeClient client.ETradeClient, submissions []*orderSubmission,
+) (jsonmap.JsonMap, error) {
+	previews := jsonmap.JsonSlice{}
+	for _, submission := range submissions {
+		account, err := GetAccountById(eTradeClient, submission.AccountId)
+		if err != nil {
+			return nil, err
+		}
+		response, err := eTradeClient.PreviewOrder(account.GetIdKey(), submission.Order)
+		if err != nil {
+			return nil, err
+		}
+		orderPreview, err := etradelib.CreateETradeOrderPreviewFromResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		if len(orderPreview.GetPreviewIds()) < 1 {
+			return nil, errors.New("order preview did not return a preview ID")
+		}
+		submission.PreviewId = orderPreview.GetPreviewIds()[0]
+		previews = append(previews, orderPreview.AsJsonMap())
+	}
+	return jsonmap.JsonMap{
+		submitOrdersPreviewsKey: previews,
+	}, nil
+}
+
+// PlaceOrderSubmissions places every previewed submission and writes a
+// journal entry for each. A failure to place one order does not prevent the
+// others from being placed; failures are reported in the returned map.
+func PlaceOrderSubmissions(
+	eTradeClient client.ETradeClient, submissions []*orderSubmission, journal io.Writer,
+) (jsonmap.JsonMap, error) {
+	placedOrders := jsonmap.JsonSlice{}
+	failedOrders := jsonmap.JsonSlice{}
+	for _, submission := range submissions {
+		orderPlaced, placeErr := placeOrderSubmission(eTradeClient, submission)
+		var orderIds []int64
+		if placeErr == nil {
+			orderIds = orderPlaced.GetOrderIds()
+			placedOrders = append(placedOrders, orderPlaced.AsJsonMap())
+		} else {
+			failedOrders = append(
+				failedOrders, jsonmap.JsonMap{
+					"clientOrderId":      submission.Order.ClientOrderId,
+					submitOrdersErrorKey: placeErr.Error(),
+				},
+			)
+		}
+		if err := AppendOrderJournalEntry(journal, submission, orderIds, placeErr); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(failedOrders) > 0 {
+		return jsonmap.JsonMap{
+			submitOrdersStatusKey:       "error",
+			submitOrdersErrorKey:        "some orders could not be placed",
+			submitOrdersPlacedOrdersKey: placedOrders,
+			submitOrdersFailedOrdersKey: failedOrders,
+		}, nil
+	}
+	return jsonmap.JsonMap{
+		submitOrdersStatusKey:       "success",
+		submitOrdersPlacedOrdersKey: placedOrders,
+	}, nil
+}
+
+func placeOrderSubmission(
+	eTradeClient client.ETradeClient, submission *orderSubmission,
+) (etradelib.ETradeOrderPlaced, error) {
+	account, err := GetAccountById(eTradeClient, submission.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := eTradeClient.PlaceOrder(account.GetIdKey(), submission.PreviewId, submission.Order)
+	if err != nil {
+		return nil, err
+	}
+	return etradelib.CreateETradeOrderPlacedFromResponse(response)
+}
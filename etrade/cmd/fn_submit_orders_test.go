@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSubmitOrders(t *testing.T) {
+	testAccountList := []byte(`
+{
+  "AccountListResponse": {
+    "Accounts": {
+      "Account": [
+        {
+          "accountId": "TestId",
+          "accountIdKey": "TestKey"
+        }
+      ]
+    }
+  }
+}`)
+	testPreviewResponse := []byte(`
+{
+  "PreviewOrderResponse": {
+    "PreviewIds": [
+      {
+        "previewId": 1234
+      }
+    ]
+  }
+}`)
+	testPlacedResponse := []byte(`
+{
+  "PlaceOrderResponse": {
+    "OrderIds": [
+      {
+        "orderId": 5678
+      }
+    ]
+  }
+}`)
+
+	type testFn func(mockClient *client.ETradeClientMock, journal *bytes.Buffer) (interface{}, error)
+	tests := []struct {
+		name                string
+		testFn              testFn
+		expectErr           bool
+		expectValue         interface{}
+		expectJournalStatus []string
+	}{
+		{
+			name: "Previews Orders",
+			testFn: func(mockClient *client.ETradeClientMock, journal *bytes.Buffer) (interface{}, error) {
+				submissions := []*orderSubmission{{AccountId: "TestId", Order: createTestOrderRequest()}}
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PreviewOrder", "TestKey", submissions[0].Order).Return(testPreviewResponse, nil)
+				response, err := PreviewOrderSubmissions(mockClient, submissions)
+				assert.Equal(t, int64(1234), submissions[0].PreviewId)
+				return response, err
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"previews": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"previewIds": jsonmap.JsonSlice{
+							jsonmap.JsonMap{"previewId": json.Number("1234")},
+						},
+					},
+				},
+			},
+			expectJournalStatus: nil,
+		},
+		{
+			name: "Preview Fails On PreviewOrder Error",
+			testFn: func(mockClient *client.ETradeClientMock, journal *bytes.Buffer) (interface{}, error) {
+				submissions := []*orderSubmission{{AccountId: "TestId", Order: createTestOrderRequest()}}
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PreviewOrder", "TestKey", submissions[0].Order).Return(
+					[]byte{}, errors.New("test error"),
+				)
+				return PreviewOrderSubmissions(mockClient, submissions)
+			},
+			expectErr:           true,
+			expectValue:         jsonmap.JsonMap(nil),
+			expectJournalStatus: nil,
+		},
+		{
+			name: "Places Orders And Journals Them",
+			testFn: func(mockClient *client.ETradeClientMock, journal *bytes.Buffer) (interface{}, error) {
+				submissions := []*orderSubmission{
+					{AccountId: "TestId", Order: createTestOrderRequest(), PreviewId: 1234},
+				}
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PlaceOrder", "TestKey", int64(1234), submissions[0].Order).Return(
+					testPlacedResponse, nil,
+				)
+				return PlaceOrderSubmissions(mockClient, submissions, journal)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status": "success",
+				"placedOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"orderIds": jsonmap.JsonSlice{
+							jsonmap.JsonMap{"orderId": json.Number("5678")},
+						},
+					},
+				},
+			},
+			expectJournalStatus: []string{"placed"},
+		},
+		{
+			name: "Continues And Journals After Failed Placement",
+			testFn: func(mockClient *client.ETradeClientMock, journal *bytes.Buffer) (interface{}, error) {
+				failingOrder := createTestOrderRequest()
+				failingOrder.ClientOrderId = "FailingOrder"
+				submissions := []*orderSubmission{
+					{AccountId: "TestId", Order: failingOrder, PreviewId: 1111},
+					{AccountId: "TestId", Order: createTestOrderRequest(), PreviewId: 1234},
+				}
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				mockClient.On("PlaceOrder", "TestKey", int64(1111), failingOrder).Return(
+					[]byte{}, errors.New("test error"),
+				)
+				mockClient.On("PlaceOrder", "TestKey", int64(1234), submissions[1].Order).Return(
+					testPlacedResponse, nil,
+				)
+				return PlaceOrderSubmissions(mockClient, submissions, journal)
+			},
+			expectErr: false,
+			expectValue: jsonmap.JsonMap{
+				"status": "error",
+				"error":  "some orders could not be placed",
+				"placedOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{
+						"orderIds": jsonmap.JsonSlice{
+							jsonmap.JsonMap{"orderId": json.Number("5678")},
+						},
+					},
+				},
+				"failedOrders": jsonmap.JsonSlice{
+					jsonmap.JsonMap{"clientOrderId": "FailingOrder", "error": "test error"},
+				},
+			},
+			expectJournalStatus: []string{"failed", "placed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockClient := client.ETradeClientMock{}
+				journal := bytes.Buffer{}
+				// Call the Method Under Test
+				actualValue, err := tt.testFn(&mockClient, &journal)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+				mockClient.AssertExpectations(t)
+
+				var actualJournalStatus []string
+				for _, line := range strings.Split(strings.TrimSpace(journal.String()), "\n") {
+					if line == "" {
+						continue
+					}
+					entry, err := jsonmap.NewJsonMapFromJsonString(line)
+					assert.Nil(t, err)
+					actualJournalStatus = append(actualJournalStatus, entry.GetValueWithDefault("status", "").(string))
+				}
+				assert.Equal(t, tt.expectJournalStatus, actualJournalStatus)
+			},
+		)
+	}
+}
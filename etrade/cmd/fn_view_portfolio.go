@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
@@ -8,56 +9,49 @@ import (
 )
 
 func ViewPortfolio(
-	eTradeClient client.ETradeClient, accountId string, sortBy constants.PortfolioSortBy, sortOrder constants.SortOrder,
+	ctx context.Context, eTradeClient client.ETradeClient, accountId string, sortBy constants.PortfolioSortBy, sortOrder constants.SortOrder,
 	marketSession constants.MarketSession, totalsRequired bool, portfolioView constants.PortfolioView, withLots bool,
+	lotsConcurrency int,
 ) (jsonmap.JsonMap, error) {
-	// This determines how many portfolio items will be retrieved in each
-	// request. This should normally be set to the max for efficiency, but can
-	// be lowered to test the pagination logic.
-	const countPerRequest = constants.PortfolioMaxCount
-
-	account, err := GetAccountById(eTradeClient, accountId)
-	if err != nil {
-		return nil, err
-	}
-
-	response, err := eTradeClient.ViewPortfolio(
-		account.GetIdKey(), countPerRequest, sortBy, sortOrder, "", marketSession, totalsRequired, true, portfolioView,
+	stream, err := StreamPortfolio(
+		eTradeClient, accountId, sortBy, sortOrder, marketSession, totalsRequired, portfolioView, withLots,
+		lotsConcurrency, 0,
 	)
 	if err != nil {
 		return nil, err
 	}
+	return stream.Collect(ctx)
+}
 
-	positionList, err := etradelib.CreateETradePositionListFromResponse(response)
+// StreamPortfolio returns a stream of the positions in an account's portfolio
+// that retrieves each page of positions as it is needed. The portfolio totals,
+// if requested, accompany the positions. Lots, if requested, are fetched for
+// up to lotsConcurrency positions at once. If maxItems is greater than zero,
+// the stream ends after that many positions.
+func StreamPortfolio(
+	eTradeClient client.ETradeClient, accountId string, sortBy constants.PortfolioSortBy, sortOrder constants.SortOrder,
+	marketSession constants.MarketSession, totalsRequired bool, portfolioView constants.PortfolioView, withLots bool,
+	lotsConcurrency int, maxItems int,
+) (*ListStream, error) {
+	account, err := GetAccountById(eTradeClient, accountId)
 	if err != nil {
 		return nil, err
 	}
-
-	for positionList.NextPage() != "" {
-		response, err = eTradeClient.ViewPortfolio(
-			account.GetIdKey(), countPerRequest, sortBy, sortOrder, positionList.NextPage(), marketSession,
-			totalsRequired, true, portfolioView,
-		)
-		if err != nil {
-			return nil, err
-		}
-		err = positionList.AddPageFromResponse(response)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if withLots {
-		for _, position := range positionList.GetAllPositions() {
-			response, err = eTradeClient.ListPositionLotsDetails(account.GetIdKey(), position.GetId())
-			if err != nil {
-				return nil, err
-			}
-			err = position.AddLotsFromResponse(response)
-			if err != nil {
-				return nil, err
+	iterator := etradelib.NewPositionIterator(
+		eTradeClient, account.GetIdKey(), sortBy, sortOrder, marketSession, totalsRequired, portfolioView, withLots,
+		lotsConcurrency, maxItems,
+	)
+	return &ListStream{
+		ListPath: etradelib.PositionsListPositionsPath,
+		Elements: etradelib.NewJsonMapIterator[etradelib.ETradePosition](iterator),
+		Rest: func() (jsonmap.JsonMap, error) {
+			rest := jsonmap.JsonMap{}
+			if totals := iterator.Totals(); totals != nil {
+				if err := rest.SetMapAtPath(etradelib.PositionsListTotalsPath, totals); err != nil {
+					return nil, err
+				}
 			}
-		}
-	}
-	return positionList.AsJsonMap(), nil
+			return rest, nil
+		},
+	}, nil
 }
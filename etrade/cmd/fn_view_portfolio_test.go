@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
@@ -98,8 +100,8 @@ func TestViewPortfolio(t *testing.T) {
 				mockClient.On("ListPositionLotsDetails", "test key", int64(5678)).Return(testLotsDetails2, nil)
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr: false,
@@ -130,8 +132,8 @@ func TestViewPortfolio(t *testing.T) {
 				mockClient.On("ListAccounts").Return(testAccountList, nil)
 
 				return ViewPortfolio(
-					mockClient, "bad id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "bad id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -147,8 +149,8 @@ func TestViewPortfolio(t *testing.T) {
 				).Return([]byte{}, errors.New("test error"))
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -162,6 +164,9 @@ func TestViewPortfolio(t *testing.T) {
 					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil, "",
 					constants.MarketSessionNil, true, true, constants.PortfolioViewNil,
 				).Return(testPortfolioResponse1, nil)
+				// Lots are fetched along with each page, so the first page's lots
+				// are fetched before the second page fails.
+				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(testLotsDetails1, nil)
 				mockClient.On(
 					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil,
 					"test page no",
@@ -169,8 +174,8 @@ func TestViewPortfolio(t *testing.T) {
 				).Return([]byte{}, errors.New("test error"))
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -191,8 +196,8 @@ func TestViewPortfolio(t *testing.T) {
 				).Return(testBadPortfolioResponse, nil)
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -211,6 +216,7 @@ func TestViewPortfolio(t *testing.T) {
 					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil, "",
 					constants.MarketSessionNil, true, true, constants.PortfolioViewNil,
 				).Return(testPortfolioResponse1, nil)
+				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(testLotsDetails1, nil)
 				mockClient.On(
 					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil,
 					"test page no",
@@ -218,8 +224,8 @@ func TestViewPortfolio(t *testing.T) {
 				).Return(testBadPortfolioResponse, nil)
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -233,18 +239,13 @@ func TestViewPortfolio(t *testing.T) {
 					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil, "",
 					constants.MarketSessionNil, true, true, constants.PortfolioViewNil,
 				).Return(testPortfolioResponse1, nil)
-				mockClient.On(
-					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil,
-					"test page no",
-					constants.MarketSessionNil, true, true, constants.PortfolioViewNil,
-				).Return(testPortfolioResponse2, nil)
 				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(
 					[]byte{}, errors.New("test error"),
 				)
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -263,16 +264,26 @@ func TestViewPortfolio(t *testing.T) {
 					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil, "",
 					constants.MarketSessionNil, true, true, constants.PortfolioViewNil,
 				).Return(testPortfolioResponse1, nil)
-				mockClient.On(
-					"ViewPortfolio", "test key", 65535, constants.PortfolioSortByNil, constants.SortOrderNil,
-					"test page no",
-					constants.MarketSessionNil, true, true, constants.PortfolioViewNil,
-				).Return(testPortfolioResponse2, nil)
 				mockClient.On("ListPositionLotsDetails", "test key", int64(1234)).Return(testBadLotsDetails, nil)
 
 				return ViewPortfolio(
-					mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
-					constants.MarketSessionNil, true, constants.PortfolioViewNil, true,
+					context.Background(), mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
+				)
+			},
+			expectErr:   true,
+			expectValue: jsonmap.JsonMap(nil),
+		},
+		{
+			name: "Fails With Cancelled Context",
+			testFn: func(mockClient *client.ETradeClientMock) (interface{}, error) {
+				mockClient.On("ListAccounts").Return(testAccountList, nil)
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ViewPortfolio(
+					ctx, mockClient, "test id", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, true, 4,
 				)
 			},
 			expectErr:   true,
@@ -284,6 +295,8 @@ func TestViewPortfolio(t *testing.T) {
 		t.Run(
 			tt.name, func(t *testing.T) {
 				mockClient := client.ETradeClientMock{}
+				// The iterators make their requests with the iteration's context.
+				mockClient.On("WithContext", mock.Anything).Return(&mockClient).Maybe()
 				// Call the Method Under Test
 				actualValue, err := tt.testFn(&mockClient)
 				if tt.expectErr {
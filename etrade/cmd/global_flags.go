@@ -5,6 +5,9 @@ type globalFlags struct {
 	debug          bool
 	outputFileName string
 	outputFormat   enumFlagValue[outputFormat]
+	apiBaseUrl     string
+	recordDir      string
+	replayDir      string
 }
 
 type outputFormat int
@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"io"
 	"os"
+	"sort"
+	"strings"
 )
 
 type jsonRenderer struct {
@@ -14,6 +22,96 @@ func (j *jsonRenderer) Render(jsonMap jsonmap.JsonMap, _ []RenderDescriptor) err
 	return jsonMap.ToIoWriter(j.outputFile, j.pretty, false)
 }
 
+// RenderStream writes each element of the list as it arrives. The output is
+// the same as rendering the collected list, provided that the list's key sorts
+// before the keys of the values that accompany it.
+func (j *jsonRenderer) RenderStream(ctx context.Context, stream *ListStream, _ []RenderDescriptor) error {
+	// Indentation and separators match those that ToIoWriter produces.
+	indent, newline, keySeparator := "", "", ":"
+	if j.pretty {
+		indent, newline, keySeparator = "  ", "\n", ": "
+	}
+
+	listKey, err := j.encode(strings.TrimPrefix(stream.ListPath, "."), "")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(j.outputFile, "{"+newline+indent+listKey+keySeparator+"["); err != nil {
+		return err
+	}
+	count := 0
+	for {
+		element, err := stream.Elements.Next(ctx)
+		if errors.Is(err, etradelib.ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		encodedElement, err := j.encode(element, indent+indent)
+		if err != nil {
+			return err
+		}
+		separator := ""
+		if count > 0 {
+			separator = ","
+		}
+		if _, err = io.WriteString(j.outputFile, separator+newline+indent+indent+encodedElement); err != nil {
+			return err
+		}
+		count++
+	}
+	if count > 0 {
+		if _, err = io.WriteString(j.outputFile, newline+indent); err != nil {
+			return err
+		}
+	}
+	if _, err = io.WriteString(j.outputFile, "]"); err != nil {
+		return err
+	}
+
+	rest, err := stream.rest()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(rest))
+	for key := range rest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		encodedKey, err := j.encode(key, "")
+		if err != nil {
+			return err
+		}
+		encodedValue, err := j.encode(rest[key], indent)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(j.outputFile, ","+newline+indent+encodedKey+keySeparator+encodedValue)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(j.outputFile, newline+"}\n")
+	return err
+}
+
 func (j *jsonRenderer) Close() error {
 	return j.outputFile.Close()
 }
+
+// encode encodes a value as JSON, with each line after the first prefixed by
+// the given string.
+func (j *jsonRenderer) encode(value interface{}, prefix string) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if j.pretty {
+		encoder.SetIndent(prefix, "  ")
+	}
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+)
+
+// orderFile is the layout of a declarative order file. Because JSON is a
+// subset of YAML, the same layout may be written in either format:
+//
+//	orders:
+//	  - accountId: "12345678"
+//	    priceType: netDebit
+//	    limitPrice: 1.50
+//	    legs:
+//	      - action: buyOpen
+//	        quantity: 1
+//	        symbol: "GOOG  240119C00150000"
+//	      - action: sellOpen
+//	        quantity: 1
+//	        symbol: "GOOG  240119C00155000"
+//	  - accountId: "12345678"
+//	    symbol: GOOG
+//	    action: buy
+//	    quantity: 10
+//	    priceType: limit
+//	    limitPrice: 123.45
+//
+// Enum values use the same names as the corresponding command line flags.
+type orderFile struct {
+	Orders []orderFileOrder `yaml:"orders"`
+}
+
+type orderFileOrder struct {
+	AccountId     string          `yaml:"accountId"`
+	ClientOrderId string          `yaml:"clientOrderId"`
+	OrderType     string          `yaml:"orderType"`
+	PriceType     string          `yaml:"priceType"`
+	OrderTerm     string          `yaml:"orderTerm"`
+	MarketSession string          `yaml:"marketSession"`
+	LimitPrice    decimal.Decimal `yaml:"limitPrice"`
+	StopPrice     decimal.Decimal `yaml:"stopPrice"`
+	AllOrNone     bool            `yaml:"allOrNone"`
+	Symbol        string          `yaml:"symbol"`
+	Action        string          `yaml:"action"`
+	Quantity      int             `yaml:"quantity"`
+	Legs          []orderFileLeg  `yaml:"legs"`
+}
+
+type orderFileLeg struct {
+	Action   string `yaml:"action"`
+	Quantity int    `yaml:"quantity"`
+	Symbol   string `yaml:"symbol"`
+}
+
+// orderSubmission is an order from an order file, along with the state it
+// accumulates as it is previewed and placed.
+type orderSubmission struct {
+	AccountId string
+	Order     *client.OrderRequest
+	PreviewId int64
+}
+
+// LoadOrderFile reads an order file and returns a validated submission for
+// each order it contains.
+func LoadOrderFile(filePath string) ([]*orderSubmission, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return loadOrderFile(file)
+}
+
+func loadOrderFile(reader io.Reader) ([]*orderSubmission, error) {
+	decoder := yaml.NewDecoder(reader)
+	// Reject unknown fields so that a misspelled field (e.g. "limitprice")
+	// fails loudly rather than being silently dropped from an order.
+	decoder.KnownFields(true)
+
+	var file orderFile
+	if err := decoder.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("order file is empty")
+		}
+		return nil, fmt.Errorf("unable to parse order file: %w", err)
+	}
+	if len(file.Orders) == 0 {
+		return nil, errors.New("order file contains no orders")
+	}
+
+	submissions := make([]*orderSubmission, 0, len(file.Orders))
+	for i := range file.Orders {
+		submission, err := file.Orders[i].submission()
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i+1, err)
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, nil
+}
+
+func (o *orderFileOrder) submission() (*orderSubmission, error) {
+	if o.AccountId == "" {
+		return nil, errors.New("accountId not provided")
+	}
+
+	var instruments []client.OrderInstrument
+	if len(o.Legs) > 0 {
+		if o.Symbol != "" || o.Action != "" || o.Quantity != 0 {
+			return nil, errors.New("symbol, action, and quantity may not be combined with legs")
+		}
+		for _, leg := range o.Legs {
+			instrument, err := newOrderFileInstrument(leg.Action, leg.Quantity, leg.Symbol)
+			if err != nil {
+				return nil, err
+			}
+			instruments = append(instruments, instrument)
+		}
+	} else {
+		if o.Symbol == "" {
+			return nil, errors.New("either symbol, action, and quantity or legs are required")
+		}
+		instrument, err := newOrderFileInstrument(o.Action, o.Quantity, o.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, instrument)
+	}
+
+	order := client.OrderRequest{
+		ClientOrderId: o.ClientOrderId,
+		LimitPrice:    o.LimitPrice,
+		StopPrice:     o.StopPrice,
+		AllOrNone:     o.AllOrNone,
+		Instruments:   instruments,
+	}
+	var err error
+	if o.OrderType == "" {
+		if order.OrderType, err = inferOrderType(instruments); err != nil {
+			return nil, err
+		}
+	} else if order.OrderType, err = orderTypeMap.GetEnumValue(o.OrderType); err != nil {
+		return nil, fmt.Errorf("unknown orderType '%s'", o.OrderType)
+	}
+	if order.PriceType, err = orderPriceTypeMap.GetEnumValue(valueOrDefault(o.PriceType, "limit")); err != nil {
+		return nil, fmt.Errorf("unknown priceType '%s'", o.PriceType)
+	}
+	if order.OrderTerm, err = orderTermMap.GetEnumValue(valueOrDefault(o.OrderTerm, "goodForDay")); err != nil {
+		return nil, fmt.Errorf("unknown orderTerm '%s'", o.OrderTerm)
+	}
+	if order.MarketSession, err = marketSessionMap.GetEnumValue(
+		valueOrDefault(o.MarketSession, "regular"),
+	); err != nil {
+		return nil, fmt.Errorf("unknown marketSession '%s'", o.MarketSession)
+	}
+	if order.ClientOrderId == "" {
+		if order.ClientOrderId, err = client.NewClientOrderId(); err != nil {
+			return nil, err
+		}
+	}
+	if err = order.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &orderSubmission{
+		AccountId: o.AccountId,
+		Order:     &order,
+	}, nil
+}
+
+func newOrderFileInstrument(action string, quantity int, symbol string) (client.OrderInstrument, error) {
+	orderAction, err := orderActionMap.GetEnumValue(action)
+	if err != nil {
+		return client.OrderInstrument{}, fmt.Errorf("unknown action '%s' for %s", action, symbol)
+	}
+	return newOrderInstrument(orderAction, quantity, symbol)
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLoadOrderFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileContent string
+		expectErr   bool
+		expectValue []*orderSubmission
+	}{
+		{
+			name: "Loads YAML Orders",
+			fileContent: `
+orders:
+  - accountId: "TestId"
+    clientOrderId: TestOrder1
+    symbol: GOOG
+    action: buy
+    quantity: 10
+    limitPrice: 123.45
+  - accountId: "TestId"
+    clientOrderId: TestOrder2
+    priceType: netDebit
+    orderTerm: goodUntilCancel
+    limitPrice: 1.5
+    legs:
+      - action: buyOpen
+        quantity: 1
+        symbol: "GOOG  240119C00150000"
+      - action: sellOpen
+        quantity: 1
+        symbol: "GOOG  240119C00155000"
+`,
+			expectErr: false,
+			expectValue: []*orderSubmission{
+				{
+					AccountId: "TestId",
+					Order: &client.OrderRequest{
+						ClientOrderId: "TestOrder1",
+						OrderType:     constants.OrderTypeEquity,
+						PriceType:     constants.OrderPriceTypeLimit,
+						OrderTerm:     constants.OrderTermGoodForDay,
+						MarketSession: constants.MarketSessionRegular,
+						LimitPrice:    decimal.MustNewFromString("123.45"),
+						Instruments: []client.OrderInstrument{
+							{
+								SecurityType: constants.OrderSecurityTypeEquity,
+								Symbol:       "GOOG",
+								OrderAction:  constants.OrderActionBuy,
+								Quantity:     10,
+							},
+						},
+					},
+				},
+				{
+					AccountId: "TestId",
+					Order: &client.OrderRequest{
+						ClientOrderId: "TestOrder2",
+						OrderType:     constants.OrderTypeSpreads,
+						PriceType:     constants.OrderPriceTypeNetDebit,
+						OrderTerm:     constants.OrderTermGoodUntilCancel,
+						MarketSession: constants.MarketSessionRegular,
+						LimitPrice:    decimal.MustNewFromString("1.5"),
+						Instruments: []client.OrderInstrument{
+							{
+								SecurityType: constants.OrderSecurityTypeOption,
+								Symbol:       "GOOG",
+								CallPut:      constants.OptionCallPutCall,
+								ExpiryYear:   2024,
+								ExpiryMonth:  1,
+								ExpiryDay:    19,
+								StrikePrice:  decimal.New(150000, 3),
+								OrderAction:  constants.OrderActionBuyOpen,
+								Quantity:     1,
+							},
+							{
+								SecurityType: constants.OrderSecurityTypeOption,
+								Symbol:       "GOOG",
+								CallPut:      constants.OptionCallPutCall,
+								ExpiryYear:   2024,
+								ExpiryMonth:  1,
+								ExpiryDay:    19,
+								StrikePrice:  decimal.New(155000, 3),
+								OrderAction:  constants.OrderActionSellOpen,
+								Quantity:     1,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Loads JSON Orders",
+			fileContent: `
+{
+  "orders": [
+    {
+      "accountId": "TestId",
+      "clientOrderId": "TestOrder1",
+      "symbol": "GOOG",
+      "action": "sell",
+      "quantity": 5,
+      "priceType": "market"
+    }
+  ]
+}`,
+			expectErr: false,
+			expectValue: []*orderSubmission{
+				{
+					AccountId: "TestId",
+					Order: &client.OrderRequest{
+						ClientOrderId: "TestOrder1",
+						OrderType:     constants.OrderTypeEquity,
+						PriceType:     constants.OrderPriceTypeMarket,
+						OrderTerm:     constants.OrderTermGoodForDay,
+						MarketSession: constants.MarketSessionRegular,
+						Instruments: []client.OrderInstrument{
+							{
+								SecurityType: constants.OrderSecurityTypeEquity,
+								Symbol:       "GOOG",
+								OrderAction:  constants.OrderActionSell,
+								Quantity:     5,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Fails On Unknown Field",
+			fileContent: `
+orders:
+  - accountId: "TestId"
+    symbol: GOOG
+    action: buy
+    quantity: 10
+    limitprice: 123.45
+`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails On Unknown Enum Value",
+			fileContent: `
+orders:
+  - accountId: "TestId"
+    symbol: GOOG
+    action: buy
+    quantity: 10
+    priceType: cheap
+`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails On Invalid Order",
+			fileContent: `
+orders:
+  - accountId: "TestId"
+    symbol: GOOG
+    action: buy
+    quantity: 10
+`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails Without Account ID",
+			fileContent: `
+orders:
+  - symbol: GOOG
+    action: buy
+    quantity: 10
+    priceType: market
+`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails With Symbol And Legs",
+			fileContent: `
+orders:
+  - accountId: "TestId"
+    symbol: GOOG
+    priceType: market
+    legs:
+      - action: buy
+        quantity: 1
+        symbol: GOOG
+`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name:        "Fails Without Orders",
+			fileContent: `orders: []`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name:        "Fails On Empty File",
+			fileContent: ``,
+			expectErr:   true,
+			expectValue: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := loadOrderFile(strings.NewReader(tt.fileContent))
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestLoadOrderFile_GeneratesClientOrderId(t *testing.T) {
+	fileContent := `
+orders:
+  - accountId: "TestId"
+    symbol: GOOG
+    action: buy
+    quantity: 10
+    priceType: market
+`
+	actualValue, err := loadOrderFile(strings.NewReader(fileContent))
+	assert.Nil(t, err)
+	assert.Len(t, actualValue, 1)
+	assert.NotEmpty(t, actualValue[0].Order.ClientOrderId)
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/spf13/cobra"
+	"strconv"
+	"strings"
+)
+
+// orderFlags holds the flags that describe an order. They are shared by the
+// commands that preview and place orders so that the same order can be
+// described identically for both steps. An order is described either by a
+// single symbol, action, and quantity or by one or more legs.
+type orderFlags struct {
+	clientOrderId string
+	symbol        string
+	quantity      int
+	legs          []string
+	limitPrice    decimalFlagValue
+	stopPrice     decimalFlagValue
+	allOrNone     bool
+	orderType     enumFlagValue[constants.OrderType]
+	orderAction   enumFlagValue[constants.OrderAction]
+	priceType     enumFlagValue[constants.OrderPriceType]
+	orderTerm     enumFlagValue[constants.OrderTerm]
+	marketSession enumFlagValue[constants.MarketSession]
+}
+
+func (f *orderFlags) addFlags(cmd *cobra.Command) {
+	// Add Flags
+	cmd.Flags().StringVarP(&f.clientOrderId, "client-order-id", "i", "", "client order ID (up to 20 characters)")
+	cmd.Flags().StringVarP(&f.symbol, "symbol", "s", "", "symbol to trade")
+	cmd.Flags().IntVarP(&f.quantity, "quantity", "q", 0, "quantity to trade")
+	cmd.Flags().StringArrayVarP(
+		&f.legs, "leg", "g", []string{},
+		"order leg as ACTION:QUANTITY:SYMBOL, where SYMBOL is an equity symbol or an OCC option symbol "+
+			"(may be repeated; e.g. buyOpen:1:\"GOOG  240119C00150000\")",
+	)
+	cmd.Flags().VarP(&f.limitPrice, "limit-price", "l", "limit price (for limit, stop limit, net debit, and net credit orders)")
+	cmd.Flags().VarP(&f.stopPrice, "stop-price", "p", "stop price (for stop and stop limit orders)")
+	cmd.Flags().BoolVar(&f.allOrNone, "all-or-none", false, "fill the entire quantity or none of it")
+
+	// Initialize Enum Flag Values
+	f.orderType = *newEnumFlagValue(orderTypeMap, constants.OrderTypeNil)
+	f.orderAction = *newEnumFlagValue(orderActionMap, constants.OrderActionNil)
+	f.priceType = *newEnumFlagValue(orderPriceTypeMap, constants.OrderPriceTypeLimit)
+	f.orderTerm = *newEnumFlagValue(orderTermMap, constants.OrderTermGoodForDay)
+	f.marketSession = *newEnumFlagValue(marketSessionMap, constants.MarketSessionRegular)
+
+	// Add Enum Flags
+	cmd.Flags().VarP(
+		&f.orderType, "order-type", "o",
+		fmt.Sprintf(
+			"order type (%s); inferred from the instruments if not provided",
+			f.orderType.JoinAllowedValues(", "),
+		),
+	)
+	_ = cmd.RegisterFlagCompletionFunc(
+		"order-type",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return f.orderType.AllowedValuesWithHelp(), cobra.ShellCompDirectiveDefault
+		},
+	)
+
+	cmd.Flags().VarP(
+		&f.orderAction, "action", "a",
+		fmt.Sprintf("order action (%s)", f.orderAction.JoinAllowedValues(", ")),
+	)
+	_ = cmd.RegisterFlagCompletionFunc(
+		"action",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return f.orderAction.AllowedValuesWithHelp(), cobra.ShellCompDirectiveDefault
+		},
+	)
+
+	cmd.Flags().VarP(
+		&f.priceType, "price-type", "y",
+		fmt.Sprintf("price type (%s)", f.priceType.JoinAllowedValues(", ")),
+	)
+	_ = cmd.RegisterFlagCompletionFunc(
+		"price-type",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return f.priceType.AllowedValuesWithHelp(), cobra.ShellCompDirectiveDefault
+		},
+	)
+
+	cmd.Flags().VarP(
+		&f.orderTerm, "term", "t",
+		fmt.Sprintf("order term (%s)", f.orderTerm.JoinAllowedValues(", ")),
+	)
+	_ = cmd.RegisterFlagCompletionFunc(
+		"term",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return f.orderTerm.AllowedValuesWithHelp(), cobra.ShellCompDirectiveDefault
+		},
+	)
+
+	cmd.Flags().VarP(
+		&f.marketSession, "market-session", "m",
+		fmt.Sprintf("market session (%s)", f.marketSession.JoinAllowedValues(", ")),
+	)
+	_ = cmd.RegisterFlagCompletionFunc(
+		"market-session",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return f.marketSession.AllowedValuesWithHelp(), cobra.ShellCompDirectiveDefault
+		},
+	)
+
+	// An order is described either by a symbol, action, and quantity or by
+	// one or more legs.
+	cmd.MarkFlagsRequiredTogether("symbol", "action", "quantity")
+	cmd.MarkFlagsMutuallyExclusive("symbol", "leg")
+	cmd.MarkFlagsMutuallyExclusive("action", "leg")
+	cmd.MarkFlagsMutuallyExclusive("quantity", "leg")
+}
+
+func (f *orderFlags) orderRequest() (*client.OrderRequest, error) {
+	var instruments []client.OrderInstrument
+	if len(f.legs) > 0 {
+		for _, legSpec := range f.legs {
+			instrument, err := parseOrderLeg(legSpec)
+			if err != nil {
+				return nil, err
+			}
+			instruments = append(instruments, instrument)
+		}
+	} else {
+		if f.symbol == "" {
+			return nil, errors.New("either --symbol, --action, and --quantity or at least one --leg is required")
+		}
+		instruments = []client.OrderInstrument{
+			{
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Symbol:       f.symbol,
+				OrderAction:  f.orderAction.Value(),
+				Quantity:     f.quantity,
+			},
+		}
+	}
+
+	orderType := f.orderType.Value()
+	if orderType == constants.OrderTypeNil {
+		var err error
+		if orderType, err = inferOrderType(instruments); err != nil {
+			return nil, err
+		}
+	}
+
+	return &client.OrderRequest{
+		ClientOrderId: f.clientOrderId,
+		OrderType:     orderType,
+		PriceType:     f.priceType.Value(),
+		OrderTerm:     f.orderTerm.Value(),
+		MarketSession: f.marketSession.Value(),
+		LimitPrice:    f.limitPrice.Value(),
+		StopPrice:     f.stopPrice.Value(),
+		AllOrNone:     f.allOrNone,
+		Instruments:   instruments,
+	}, nil
+}
+
+// occSymbolMinLength is the shortest possible OCC option symbol: a
+// one-character root symbol followed by the 15-character date, type, and
+// strike. Leg symbols shorter than this are treated as equity symbols.
+const occSymbolMinLength = 16
+
+// parseOrderLeg parses a leg specification of the form ACTION:QUANTITY:SYMBOL.
+func parseOrderLeg(legSpec string) (client.OrderInstrument, error) {
+	parts := strings.SplitN(legSpec, ":", 3)
+	if len(parts) != 3 {
+		return client.OrderInstrument{}, fmt.Errorf("leg '%s' must be in the form ACTION:QUANTITY:SYMBOL", legSpec)
+	}
+	action, err := orderActionMap.GetEnumValue(parts[0])
+	if err != nil {
+		return client.OrderInstrument{}, fmt.Errorf("leg '%s' has unknown action '%s'", legSpec, parts[0])
+	}
+	quantity, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return client.OrderInstrument{}, fmt.Errorf("leg '%s' has invalid quantity '%s'", legSpec, parts[1])
+	}
+	return newOrderInstrument(action, quantity, parts[2])
+}
+
+// newOrderInstrument creates an option instrument if the symbol is an OCC
+// option symbol or an equity instrument otherwise.
+func newOrderInstrument(action constants.OrderAction, quantity int, symbol string) (client.OrderInstrument, error) {
+	symbol = strings.TrimSpace(symbol)
+	if len(symbol) >= occSymbolMinLength {
+		return client.NewOptionInstrumentFromOccSymbol(symbol, action, quantity)
+	}
+	return client.OrderInstrument{
+		SecurityType: constants.OrderSecurityTypeEquity,
+		Symbol:       symbol,
+		OrderAction:  action,
+		Quantity:     quantity,
+	}, nil
+}
+
+// inferOrderType chooses an order type for the common combinations of
+// instruments. Orders such as butterflies and condors must specify their
+// order type explicitly.
+func inferOrderType(instruments []client.OrderInstrument) (constants.OrderType, error) {
+	equityLegs, optionLegs := 0, 0
+	for _, instrument := range instruments {
+		if instrument.SecurityType == constants.OrderSecurityTypeOption {
+			optionLegs++
+		} else {
+			equityLegs++
+		}
+	}
+	switch {
+	case equityLegs == 1 && optionLegs == 0:
+		return constants.OrderTypeEquity, nil
+	case equityLegs == 0 && optionLegs == 1:
+		return constants.OrderTypeOption, nil
+	case equityLegs == 1 && optionLegs == 1:
+		return constants.OrderTypeBuyWrites, nil
+	case equityLegs == 0 && optionLegs > 1:
+		return constants.OrderTypeSpreads, nil
+	default:
+		return constants.OrderTypeNil, errors.New("unable to infer order type; please specify --order-type")
+	}
+}
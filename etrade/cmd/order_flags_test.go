@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseOrderLeg(t *testing.T) {
+	tests := []struct {
+		name        string
+		legSpec     string
+		expectErr   bool
+		expectValue client.OrderInstrument
+	}{
+		{
+			name:      "Parses Option Leg",
+			legSpec:   "buyOpen:2:GOOG  240119C00150000",
+			expectErr: false,
+			expectValue: client.OrderInstrument{
+				SecurityType: constants.OrderSecurityTypeOption,
+				Symbol:       "GOOG",
+				CallPut:      constants.OptionCallPutCall,
+				ExpiryYear:   2024,
+				ExpiryMonth:  1,
+				ExpiryDay:    19,
+				StrikePrice:  decimal.New(150000, 3),
+				OrderAction:  constants.OrderActionBuyOpen,
+				Quantity:     2,
+			},
+		},
+		{
+			name:      "Parses Equity Leg",
+			legSpec:   "buy:100:GOOG",
+			expectErr: false,
+			expectValue: client.OrderInstrument{
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Symbol:       "GOOG",
+				OrderAction:  constants.OrderActionBuy,
+				Quantity:     100,
+			},
+		},
+		{
+			name:        "Fails With Missing Parts",
+			legSpec:     "buy:GOOG",
+			expectErr:   true,
+			expectValue: client.OrderInstrument{},
+		},
+		{
+			name:        "Fails With Unknown Action",
+			legSpec:     "hold:1:GOOG",
+			expectErr:   true,
+			expectValue: client.OrderInstrument{},
+		},
+		{
+			name:        "Fails With Bad Quantity",
+			legSpec:     "buy:one:GOOG",
+			expectErr:   true,
+			expectValue: client.OrderInstrument{},
+		},
+		{
+			name:        "Fails With Bad Option Symbol",
+			legSpec:     "buyOpen:1:GOOG  240119X00150000",
+			expectErr:   true,
+			expectValue: client.OrderInstrument{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := parseOrderLeg(tt.legSpec)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestInferOrderType(t *testing.T) {
+	equity := client.OrderInstrument{SecurityType: constants.OrderSecurityTypeEquity}
+	option := client.OrderInstrument{SecurityType: constants.OrderSecurityTypeOption}
+	tests := []struct {
+		name        string
+		instruments []client.OrderInstrument
+		expectErr   bool
+		expectValue constants.OrderType
+	}{
+		{
+			name:        "Infers Equity",
+			instruments: []client.OrderInstrument{equity},
+			expectErr:   false,
+			expectValue: constants.OrderTypeEquity,
+		},
+		{
+			name:        "Infers Option",
+			instruments: []client.OrderInstrument{option},
+			expectErr:   false,
+			expectValue: constants.OrderTypeOption,
+		},
+		{
+			name:        "Infers Buy Writes",
+			instruments: []client.OrderInstrument{equity, option},
+			expectErr:   false,
+			expectValue: constants.OrderTypeBuyWrites,
+		},
+		{
+			name:        "Infers Spreads",
+			instruments: []client.OrderInstrument{option, option},
+			expectErr:   false,
+			expectValue: constants.OrderTypeSpreads,
+		},
+		{
+			name:        "Fails On Multiple Equities",
+			instruments: []client.OrderInstrument{equity, equity},
+			expectErr:   true,
+			expectValue: constants.OrderTypeNil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := inferOrderType(tt.instruments)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The order journal is a file of JSON lines, each of which records an
+// attempt to place an order. An entry looks like this:
+// {
+//   "time": "2023-06-01T12:34:56-07:00",
+//   "accountId": "12345678",
+//   "clientOrderId": "0123456789abcdef",
+//   "previewId": 1234,
+//   "status": "placed",
+//   "orderIds": [5678],
+//   "error": "<error message, if status is failed>",
+//   "request": {
+//     <place order request>
+//   }
+// }
+
+// OpenOrderJournalFile opens the journal file for appending, creating it if
+// it does not exist.
+func OpenOrderJournalFile(filename string) (*os.File, error) {
+	dirPath := filepath.Dir(filename)
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+}
+
+// AppendOrderJournalEntry writes a journal entry for an order submission.
+// If placeErr is nil, the order is recorded as placed with the given order
+// IDs; otherwise, it is recorded as failed.
+func AppendOrderJournalEntry(
+	writer io.Writer, submission *orderSubmission, orderIds []int64, placeErr error,
+) error {
+	entry := jsonmap.JsonMap{
+		"time":          time.Now().Format(time.RFC3339),
+		"accountId":     submission.AccountId,
+		"clientOrderId": submission.Order.ClientOrderId,
+		"previewId":     submission.PreviewId,
+		"request":       submission.Order.AsPlaceRequestJsonMap(submission.PreviewId),
+	}
+	if placeErr == nil {
+		entry["status"] = "placed"
+		entry["orderIds"] = jsonmap.NewJsonSliceFromSlice(orderIds)
+	} else {
+		entry["status"] = "failed"
+		entry["error"] = placeErr.Error()
+	}
+	// The encoder terminates each entry with a newline.
+	return entry.ToIoWriter(writer, false, false)
+}
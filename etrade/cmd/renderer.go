@@ -1,9 +1,14 @@
 package cmd
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"context"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
 
 type Renderer interface {
 	Render(jsonMap jsonmap.JsonMap, descriptors []RenderDescriptor) error
+	RenderStream(ctx context.Context, stream *ListStream, descriptors []RenderDescriptor) error
 	Close() error
 }
 
@@ -22,3 +27,58 @@ type RenderValue struct {
 	Path        string
 	Transformer TransformerFn
 }
+
+// ListStream is a list whose elements are retrieved as they are rendered,
+// rather than all at once before rendering begins.
+type ListStream struct {
+	// ListPath is the top-level path of the list (e.g. ".transactions").
+	ListPath string
+	// Elements returns the elements of the list.
+	Elements etradelib.Iterator[jsonmap.JsonMap]
+	// Rest, if not nil, returns any values that accompany the list. These
+	// values may not be complete until every element has been read, so they
+	// are rendered after the list.
+	Rest func() (jsonmap.JsonMap, error)
+}
+
+// Collect reads every element of the stream and returns the same map that
+// Render would expect for the fully-retrieved list.
+func (s *ListStream) Collect(ctx context.Context) (jsonmap.JsonMap, error) {
+	elements, err := etradelib.CollectIterator(ctx, s.Elements)
+	if err != nil {
+		return nil, err
+	}
+	slice := make(jsonmap.JsonSlice, 0, len(elements))
+	for _, element := range elements {
+		slice = append(slice, element)
+	}
+	// Copy the rest, rather than adding the list to it, in case Rest returns
+	// a map that belongs to something else.
+	rest, err := s.rest()
+	if err != nil {
+		return nil, err
+	}
+	result := jsonmap.JsonMap{}
+	for key, value := range rest {
+		result[key] = value
+	}
+	err = result.SetSliceAtPath(s.ListPath, slice)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *ListStream) rest() (jsonmap.JsonMap, error) {
+	if s.Rest == nil {
+		return jsonmap.JsonMap{}, nil
+	}
+	rest, err := s.Rest()
+	if err != nil {
+		return nil, err
+	}
+	if rest == nil {
+		return jsonmap.JsonMap{}, nil
+	}
+	return rest, nil
+}
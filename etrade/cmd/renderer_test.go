@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testStreamDescriptor = []RenderDescriptor{
+	{
+		ObjectPath: ".items",
+		Values: []RenderValue{
+			{Header: "Name", Path: ".name"},
+			{Header: "Price", Path: ".price"},
+		},
+		SubObjects: []RenderDescriptor{
+			{
+				ObjectPath: ".lots",
+				Values:     []RenderValue{{Header: "Lot", Path: ".lot"}},
+				SpaceAfter: true,
+			},
+		},
+		SpaceAfter: true,
+	},
+	{
+		ObjectPath: ".totals",
+		Values:     []RenderValue{{Header: "Total", Path: ".total"}},
+	},
+}
+
+func newTestStream(elements []jsonmap.JsonMap, rest jsonmap.JsonMap) *ListStream {
+	return &ListStream{
+		ListPath: ".items",
+		Elements: etradelib.NewPageIterator(
+			func(ctx context.Context, marker string) ([]jsonmap.JsonMap, string, error) {
+				return elements, "", nil
+			}, 0,
+		),
+		Rest: func() (jsonmap.JsonMap, error) { return rest, nil },
+	}
+}
+
+// renderToString renders with a renderer that writes to a temporary file and
+// returns what was written.
+func renderToString(t *testing.T, newRenderer func(f *os.File) Renderer, renderFn func(r Renderer) error) string {
+	path := filepath.Join(t.TempDir(), "output")
+	outputFile, err := os.Create(path)
+	require.Nil(t, err)
+	renderer := newRenderer(outputFile)
+	require.Nil(t, renderFn(renderer))
+	require.Nil(t, renderer.Close())
+	output, err := os.ReadFile(path)
+	require.Nil(t, err)
+	return string(output)
+}
+
+func TestRenderer_RenderStream(t *testing.T) {
+	renderers := []struct {
+		name        string
+		newRenderer func(f *os.File) Renderer
+	}{
+		{"CSV", func(f *os.File) Renderer { return &csvRenderer{outputFile: f} }},
+		{"JSON", func(f *os.File) Renderer { return &jsonRenderer{outputFile: f, pretty: false} }},
+		{"Pretty JSON", func(f *os.File) Renderer { return &jsonRenderer{outputFile: f, pretty: true} }},
+	}
+	streams := []struct {
+		name     string
+		elements []jsonmap.JsonMap
+		rest     jsonmap.JsonMap
+	}{
+		{
+			name: "List With Totals",
+			elements: []jsonmap.JsonMap{
+				{"name": "a<b", "price": json.Number("1.50"), "lots": jsonmap.JsonSlice{jsonmap.JsonMap{"lot": "1"}}},
+				{"name": "c", "price": json.Number("2"), "lots": jsonmap.JsonSlice{}},
+			},
+			rest: jsonmap.JsonMap{"totals": jsonmap.JsonMap{"total": json.Number("3.50")}},
+		},
+		{
+			name:     "List Without Totals",
+			elements: []jsonmap.JsonMap{{"name": "a", "price": json.Number("1")}},
+		},
+		{
+			name:     "Empty List",
+			elements: []jsonmap.JsonMap{},
+		},
+	}
+
+	for _, r := range renderers {
+		for _, s := range streams {
+			t.Run(
+				r.name+" "+s.name, func(t *testing.T) {
+					expected := renderToString(
+						t, r.newRenderer, func(renderer Renderer) error {
+							collected, err := newTestStream(s.elements, s.rest).Collect(context.Background())
+							if err != nil {
+								return err
+							}
+							return renderer.Render(collected, testStreamDescriptor)
+						},
+					)
+
+					// Call the Method Under Test
+					actual := renderToString(
+						t, r.newRenderer, func(renderer Renderer) error {
+							return renderer.RenderStream(
+								context.Background(), newTestStream(s.elements, s.rest), testStreamDescriptor,
+							)
+						},
+					)
+
+					assert.Equal(t, expected, actual)
+				},
+			)
+		}
+	}
+}
+
+func TestRenderer_RenderStream_ReturnsIteratorError(t *testing.T) {
+	outputFile, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	require.Nil(t, err)
+	defer func() { _ = outputFile.Close() }()
+
+	for _, renderer := range []Renderer{&csvRenderer{outputFile: outputFile}, &jsonRenderer{outputFile: outputFile}} {
+		stream := &ListStream{
+			ListPath: ".items",
+			Elements: etradelib.NewPageIterator(
+				func(ctx context.Context, marker string) ([]jsonmap.JsonMap, string, error) {
+					return nil, "", errors.New("test error")
+				}, 0,
+			),
+		}
+
+		// Call the Method Under Test
+		err := renderer.RenderStream(context.Background(), stream, testStreamDescriptor)
+
+		assert.EqualError(t, err, "test error")
+	}
+}
+
+func TestRenderer_RenderStream_ReturnsRestError(t *testing.T) {
+	outputFile, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	require.Nil(t, err)
+	defer func() { _ = outputFile.Close() }()
+	newStream := func() *ListStream {
+		stream := newTestStream([]jsonmap.JsonMap{{"name": "a", "price": json.Number("1")}}, nil)
+		stream.Rest = func() (jsonmap.JsonMap, error) { return nil, errors.New("test error") }
+		return stream
+	}
+
+	for _, renderer := range []Renderer{&csvRenderer{outputFile: outputFile}, &jsonRenderer{outputFile: outputFile}} {
+		// Call the Method Under Test
+		err := renderer.RenderStream(context.Background(), newStream(), testStreamDescriptor)
+
+		assert.EqualError(t, err, "test error")
+	}
+
+	// Call the Method Under Test
+	_, err = newStream().Collect(context.Background())
+
+	assert.EqualError(t, err, "test error")
+}
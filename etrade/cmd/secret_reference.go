@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Prefixes for configuration values that refer to a secret kept elsewhere
+// rather than embedding the secret itself:
+//
+//	env:ETRADE_CONSUMER_SECRET          - the value of an environment variable
+//	file:/run/secrets/etrade            - the contents of a file
+//	cmd:pass show etrade/consumerSecret - the output of a command
+//
+// Values without one of these prefixes are used as is. Leading and trailing
+// whitespace is trimmed from file contents and command output.
+const (
+	secretReferenceEnvPrefix  = "env:"
+	secretReferenceFilePrefix = "file:"
+	secretReferenceCmdPrefix  = "cmd:"
+)
+
+// ResolveSecretReference returns the secret that a configuration value
+// refers to, or the value itself if it isn't a secret reference.
+func ResolveSecretReference(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, secretReferenceEnvPrefix):
+		name := strings.TrimPrefix(value, secretReferenceEnvPrefix)
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, secretReferenceFilePrefix):
+		fileName := strings.TrimPrefix(value, secretReferenceFilePrefix)
+		secret, err := os.ReadFile(fileName)
+		if err != nil {
+			return "", fmt.Errorf("unable to read secret file %s (%w)", fileName, err)
+		}
+		return strings.TrimSpace(string(secret)), nil
+	case strings.HasPrefix(value, secretReferenceCmdPrefix):
+		args := strings.Fields(strings.TrimPrefix(value, secretReferenceCmdPrefix))
+		secret, err := runSecretCommand(args, nil)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(secret)), nil
+	default:
+		return value, nil
+	}
+}
+
+// runSecretCommand runs a command, optionally with the given standard input,
+// and returns its standard output. The command is run directly rather than
+// through a shell.
+func runSecretCommand(args []string, stdin []byte) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("secret command not provided")
+	}
+	command := exec.Command(args[0], args[1:]...)
+	if stdin != nil {
+		command.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"secret command '%s' failed (%w): %s", args[0], err, strings.TrimSpace(stderr.String()),
+		)
+	}
+	return output, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveSecretReference(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	assert.Nil(t, os.WriteFile(secretFile, []byte("TestFileSecret\n"), 0600))
+	t.Setenv("ETRADE_TEST_SECRET", "TestEnvSecret")
+
+	tests := []struct {
+		name        string
+		value       string
+		expectErr   bool
+		expectValue string
+	}{
+		{
+			name:        "Plain Value Is Used As Is",
+			value:       "TestSecret",
+			expectErr:   false,
+			expectValue: "TestSecret",
+		},
+		{
+			name:        "Resolves Environment Variable",
+			value:       "env:ETRADE_TEST_SECRET",
+			expectErr:   false,
+			expectValue: "TestEnvSecret",
+		},
+		{
+			name:        "Fails With Unset Environment Variable",
+			value:       "env:ETRADE_TEST_UNSET_SECRET",
+			expectErr:   true,
+			expectValue: "",
+		},
+		{
+			name:        "Resolves File",
+			value:       "file:" + secretFile,
+			expectErr:   false,
+			expectValue: "TestFileSecret",
+		},
+		{
+			name:        "Fails With Missing File",
+			value:       "file:" + filepath.Join(t.TempDir(), "missing"),
+			expectErr:   true,
+			expectValue: "",
+		},
+		{
+			name:        "Resolves Command Output",
+			value:       "cmd:echo TestCommandSecret",
+			expectErr:   false,
+			expectValue: "TestCommandSecret",
+		},
+		{
+			name:        "Fails With Failed Command",
+			value:       "cmd:false",
+			expectErr:   true,
+			expectValue: "",
+		},
+		{
+			name:        "Fails With Empty Command",
+			value:       "cmd:",
+			expectErr:   true,
+			expectValue: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := ResolveSecretReference(tt.value)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
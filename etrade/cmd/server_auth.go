@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strings"
+)
+
+// Capabilities that a server API key may have for a customer.
+const (
+	// serverCapabilityMarket allows reading market data.
+	serverCapabilityMarket = "market"
+	// serverCapabilityAccounts allows reading accounts, orders, and alerts.
+	serverCapabilityAccounts = "accounts"
+	// serverCapabilityTrade allows changing the account.
+	serverCapabilityTrade = "trade"
+	// serverCapabilityAuth allows logging the customer in and out, which
+	// replaces or revokes the customer's stored access token.
+	serverCapabilityAuth = "auth"
+)
+
+var serverCapabilities = map[string]bool{
+	serverCapabilityMarket:   true,
+	serverCapabilityAccounts: true,
+	serverCapabilityTrade:    true,
+	serverCapabilityAuth:     true,
+}
+
+// serverKeyGrants maps each customer ID that a key may access to the set of
+// capabilities it has for that customer.
+type serverKeyGrants map[string]map[string]bool
+
+// serverAuthorizer checks the API keys that the server's clients send against
+// the keys in the customer configuration.
+type serverAuthorizer struct {
+	// grants is indexed by the SHA-256 hash of each key so that looking up a
+	// key doesn't reveal, through its timing, how much of a guess was right.
+	grants map[[sha256.Size]byte]serverKeyGrants
+}
+
+// newServerAuthorizer collects the API keys from every customer's
+// configuration, resolving any secret references.
+func newServerAuthorizer(cfgStore *CustomerConfigurationStore) (*serverAuthorizer, error) {
+	authorizer := &serverAuthorizer{grants: map[[sha256.Size]byte]serverKeyGrants{}}
+	for customerId, customerConfig := range cfgStore.GetAllConfigurations() {
+		for _, serverKey := range customerConfig.CustomerServerKeys {
+			key, err := ResolveSecretReference(serverKey.Key)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get a server key for customer '%s' (%w)", customerId, err)
+			}
+			if key == "" {
+				return nil, fmt.Errorf("customer '%s' has an empty server key", customerId)
+			}
+			hash := sha256.Sum256([]byte(key))
+			grants, ok := authorizer.grants[hash]
+			if !ok {
+				grants = serverKeyGrants{}
+				authorizer.grants[hash] = grants
+			}
+			if grants[customerId] == nil {
+				grants[customerId] = map[string]bool{}
+			}
+			for _, capability := range serverKey.Capabilities {
+				if !serverCapabilities[capability] {
+					return nil, fmt.Errorf(
+						"customer '%s' has a server key with unknown capability '%s'", customerId, capability,
+					)
+				}
+				grants[customerId][capability] = true
+			}
+		}
+	}
+	return authorizer, nil
+}
+
+// Enabled returns whether any API keys are configured. If none are, the
+// server doesn't require authentication.
+func (a *serverAuthorizer) Enabled() bool {
+	return len(a.grants) > 0
+}
+
+// GetGrants returns what a key may access, or false if the key isn't valid.
+func (a *serverAuthorizer) GetGrants(key string) (serverKeyGrants, bool) {
+	if key == "" {
+		return nil, false
+	}
+	grants, ok := a.grants[sha256.Sum256([]byte(key))]
+	return grants, ok
+}
+
+// getRequestKey returns the API key sent with a request, either as a bearer
+// token or in the X-API-Key header.
+func getRequestKey(r *http.Request) string {
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		scheme, token, found := strings.Cut(authorization, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return r.Header.Get("X-API-Key")
+}
+
+// AuthenticationCtx rejects requests that don't include a valid API key and
+// passes the key's grants on with the others. If authentication isn't
+// enabled, every request is passed on without grants.
+func (s *eTradeServer) AuthenticationCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !s.authorizer.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			grants, ok := s.authorizer.GetGrants(getRequestKey(r))
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				s.WriteError(
+					w, &serverError{
+						statusCode: http.StatusUnauthorized,
+						code:       serverErrorCodeInvalidApiKey,
+						err:        errors.New("missing or invalid API key"),
+					},
+				)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "serverKeyGrants", grants)))
+		},
+	)
+}
+
+// RequireCapability returns middleware that rejects requests whose API key
+// may not access the request's customer or doesn't have the given capability
+// for it. An empty capability requires only access to the customer.
+func (s *eTradeServer) RequireCapability(capability string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if !s.authorizer.Enabled() {
+					next.ServeHTTP(w, r)
+					return
+				}
+				grants, _ := r.Context().Value("serverKeyGrants").(serverKeyGrants)
+				customerId := chi.URLParam(r, "customerId")
+				capabilities, ok := grants[customerId]
+				if !ok {
+					s.WriteError(
+						w, &serverError{
+							statusCode: http.StatusForbidden,
+							code:       serverErrorCodeForbidden,
+							err:        fmt.Errorf("API key may not access customer '%s'", customerId),
+						},
+					)
+					return
+				}
+				if capability != "" && !capabilities[capability] {
+					s.WriteError(
+						w, &serverError{
+							statusCode: http.StatusForbidden,
+							code:       serverErrorCodeForbidden,
+							err: fmt.Errorf(
+								"API key does not have the '%s' capability for customer '%s'", capability, customerId,
+							),
+						},
+					)
+					return
+				}
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestETradeServer_Authentication(t *testing.T) {
+	serverUrl := newTestETradeServer(
+		t, []string{"alice", "bob"}, map[string][]CustomerServerKey{
+			"alice": {
+				{Key: "aliceReader", Capabilities: []string{"accounts", "market"}},
+				{Key: "marketOnly", Capabilities: []string{"market"}},
+			},
+			"bob": {
+				{Key: "bobTrader", Capabilities: []string{"accounts", "trade", "auth"}},
+				{Key: "marketOnly", Capabilities: []string{"market"}},
+			},
+		},
+	)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		header         string
+		headerValue    string
+		expectStatus   int
+		expectContains string
+	}{
+		{
+			name:           "Rejects Request Without Key",
+			method:         http.MethodGet,
+			path:           "/customers/alice/accounts",
+			expectStatus:   http.StatusUnauthorized,
+			expectContains: "missing or invalid API key",
+		},
+		{
+			name:           "Rejects Unknown Key",
+			method:         http.MethodGet,
+			path:           "/customers/alice/accounts",
+			header:         "Authorization",
+			headerValue:    "Bearer notAKey",
+			expectStatus:   http.StatusUnauthorized,
+			expectContains: "missing or invalid API key",
+		},
+		{
+			name:         "Rejects Key In Unsupported Authorization Scheme",
+			method:       http.MethodGet,
+			path:         "/customers/alice/accounts",
+			header:       "Authorization",
+			headerValue:  "Basic aliceReader",
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "Accepts Bearer Token",
+			method:       http.MethodGet,
+			path:         "/customers/alice/accounts",
+			header:       "Authorization",
+			headerValue:  "Bearer aliceReader",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "Accepts API Key Header",
+			method:       http.MethodGet,
+			path:         "/customers/alice/market/quote?symbol=GOOG",
+			header:       "X-API-Key",
+			headerValue:  "aliceReader",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:           "Rejects Key For Another Customer",
+			method:         http.MethodGet,
+			path:           "/customers/bob/accounts",
+			header:         "X-API-Key",
+			headerValue:    "aliceReader",
+			expectStatus:   http.StatusForbidden,
+			expectContains: "may not access customer 'bob'",
+		},
+		{
+			name:           "Rejects Unknown Customer Without Revealing It Doesn't Exist",
+			method:         http.MethodGet,
+			path:           "/customers/mallory/accounts",
+			header:         "X-API-Key",
+			headerValue:    "aliceReader",
+			expectStatus:   http.StatusForbidden,
+			expectContains: "may not access customer 'mallory'",
+		},
+		{
+			name:           "Rejects Key Without Capability",
+			method:         http.MethodGet,
+			path:           "/customers/alice/accounts",
+			header:         "X-API-Key",
+			headerValue:    "marketOnly",
+			expectStatus:   http.StatusForbidden,
+			expectContains: "does not have the 'accounts' capability",
+		},
+		{
+			name:         "Accepts Shared Key For Each Customer",
+			method:       http.MethodGet,
+			path:         "/customers/bob/market/quote?symbol=GOOG",
+			header:       "X-API-Key",
+			headerValue:  "marketOnly",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:           "Requires Trade Capability To Delete Alert",
+			method:         http.MethodDelete,
+			path:           "/customers/alice/alerts/1",
+			header:         "X-API-Key",
+			headerValue:    "aliceReader",
+			expectStatus:   http.StatusForbidden,
+			expectContains: "does not have the 'trade' capability",
+		},
+		{
+			name:         "Allows Any Key For Customer To Get Auth Status",
+			method:       http.MethodGet,
+			path:         "/customers/alice/auth",
+			header:       "X-API-Key",
+			headerValue:  "marketOnly",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:           "Requires Auth Capability To Log In",
+			method:         http.MethodPost,
+			path:           "/customers/alice/auth",
+			header:         "X-API-Key",
+			headerValue:    "aliceReader",
+			expectStatus:   http.StatusForbidden,
+			expectContains: "does not have the 'auth' capability",
+		},
+		{
+			name:           "Requires Auth Capability To Log Out",
+			method:         http.MethodDelete,
+			path:           "/customers/alice/auth",
+			header:         "X-API-Key",
+			headerValue:    "aliceReader",
+			expectStatus:   http.StatusForbidden,
+			expectContains: "does not have the 'auth' capability",
+		},
+		{
+			name:         "Allows Key With Auth Capability To Log In",
+			method:       http.MethodPost,
+			path:         "/customers/bob/auth",
+			header:       "X-API-Key",
+			headerValue:  "bobTrader",
+			expectStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				request, err := http.NewRequest(tt.method, serverUrl+tt.path, nil)
+				require.Nil(t, err)
+				if tt.header != "" {
+					request.Header.Set(tt.header, tt.headerValue)
+				}
+
+				// Call the Method Under Test
+				response, err := http.DefaultClient.Do(request)
+				require.Nil(t, err)
+				defer response.Body.Close()
+				body, err := io.ReadAll(response.Body)
+				require.Nil(t, err)
+
+				assert.Equal(t, tt.expectStatus, response.StatusCode, string(body))
+				assert.Equal(t, "application/json; charset=utf-8", response.Header.Get("Content-Type"))
+				if tt.expectStatus == http.StatusUnauthorized {
+					assert.Equal(t, "Bearer", response.Header.Get("WWW-Authenticate"))
+				}
+				assert.Contains(t, string(body), tt.expectContains)
+			},
+		)
+	}
+}
+
+func TestETradeServer_ListsOnlyCustomersKeyMayAccess(t *testing.T) {
+	serverUrl := newTestETradeServer(
+		t, []string{"alice", "bob"}, map[string][]CustomerServerKey{
+			"alice": {{Key: "aliceReader", Capabilities: []string{"accounts"}}},
+		},
+	)
+	request, err := http.NewRequest(http.MethodGet, serverUrl+"/customers", nil)
+	require.Nil(t, err)
+	request.Header.Set("Authorization", "Bearer aliceReader")
+
+	// Call the Method Under Test
+	response, err := http.DefaultClient.Do(request)
+	require.Nil(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var customerList struct {
+		Customers []struct {
+			CustomerId string `json:"customerId"`
+		} `json:"customers"`
+	}
+	require.Nil(t, json.NewDecoder(response.Body).Decode(&customerList))
+	require.Len(t, customerList.Customers, 1)
+	assert.Equal(t, "alice", customerList.Customers[0].CustomerId)
+}
+
+func TestNewServerAuthorizer(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverKeys    []CustomerServerKey
+		expectErr     string
+		expectEnabled bool
+	}{
+		{
+			name:          "Disabled Without Keys",
+			expectEnabled: false,
+		},
+		{
+			name:          "Enabled With Keys",
+			serverKeys:    []CustomerServerKey{{Key: "key", Capabilities: []string{"market"}}},
+			expectEnabled: true,
+		},
+		{
+			name:       "Fails With Unknown Capability",
+			serverKeys: []CustomerServerKey{{Key: "key", Capabilities: []string{"withdraw"}}},
+			expectErr:  "customer 'alice' has a server key with unknown capability 'withdraw'",
+		},
+		{
+			name:       "Fails With Empty Key",
+			serverKeys: []CustomerServerKey{{Key: "", Capabilities: []string{"market"}}},
+			expectErr:  "customer 'alice' has an empty server key",
+		},
+		{
+			name:       "Fails With Unresolvable Secret Reference",
+			serverKeys: []CustomerServerKey{{Key: "env:ETRADE_TEST_UNSET_SERVER_KEY", Capabilities: []string{"market"}}},
+			expectErr:  "unable to get a server key for customer 'alice'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfgStore, err := LoadCustomerConfigurationStore(strings.NewReader("{}"))
+				require.Nil(t, err)
+				cfgStore.SetCustomerConfigurationForId("alice", &CustomerConfiguration{CustomerServerKeys: tt.serverKeys})
+
+				// Call the Method Under Test
+				authorizer, err := newServerAuthorizer(cfgStore)
+				if tt.expectErr != "" {
+					assert.ErrorContains(t, err, tt.expectErr)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.expectEnabled, authorizer.Enabled())
+				}
+			},
+		)
+	}
+}
+
+func TestNewETradeServer_RequiresKeysUnlessInsecureNoAuth(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverKeys     []CustomerServerKey
+		insecureNoAuth bool
+		expectErr      string
+	}{
+		{
+			name:      "Fails Without Keys",
+			expectErr: "no server keys are configured",
+		},
+		{
+			name:           "Starts Without Keys When Insecure",
+			insecureNoAuth: true,
+		},
+		{
+			name:       "Starts With Keys",
+			serverKeys: []CustomerServerKey{{Key: "key", Capabilities: []string{"market"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfgStore, err := LoadCustomerConfigurationStore(strings.NewReader("{}"))
+				require.Nil(t, err)
+				cfgStore.SetCustomerConfigurationForId("alice", &CustomerConfiguration{CustomerServerKeys: tt.serverKeys})
+
+				// Call the Method Under Test
+				_, err = NewETradeServer(
+					"", etradelibtest.CreateNullLogger(), NewConfigurationFolder(t.TempDir()), cfgStore,
+					tt.insecureNoAuth,
+				)
+				if tt.expectErr != "" {
+					assert.ErrorContains(t, err, tt.expectErr)
+				} else {
+					assert.Nil(t, err)
+				}
+			},
+		)
+	}
+}
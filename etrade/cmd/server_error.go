@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"net/http"
+	"net/url"
+)
+
+// The codes in the server's error responses, which let clients act on an
+// error without parsing its message.
+const (
+	// serverErrorCodeInvalidApiKey means the request has no API key or one
+	// that the server doesn't know.
+	serverErrorCodeInvalidApiKey = "invalidApiKey"
+	// serverErrorCodeForbidden means the API key may not make the request.
+	serverErrorCodeForbidden = "forbidden"
+	// serverErrorCodeLoginRequired means ETrade rejected the customer's
+	// credentials, or there are none, so the customer must log in again.
+	serverErrorCodeLoginRequired = "loginRequired"
+	// serverErrorCodeInvalidRequest means a parameter or the request body is
+	// invalid.
+	serverErrorCodeInvalidRequest = "invalidRequest"
+	// serverErrorCodeRiskLimitExceeded means an order was rejected by the
+	// customer's risk limits before it was sent to ETrade.
+	serverErrorCodeRiskLimitExceeded = "riskLimitExceeded"
+	// serverErrorCodeCustomerNotFound means the customer isn't in the
+	// configuration file.
+	serverErrorCodeCustomerNotFound = "customerNotFound"
+	// serverErrorCodeAccountNotFound means the account isn't one of the
+	// customer's accounts.
+	serverErrorCodeAccountNotFound = "accountNotFound"
+	// serverErrorCodeOrderNotFound means the order isn't one of the account's
+	// open orders.
+	serverErrorCodeOrderNotFound = "orderNotFound"
+	// serverErrorCodeUpstreamError means ETrade responded with an error or
+	// couldn't be reached.
+	serverErrorCodeUpstreamError = "upstreamError"
+	// serverErrorCodeInternalError means the server failed.
+	serverErrorCodeInternalError = "internalError"
+)
+
+// serverErrorCodes lists every error code, for the OpenAPI document.
+var serverErrorCodes = []string{
+	serverErrorCodeInvalidApiKey,
+	serverErrorCodeForbidden,
+	serverErrorCodeLoginRequired,
+	serverErrorCodeInvalidRequest,
+	serverErrorCodeRiskLimitExceeded,
+	serverErrorCodeCustomerNotFound,
+	serverErrorCodeAccountNotFound,
+	serverErrorCodeOrderNotFound,
+	serverErrorCodeUpstreamError,
+	serverErrorCodeInternalError,
+}
+
+// serverError is an error that the server responds to with a particular HTTP
+// status and error code.
+type serverError struct {
+	statusCode int
+	code       string
+	err        error
+}
+
+func (e *serverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *serverError) Unwrap() error {
+	return e.err
+}
+
+// newInvalidRequestError marks an error in a request's parameters or body.
+func newInvalidRequestError(err error) error {
+	return &serverError{statusCode: http.StatusBadRequest, code: serverErrorCodeInvalidRequest, err: err}
+}
+
+// classifyServerError returns the HTTP status and error code with which to
+// respond to an error.
+func classifyServerError(err error) (int, string) {
+	var serverErr *serverError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &serverErr):
+		return serverErr.statusCode, serverErr.code
+	case client.IsAuthFailed(err):
+		return http.StatusUnauthorized, serverErrorCodeLoginRequired
+	case client.IsRiskLimitExceeded(err):
+		return http.StatusBadRequest, serverErrorCodeRiskLimitExceeded
+	case client.IsInvalidOrder(err), client.IsInvalidArgument(err):
+		return http.StatusBadRequest, serverErrorCodeInvalidRequest
+	case errors.Is(err, ErrCustomerNotFound):
+		return http.StatusNotFound, serverErrorCodeCustomerNotFound
+	case errors.Is(err, ErrAccountNotFound):
+		return http.StatusNotFound, serverErrorCodeAccountNotFound
+	case errors.Is(err, ErrOrderNotFound):
+		return http.StatusNotFound, serverErrorCodeOrderNotFound
+	case errors.As(err, new(*client.ETradeAPIError)), errors.As(err, &urlErr):
+		return http.StatusBadGateway, serverErrorCodeUpstreamError
+	default:
+		return http.StatusInternalServerError, serverErrorCodeInternalError
+	}
+}
+
+// newServerErrorJsonMap returns the body of the server's response to an error.
+func newServerErrorJsonMap(code string, err error) jsonmap.JsonMap {
+	errorMap := jsonmap.JsonMap{
+		"status":  "error",
+		"code":    code,
+		"message": err.Error(),
+	}
+	if apiError, ok := client.AsETradeAPIError(err); ok {
+		errorMap["upstreamStatus"] = apiError.StatusCode
+		errorMap["etradeError"] = eTradeAPIErrorAsJsonMap(apiError)
+	}
+	return errorMap
+}
+
+// eTradeAPIErrorAsJsonMap returns the details of an error response from
+// ETrade so that they can be passed through to the server's client.
+func eTradeAPIErrorAsJsonMap(apiError *client.ETradeAPIError) jsonmap.JsonMap {
+	errorMap := jsonmap.JsonMap{
+		"httpStatus": apiError.StatusCode,
+		"body":       string(apiError.Body),
+	}
+	if apiError.Code != 0 {
+		errorMap["code"] = apiError.Code
+	}
+	if apiError.Message != "" {
+		errorMap["message"] = apiError.Message
+	}
+	return errorMap
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClassifyServerError(t *testing.T) {
+	tests := []struct {
+		name             string
+		err              error
+		expectStatusCode int
+		expectErrorMap   jsonmap.JsonMap
+	}{
+		{
+			name:             "Invalid Request",
+			err:              newInvalidRequestError(errors.New("missing symbol")),
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorMap:   jsonmap.JsonMap{"status": "error", "code": "invalidRequest", "message": "missing symbol"},
+		},
+		{
+			name:             "ETrade Auth Failure",
+			err:              fmt.Errorf("wrapped (%w)", &client.ETradeAPIError{StatusCode: 401, Body: []byte("denied")}),
+			expectStatusCode: http.StatusUnauthorized,
+			expectErrorMap: jsonmap.JsonMap{
+				"status":         "error",
+				"code":           "loginRequired",
+				"message":        "wrapped (authentication failed)",
+				"upstreamStatus": 401,
+				"etradeError":    jsonmap.JsonMap{"httpStatus": 401, "body": "denied"},
+			},
+		},
+		{
+			name:             "Local Auth Failure",
+			err:              fmt.Errorf("%w: customer 'alice' is not logged in", client.ErrETradeAuthFailed),
+			expectStatusCode: http.StatusUnauthorized,
+			expectErrorMap: jsonmap.JsonMap{
+				"status":  "error",
+				"code":    "loginRequired",
+				"message": "authentication failed: customer 'alice' is not logged in",
+			},
+		},
+		{
+			name:             "Risk Limit Exceeded",
+			err:              fmt.Errorf("%w: trading is disabled for this customer", client.ErrRiskLimitExceeded),
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorMap: jsonmap.JsonMap{
+				"status":  "error",
+				"code":    "riskLimitExceeded",
+				"message": "order rejected by risk limits: trading is disabled for this customer",
+			},
+		},
+		{
+			name:             "Customer Not Found",
+			err:              ErrCustomerNotFound,
+			expectStatusCode: http.StatusNotFound,
+			expectErrorMap:   jsonmap.JsonMap{"status": "error", "code": "customerNotFound", "message": "customer not found"},
+		},
+		{
+			name:             "Invalid Order",
+			err:              (&client.OrderRequest{}).Validate(),
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorMap: jsonmap.JsonMap{
+				"status": "error", "code": "invalidRequest", "message": "clientOrderId not provided",
+			},
+		},
+		{
+			name:             "Invalid Argument",
+			err:              fmt.Errorf("%w: no symbols provided", client.ErrInvalidArgument),
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorMap: jsonmap.JsonMap{
+				"status": "error", "code": "invalidRequest", "message": "invalid argument: no symbols provided",
+			},
+		},
+		{
+			name:             "Account Not Found",
+			err:              fmt.Errorf("%w: no account with id 1234", ErrAccountNotFound),
+			expectStatusCode: http.StatusNotFound,
+			expectErrorMap: jsonmap.JsonMap{
+				"status": "error", "code": "accountNotFound", "message": "account not found: no account with id 1234",
+			},
+		},
+		{
+			name:             "Order Not Found",
+			err:              fmt.Errorf("%w: no open order with ID 1234", ErrOrderNotFound),
+			expectStatusCode: http.StatusNotFound,
+			expectErrorMap: jsonmap.JsonMap{
+				"status": "error", "code": "orderNotFound", "message": "order not found: no open order with ID 1234",
+			},
+		},
+		{
+			name: "ETrade Error Response",
+			err: &client.ETradeAPIError{
+				StatusCode: 400, Status: "400 Bad Request", Code: 100, Message: "bad order", Body: []byte("body"),
+			},
+			expectStatusCode: http.StatusBadGateway,
+			expectErrorMap: jsonmap.JsonMap{
+				"status":         "error",
+				"code":           "upstreamError",
+				"message":        "request failed: 400 Bad Request (ETrade error 100: bad order)",
+				"upstreamStatus": 400,
+				"etradeError": jsonmap.JsonMap{
+					"httpStatus": 400, "code": 100, "message": "bad order", "body": "body",
+				},
+			},
+		},
+		{
+			name:             "ETrade Unreachable",
+			err:              &url.Error{Op: "Get", URL: "https://api.etrade.com", Err: errors.New("connection refused")},
+			expectStatusCode: http.StatusBadGateway,
+			expectErrorMap: jsonmap.JsonMap{
+				"status":  "error",
+				"code":    "upstreamError",
+				"message": `Get "https://api.etrade.com": connection refused`,
+			},
+		},
+		{
+			name:             "Other Error",
+			err:              errors.New("test error"),
+			expectStatusCode: http.StatusInternalServerError,
+			expectErrorMap:   jsonmap.JsonMap{"status": "error", "code": "internalError", "message": "test error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				statusCode, code := classifyServerError(tt.err)
+
+				assert.Equal(t, tt.expectStatusCode, statusCode)
+				assert.Equal(t, tt.expectErrorMap, newServerErrorJsonMap(code, tt.err))
+			},
+		)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+// defaultUnixSocketMode allows only the server's user to connect to its Unix
+// socket.
+const defaultUnixSocketMode = "0600"
+
+// newServerListener listens on a Unix socket if unixSocketPath isn't empty, or
+// on the TCP address addr otherwise. A Unix socket's file is given the mode
+// socketMode (an octal string such as "0660"), and is removed when the
+// listener is closed.
+func newServerListener(addr string, unixSocketPath string, socketMode string) (net.Listener, error) {
+	if unixSocketPath == "" {
+		return net.Listen("tcp", addr)
+	}
+	mode, err := strconv.ParseUint(socketMode, 8, 32)
+	if err != nil || os.FileMode(mode)&^os.ModePerm != 0 {
+		return nil, fmt.Errorf("%s is not a valid file mode", socketMode)
+	}
+	if err = removeStaleSocket(unixSocketPath); err != nil {
+		return nil, err
+	}
+	// Create the socket in a directory that only this user can enter, and
+	// link it into place only once it has its mode, so that no other user can
+	// connect to it in the meantime.
+	privateDir, err := os.MkdirTemp(filepath.Dir(unixSocketPath), ".etrade-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a directory for socket %s (%w)", unixSocketPath, err)
+	}
+	defer func() { _ = os.RemoveAll(privateDir) }()
+	privatePath := filepath.Join(privateDir, "socket")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: privatePath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The socket is removed from its final path when the listener is closed.
+	listener.SetUnlinkOnClose(false)
+	if err = os.Chmod(privatePath, os.FileMode(mode)); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("unable to set the mode of socket %s (%w)", unixSocketPath, err)
+	}
+	// Link, rather than rename, so that whatever is already at the path is
+	// left alone.
+	if err = os.Link(privatePath, unixSocketPath); err != nil {
+		_ = listener.Close()
+		if errors.Is(err, os.ErrExist) {
+			err = syscall.EADDRINUSE
+		}
+		return nil, fmt.Errorf("unable to move socket into place at %s (%w)", unixSocketPath, err)
+	}
+	return &unixSocketListener{UnixListener: listener, path: unixSocketPath}, nil
+}
+
+// unixSocketListener is a Unix socket listener that removes its socket file
+// when it is closed.
+type unixSocketListener struct {
+	*net.UnixListener
+	path string
+}
+
+func (l *unixSocketListener) Close() error {
+	err := l.UnixListener.Close()
+	if removeErr := os.Remove(l.path); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// removeStaleSocket removes a socket left behind by a server that didn't shut
+// down cleanly. It doesn't remove anything that isn't a socket, and fails
+// rather than remove a socket that another server is still listening on.
+func removeStaleSocket(unixSocketPath string) error {
+	fileInfo, err := os.Lstat(unixSocketPath)
+	if err != nil || fileInfo.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	conn, err := net.Dial("unix", unixSocketPath)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("another server is already listening on socket %s", unixSocketPath)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("unable to check whether socket %s is stale (%w)", unixSocketPath, err)
+	}
+	if err = os.Remove(unixSocketPath); err != nil {
+		return fmt.Errorf("unable to remove stale socket %s (%w)", unixSocketPath, err)
+	}
+	return nil
+}
+
+// newServerTlsConfig returns the TLS configuration for a server with the given
+// certificate and key files, or nil if neither is given. If clientCaFile isn't
+// empty, clients must present a certificate signed by one of the CAs in it.
+func newServerTlsConfig(certFile string, keyFile string, clientCaFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCaFile != "" {
+			return nil, errors.New("a client CA requires a TLS certificate and key")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("a TLS certificate and key must be given together")
+	}
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load TLS certificate and key (%w)", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCaFile != "" {
+		caBytes, err := os.ReadFile(clientCaFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client CA file %s (%w)", clientCaFile, err)
+		}
+		clientCas := x509.NewCertPool()
+		if !clientCas.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", clientCaFile)
+		}
+		tlsConfig.ClientCAs = clientCas
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
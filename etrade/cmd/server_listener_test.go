@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCertificate is a certificate and key generated for a test.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPem     []byte
+	keyPem      []byte
+}
+
+// newTestCertificate creates a certificate signed by parent, or a self-signed
+// CA certificate if parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signerCertificate, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signerCertificate, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCertificate, &key.PublicKey, signerKey)
+	require.Nil(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPem:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPem:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+// writeTestFile writes a file in dir and returns its path.
+func writeTestFile(t *testing.T, dir string, name string, contents []byte) string {
+	path := filepath.Join(dir, name)
+	require.Nil(t, os.WriteFile(path, contents, 0600))
+	return path
+}
+
+func TestNewServerListener_UnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "etrade.sock")
+	// Leave a stale socket behind, as a server that didn't shut down cleanly
+	// would.
+	staleListener, err := net.Listen("unix", socketPath)
+	require.Nil(t, err)
+	staleListener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.Nil(t, staleListener.Close())
+
+	// Call the Method Under Test
+	listener, err := newServerListener(":0", socketPath, "0660")
+	require.Nil(t, err)
+
+	fileInfo, err := os.Stat(socketPath)
+	require.Nil(t, err)
+	assert.Equal(t, os.FileMode(0660), fileInfo.Mode().Perm())
+	// The private directory in which the socket was created is gone.
+	entries, err := os.ReadDir(filepath.Dir(socketPath))
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "etrade.sock", entries[0].Name())
+
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	)
+	server.Listener = listener
+	server.Start()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	response, err := httpClient.Get("http://unix/customers")
+	require.Nil(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	server.Close()
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNewServerListener_DoesNotRemoveLiveSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "etrade.sock")
+	liveListener, err := net.Listen("unix", socketPath)
+	require.Nil(t, err)
+	defer liveListener.Close()
+
+	// Call the Method Under Test
+	_, err = newServerListener(":0", socketPath, "0600")
+
+	assert.ErrorContains(t, err, "another server is already listening on socket "+socketPath)
+	conn, err := net.Dial("unix", socketPath)
+	require.Nil(t, err)
+	_ = conn.Close()
+}
+
+func TestNewServerListener_Fails(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		setupFn   func(t *testing.T, socketPath string)
+		expectErr string
+	}{
+		{
+			name:      "With Invalid Mode",
+			mode:      "0999",
+			expectErr: "0999 is not a valid file mode",
+		},
+		{
+			name:      "With Mode Outside Permission Bits",
+			mode:      "10600",
+			expectErr: "10600 is not a valid file mode",
+		},
+		{
+			name: "Without Removing A File That Isn't A Socket",
+			mode: "0600",
+			setupFn: func(t *testing.T, socketPath string) {
+				require.Nil(t, os.WriteFile(socketPath, []byte("keep me"), 0600))
+			},
+			expectErr: "address already in use",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				socketPath := filepath.Join(t.TempDir(), "etrade.sock")
+				if tt.setupFn != nil {
+					tt.setupFn(t, socketPath)
+				}
+
+				// Call the Method Under Test
+				_, err := newServerListener(":0", socketPath, tt.mode)
+
+				assert.ErrorContains(t, err, tt.expectErr)
+				if tt.setupFn != nil {
+					contents, err := os.ReadFile(socketPath)
+					assert.Nil(t, err)
+					assert.Equal(t, "keep me", string(contents))
+				}
+			},
+		)
+	}
+}
+
+func TestNewServerTlsConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "Test CA", nil)
+	serverCertificate := newTestCertificate(t, "server", ca)
+	certFile := writeTestFile(t, dir, "server.crt", serverCertificate.certPem)
+	keyFile := writeTestFile(t, dir, "server.key", serverCertificate.keyPem)
+	caFile := writeTestFile(t, dir, "ca.crt", ca.certPem)
+	emptyFile := writeTestFile(t, dir, "empty.crt", []byte{})
+
+	tests := []struct {
+		name             string
+		certFile         string
+		keyFile          string
+		clientCaFile     string
+		expectErr        string
+		expectNil        bool
+		expectClientAuth tls.ClientAuthType
+	}{
+		{
+			name:      "Returns Nil Without Certificate",
+			expectNil: true,
+		},
+		{
+			name:             "Loads Certificate",
+			certFile:         certFile,
+			keyFile:          keyFile,
+			expectClientAuth: tls.NoClientCert,
+		},
+		{
+			name:             "Requires Client Certificate With Client CA",
+			certFile:         certFile,
+			keyFile:          keyFile,
+			clientCaFile:     caFile,
+			expectClientAuth: tls.RequireAndVerifyClientCert,
+		},
+		{
+			name:      "Fails With Certificate But No Key",
+			certFile:  certFile,
+			expectErr: "a TLS certificate and key must be given together",
+		},
+		{
+			name:         "Fails With Client CA But No Certificate",
+			clientCaFile: caFile,
+			expectErr:    "a client CA requires a TLS certificate and key",
+		},
+		{
+			name:      "Fails With Mismatched Files",
+			certFile:  keyFile,
+			keyFile:   certFile,
+			expectErr: "unable to load TLS certificate and key",
+		},
+		{
+			name:         "Fails With Empty Client CA",
+			certFile:     certFile,
+			keyFile:      keyFile,
+			clientCaFile: emptyFile,
+			expectErr:    "no certificates found in client CA file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				tlsConfig, err := newServerTlsConfig(tt.certFile, tt.keyFile, tt.clientCaFile)
+				if tt.expectErr != "" {
+					assert.ErrorContains(t, err, tt.expectErr)
+					return
+				}
+				require.Nil(t, err)
+				if tt.expectNil {
+					assert.Nil(t, tlsConfig)
+					return
+				}
+				assert.Len(t, tlsConfig.Certificates, 1)
+				assert.Equal(t, tt.expectClientAuth, tlsConfig.ClientAuth)
+			},
+		)
+	}
+}
+
+func TestNewServerTlsConfig_MutualTls(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "Test CA", nil)
+	serverCertificate := newTestCertificate(t, "server", ca)
+	clientCertificate := newTestCertificate(t, "client", ca)
+	untrustedCertificate := newTestCertificate(t, "untrusted", newTestCertificate(t, "Other CA", nil))
+	tlsConfig, err := newServerTlsConfig(
+		writeTestFile(t, dir, "server.crt", serverCertificate.certPem),
+		writeTestFile(t, dir, "server.key", serverCertificate.keyPem),
+		writeTestFile(t, dir, "ca.crt", ca.certPem),
+	)
+	require.Nil(t, err)
+
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }),
+	)
+	server.TLS = tlsConfig
+	// Don't log the handshakes that are expected to fail.
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	rootCas := x509.NewCertPool()
+	rootCas.AddCert(ca.certificate)
+	tests := []struct {
+		name              string
+		clientCertificate *testCertificate
+		expectErr         bool
+	}{
+		{
+			name:              "Accepts Client With Trusted Certificate",
+			clientCertificate: clientCertificate,
+		},
+		{
+			name:      "Rejects Client Without Certificate",
+			expectErr: true,
+		},
+		{
+			name:              "Rejects Client With Untrusted Certificate",
+			clientCertificate: untrustedCertificate,
+			expectErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				clientTlsConfig := &tls.Config{RootCAs: rootCas}
+				if tt.clientCertificate != nil {
+					certificate, err := tls.X509KeyPair(tt.clientCertificate.certPem, tt.clientCertificate.keyPem)
+					require.Nil(t, err)
+					clientTlsConfig.Certificates = []tls.Certificate{certificate}
+				}
+				httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTlsConfig}}
+
+				// Call the Method Under Test
+				response, err := httpClient.Get(server.URL)
+				if tt.expectErr {
+					assert.Error(t, err)
+					return
+				}
+				require.Nil(t, err)
+				_ = response.Body.Close()
+				assert.Equal(t, http.StatusNoContent, response.StatusCode)
+			},
+		)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
+)
+
+// maxServerRequestBodySize bounds the size of a request body. Orders are a
+// few hundred bytes, so this is generous.
+const maxServerRequestBodySize = 64 * 1024
+
+// serverOrderRequest is the JSON body of a request to preview or place an
+// order. It has the same layout as an order in an order file, plus the
+// preview ID when placing an order. The account ID may be left out, since
+// it is part of the request's URL.
+type serverOrderRequest struct {
+	orderFileOrder `yaml:",inline"`
+	PreviewId      int64 `yaml:"previewId"`
+}
+
+// serverOrderChanges is the JSON body of a request to change an order.
+// Fields that are left out aren't changed.
+type serverOrderChanges struct {
+	LimitPrice  *decimal.Decimal `yaml:"limitPrice"`
+	StopPrice   *decimal.Decimal `yaml:"stopPrice"`
+	Quantity    *int             `yaml:"quantity"`
+	OrderTerm   string           `yaml:"orderTerm"`
+	PreviewOnly bool             `yaml:"previewOnly"`
+}
+
+// readServerOrderRequest reads an order for the given account from a request
+// body. If forPlacement is set, the order must include the preview ID and
+// client order ID that it was previewed with.
+func readServerOrderRequest(reader io.Reader, accountId string, forPlacement bool) (
+	*client.OrderRequest, int64, error,
+) {
+	var request serverOrderRequest
+	if err := decodeServerRequestBody(reader, &request); err != nil {
+		return nil, 0, err
+	}
+	if request.AccountId == "" {
+		request.AccountId = accountId
+	} else if request.AccountId != accountId {
+		return nil, 0, errors.New("accountId in the request body does not match the URL")
+	}
+	if forPlacement {
+		if request.PreviewId == 0 {
+			return nil, 0, errors.New("previewId is required to place an order")
+		}
+		// The client order ID is generated when it's missing, but placing an
+		// order requires the one that it was previewed with.
+		if request.ClientOrderId == "" {
+			return nil, 0, errors.New("clientOrderId is required to place an order")
+		}
+	}
+	submission, err := request.submission()
+	if err != nil {
+		return nil, 0, err
+	}
+	return submission.Order, request.PreviewId, nil
+}
+
+// readServerOrderChanges reads the changes to make to an order, and whether
+// only to preview them, from a request body.
+func readServerOrderChanges(reader io.Reader) (*OrderChanges, bool, error) {
+	var request serverOrderChanges
+	if err := decodeServerRequestBody(reader, &request); err != nil {
+		return nil, false, err
+	}
+	changes := &OrderChanges{
+		LimitPrice: request.LimitPrice,
+		StopPrice:  request.StopPrice,
+		Quantity:   request.Quantity,
+		OrderTerm:  constants.OrderTermNil,
+	}
+	if request.OrderTerm != "" {
+		orderTerm, err := orderTermMap.GetEnumValue(request.OrderTerm)
+		if err != nil {
+			return nil, false, fmt.Errorf("unknown orderTerm '%s'", request.OrderTerm)
+		}
+		changes.OrderTerm = orderTerm
+	}
+	return changes, request.PreviewOnly, nil
+}
+
+// decodeServerRequestBody decodes a JSON request body. The body is decoded
+// as YAML, of which JSON is a subset, so that it is parsed exactly like an
+// order file. Bodies larger than maxServerRequestBodySize are rejected.
+func decodeServerRequestBody(reader io.Reader, value interface{}) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, io.NopCloser(reader), maxServerRequestBodySize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return fmt.Errorf("request body is larger than %d bytes", maxBytesError.Limit)
+		}
+		return fmt.Errorf("unable to read request body: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(body))
+	// Reject unknown fields so that a misspelled field (e.g. "limitprice")
+	// fails loudly rather than being silently dropped from an order.
+	decoder.KnownFields(true)
+	if err = decoder.Decode(value); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("request body is empty")
+		}
+		return fmt.Errorf("unable to parse request body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestReadServerOrderRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		forPlacement    bool
+		expectErr       string
+		expectPreviewId int64
+	}{
+		{
+			name:         "Reads Order For Preview",
+			body:         `{"orderType": "equity", "limitPrice": 170, "symbol": "AAPL", "action": "buy", "quantity": 5}`,
+			forPlacement: false,
+		},
+		{
+			name: "Reads Order For Placement",
+			body: `{"clientOrderId": "abc", "previewId": 123, "orderType": "equity", "limitPrice": 170, ` +
+				`"symbol": "AAPL", "action": "buy", "quantity": 5}`,
+			forPlacement:    true,
+			expectPreviewId: 123,
+		},
+		{
+			name: "Accepts Matching Account ID",
+			body: `{"accountId": "84910001", "orderType": "equity", "limitPrice": 170, "symbol": "AAPL", ` +
+				`"action": "buy", "quantity": 5}`,
+		},
+		{
+			name: "Fails With Mismatched Account ID",
+			body: `{"accountId": "84910002", "orderType": "equity", "limitPrice": 170, "symbol": "AAPL", ` +
+				`"action": "buy", "quantity": 5}`,
+			expectErr: "accountId in the request body does not match the URL",
+		},
+		{
+			name: "Fails Placement Without Preview ID",
+			body: `{"clientOrderId": "abc", "orderType": "equity", "limitPrice": 170, "symbol": "AAPL", ` +
+				`"action": "buy", "quantity": 5}`,
+			forPlacement: true,
+			expectErr:    "previewId is required to place an order",
+		},
+		{
+			name: "Fails Placement Without Client Order ID",
+			body: `{"previewId": 123, "orderType": "equity", "limitPrice": 170, "symbol": "AAPL", ` +
+				`"action": "buy", "quantity": 5}`,
+			forPlacement: true,
+			expectErr:    "clientOrderId is required to place an order",
+		},
+		{
+			name:      "Fails With Unknown Field",
+			body:      `{"orderType": "equity", "limitprice": 170, "symbol": "AAPL", "action": "buy", "quantity": 5}`,
+			expectErr: "unable to parse request body",
+		},
+		{
+			name:      "Fails With Invalid Order",
+			body:      `{"orderType": "bond", "limitPrice": 170, "symbol": "AAPL", "action": "buy", "quantity": 5}`,
+			expectErr: "unknown orderType 'bond'",
+		},
+		{
+			name:      "Fails With Empty Body",
+			body:      "",
+			expectErr: "request body is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				order, previewId, err := readServerOrderRequest(strings.NewReader(tt.body), "84910001", tt.forPlacement)
+				if tt.expectErr != "" {
+					assert.ErrorContains(t, err, tt.expectErr)
+					return
+				}
+				require.Nil(t, err)
+				assert.Equal(t, tt.expectPreviewId, previewId)
+				assert.Equal(t, constants.OrderTypeEquity, order.OrderType)
+				assert.Equal(t, "AAPL", order.Instruments[0].Symbol)
+			},
+		)
+	}
+}
+
+func TestReadServerOrderChanges(t *testing.T) {
+	limitPrice := decimal.NewFromInt(171)
+	quantity := 10
+	tests := []struct {
+		name              string
+		body              string
+		expectErr         string
+		expectChanges     *OrderChanges
+		expectPreviewOnly bool
+	}{
+		{
+			name: "Reads Changes",
+			body: `{"limitPrice": 171, "quantity": 10, "orderTerm": "goodUntilCancel", "previewOnly": true}`,
+			expectChanges: &OrderChanges{
+				LimitPrice: &limitPrice,
+				Quantity:   &quantity,
+				OrderTerm:  constants.OrderTermGoodUntilCancel,
+			},
+			expectPreviewOnly: true,
+		},
+		{
+			name:          "Leaves Out Missing Changes",
+			body:          `{}`,
+			expectChanges: &OrderChanges{OrderTerm: constants.OrderTermNil},
+		},
+		{
+			name:      "Fails With Unknown Order Term",
+			body:      `{"orderTerm": "forever"}`,
+			expectErr: "unknown orderTerm 'forever'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				changes, previewOnly, err := readServerOrderChanges(strings.NewReader(tt.body))
+				if tt.expectErr != "" {
+					assert.ErrorContains(t, err, tt.expectErr)
+					return
+				}
+				require.Nil(t, err)
+				assert.Equal(t, tt.expectChanges, changes)
+				assert.Equal(t, tt.expectPreviewOnly, previewOnly)
+			},
+		)
+	}
+}
+
+func TestDecodeServerRequestBody_RejectsLargeBody(t *testing.T) {
+	body := `{"symbol": "` + strings.Repeat("A", maxServerRequestBodySize) + `"}`
+	var request serverOrderRequest
+
+	// Call the Method Under Test
+	err := decodeServerRequestBody(strings.NewReader(body), &request)
+
+	assert.ErrorContains(t, err, "request body is larger than 65536 bytes")
+}
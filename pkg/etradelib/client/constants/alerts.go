@@ -65,3 +65,15 @@ func (e AlertStatus) String() string {
 	}
 	return "UNKNOWN"
 }
+
+// AlertCategoryFromString converts a string representation of an
+// AlertCategory to its enum value.
+func AlertCategoryFromString(s string) (AlertCategory, error) {
+	return enumFromString(alertCategoryToString, s)
+}
+
+// AlertStatusFromString converts a string representation of an AlertStatus
+// to its enum value.
+func AlertStatusFromString(s string) (AlertStatus, error) {
+	return enumFromString(alertStatusToString, s)
+}
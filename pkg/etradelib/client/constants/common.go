@@ -1,5 +1,7 @@
 package constants
 
+import "fmt"
+
 type SortOrder int
 
 const (
@@ -51,3 +53,20 @@ func (e *MarketSession) String() string {
 	}
 	return "UNKNOWN"
 }
+
+// MarketSessionFromString converts a string representation of a
+// MarketSession to its enum value.
+func MarketSessionFromString(s string) (MarketSession, error) {
+	return enumFromString(marketSessionToString, s)
+}
+
+// enumFromString looks up an enum value by its string representation.
+func enumFromString[T comparable](toStringMap map[T]string, s string) (T, error) {
+	for e, eString := range toStringMap {
+		if eString == s {
+			return e, nil
+		}
+	}
+	var nilValue T
+	return nilValue, fmt.Errorf("unknown value '%s'", s)
+}
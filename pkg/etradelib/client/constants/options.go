@@ -88,6 +88,21 @@ const (
 	OptionExpiryTypeMonthEnd
 )
 
+// OptionCallPut specifies whether an option is a call or a put.
+// See the constants below for semantics.
+type OptionCallPut int
+
+const (
+	// OptionCallPutNil indicates no option type
+	OptionCallPutNil OptionCallPut = iota
+
+	// OptionCallPutCall is a call option
+	OptionCallPutCall
+
+	// OptionCallPutPut is a put option
+	OptionCallPutPut
+)
+
 var optionCategoryToString = map[OptionCategory]string{
 	OptionCategoryStandard: "STANDARD",
 	OptionCategoryAll:      "ALL",
@@ -147,3 +162,28 @@ func (e OptionExpiryType) String() string {
 	}
 	return "UNKNOWN"
 }
+
+var optionCallPutToString = map[OptionCallPut]string{
+	OptionCallPutCall: "CALL",
+	OptionCallPutPut:  "PUT",
+}
+
+// String converts an OptionCallPut to its string representation.
+func (e OptionCallPut) String() string {
+	if s, found := optionCallPutToString[e]; found {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+// OptionCallPutFromString converts a string representation of an
+// OptionCallPut to its enum value.
+func OptionCallPutFromString(s string) (OptionCallPut, error) {
+	return enumFromString(optionCallPutToString, s)
+}
+
+// OptionExpiryTypeFromString converts a string representation of an
+// OptionExpiryType to its enum value.
+func OptionExpiryTypeFromString(s string) (OptionExpiryType, error) {
+	return enumFromString(expiryTypeToString, s)
+}
@@ -8,6 +8,10 @@ const OrdersMaxCount = 100
 // in a List Orders request
 const ListOrdersMaxSymbols = 25
 
+// ClientOrderIdMaxLength is the maximum length of the client order ID that
+// is included in Preview Order and Place Order requests.
+const ClientOrderIdMaxLength = 20
+
 // OrderStatus specifies the status of orders to retrieve.
 // See the constants below for semantics.
 type OrderStatus int
@@ -138,3 +142,234 @@ func (e OrderTransactionType) String() string {
 	}
 	return "UNKNOWN"
 }
+
+// OrderType specifies the type of order to preview or place.
+// See the constants below for semantics.
+type OrderType int
+
+const (
+	// OrderTypeNil indicates no order type
+	OrderTypeNil OrderType = iota
+
+	// OrderTypeEquity is an order for equities
+	OrderTypeEquity
+
+	// OrderTypeOption is a single-leg option order
+	OrderTypeOption
+
+	// OrderTypeSpreads is a multi-leg option spread order (e.g. a vertical,
+	// calendar, straddle, or strangle)
+	OrderTypeSpreads
+
+	// OrderTypeBuyWrites is a covered call order that buys an equity and
+	// writes a call against it
+	OrderTypeBuyWrites
+
+	// OrderTypeButterfly is a three-strike butterfly order
+	OrderTypeButterfly
+
+	// OrderTypeIronButterfly is an iron butterfly order
+	OrderTypeIronButterfly
+
+	// OrderTypeCondor is a four-strike condor order
+	OrderTypeCondor
+
+	// OrderTypeIronCondor is an iron condor order
+	OrderTypeIronCondor
+)
+
+// OrderAction specifies the action to take for an order instrument.
+// See the constants below for semantics.
+type OrderAction int
+
+const (
+	// OrderActionNil indicates no order action
+	OrderActionNil OrderAction = iota
+
+	// OrderActionBuy buys a security
+	OrderActionBuy
+
+	// OrderActionSell sells a security
+	OrderActionSell
+
+	// OrderActionSellShort sells a security short
+	OrderActionSellShort
+
+	// OrderActionBuyToCover buys a security to cover a short position
+	OrderActionBuyToCover
+
+	// OrderActionBuyOpen buys an option to open a position
+	OrderActionBuyOpen
+
+	// OrderActionSellOpen sells (writes) an option to open a position
+	OrderActionSellOpen
+
+	// OrderActionBuyClose buys an option to close a short position
+	OrderActionBuyClose
+
+	// OrderActionSellClose sells an option to close a long position
+	OrderActionSellClose
+)
+
+// OrderPriceType specifies the price type of an order.
+// See the constants below for semantics.
+type OrderPriceType int
+
+const (
+	// OrderPriceTypeNil indicates no price type
+	OrderPriceTypeNil OrderPriceType = iota
+
+	// OrderPriceTypeMarket executes the order at the market price
+	OrderPriceTypeMarket
+
+	// OrderPriceTypeLimit executes the order at the limit price or better
+	OrderPriceTypeLimit
+
+	// OrderPriceTypeStop converts the order to a market order once the stop
+	// price is reached
+	OrderPriceTypeStop
+
+	// OrderPriceTypeStopLimit converts the order to a limit order once the
+	// stop price is reached
+	OrderPriceTypeStopLimit
+
+	// OrderPriceTypeNetDebit executes a multi-leg order for a net debit no
+	// greater than the limit price
+	OrderPriceTypeNetDebit
+
+	// OrderPriceTypeNetCredit executes a multi-leg order for a net credit no
+	// less than the limit price
+	OrderPriceTypeNetCredit
+
+	// OrderPriceTypeNetEven executes a multi-leg order for a net price of zero
+	OrderPriceTypeNetEven
+)
+
+// OrderTerm specifies how long an order remains in effect.
+// See the constants below for semantics.
+type OrderTerm int
+
+const (
+	// OrderTermNil indicates no order term
+	OrderTermNil OrderTerm = iota
+
+	// OrderTermGoodForDay keeps the order open until the end of the day
+	OrderTermGoodForDay
+
+	// OrderTermGoodUntilCancel keeps the order open until it is cancelled
+	OrderTermGoodUntilCancel
+
+	// OrderTermImmediateOrCancel executes what it can immediately and cancels
+	// the rest
+	OrderTermImmediateOrCancel
+
+	// OrderTermFillOrKill executes the entire order immediately or cancels it
+	OrderTermFillOrKill
+)
+
+var orderTypeToString = map[OrderType]string{
+	OrderTypeEquity:        "EQ",
+	OrderTypeOption:        "OPTN",
+	OrderTypeSpreads:       "SPREADS",
+	OrderTypeBuyWrites:     "BUY_WRITES",
+	OrderTypeButterfly:     "BUTTERFLY",
+	OrderTypeIronButterfly: "IRON_BUTTERFLY",
+	OrderTypeCondor:        "CONDOR",
+	OrderTypeIronCondor:    "IRON_CONDOR",
+}
+
+// String converts an OrderType to its string representation.
+func (e OrderType) String() string {
+	if s, found := orderTypeToString[e]; found {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+var orderActionToString = map[OrderAction]string{
+	OrderActionBuy:        "BUY",
+	OrderActionSell:       "SELL",
+	OrderActionSellShort:  "SELL_SHORT",
+	OrderActionBuyToCover: "BUY_TO_COVER",
+	OrderActionBuyOpen:    "BUY_OPEN",
+	OrderActionSellOpen:   "SELL_OPEN",
+	OrderActionBuyClose:   "BUY_CLOSE",
+	OrderActionSellClose:  "SELL_CLOSE",
+}
+
+// String converts an OrderAction to its string representation.
+func (e OrderAction) String() string {
+	if s, found := orderActionToString[e]; found {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+var orderPriceTypeToString = map[OrderPriceType]string{
+	OrderPriceTypeMarket:    "MARKET",
+	OrderPriceTypeLimit:     "LIMIT",
+	OrderPriceTypeStop:      "STOP",
+	OrderPriceTypeStopLimit: "STOP_LIMIT",
+	OrderPriceTypeNetDebit:  "NET_DEBIT",
+	OrderPriceTypeNetCredit: "NET_CREDIT",
+	OrderPriceTypeNetEven:   "NET_EVEN",
+}
+
+// String converts an OrderPriceType to its string representation.
+func (e OrderPriceType) String() string {
+	if s, found := orderPriceTypeToString[e]; found {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+var orderTermToString = map[OrderTerm]string{
+	OrderTermGoodForDay:        "GOOD_FOR_DAY",
+	OrderTermGoodUntilCancel:   "GOOD_UNTIL_CANCEL",
+	OrderTermImmediateOrCancel: "IMMEDIATE_OR_CANCEL",
+	OrderTermFillOrKill:        "FILL_OR_KILL",
+}
+
+// String converts an OrderTerm to its string representation.
+func (e OrderTerm) String() string {
+	if s, found := orderTermToString[e]; found {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+// OrderTypeFromString converts a string representation of an OrderType to
+// its enum value.
+func OrderTypeFromString(s string) (OrderType, error) {
+	return enumFromString(orderTypeToString, s)
+}
+
+// OrderActionFromString converts a string representation of an OrderAction
+// to its enum value.
+func OrderActionFromString(s string) (OrderAction, error) {
+	return enumFromString(orderActionToString, s)
+}
+
+// OrderPriceTypeFromString converts a string representation of an
+// OrderPriceType to its enum value.
+func OrderPriceTypeFromString(s string) (OrderPriceType, error) {
+	return enumFromString(orderPriceTypeToString, s)
+}
+
+// OrderTermFromString converts a string representation of an OrderTerm to
+// its enum value.
+func OrderTermFromString(s string) (OrderTerm, error) {
+	return enumFromString(orderTermToString, s)
+}
+
+// OrderSecurityTypeFromString converts a string representation of an
+// OrderSecurityType to its enum value.
+func OrderSecurityTypeFromString(s string) (OrderSecurityType, error) {
+	return enumFromString(orderSecurityTypeToString, s)
+}
+
+// OrderStatusFromString converts a string representation of an OrderStatus
+// to its enum value.
+func OrderStatusFromString(s string) (OrderStatus, error) {
+	return enumFromString(orderStatusToString, s)
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"time"
+	// Embed the time zone database so that ETrade's times can be evaluated in
+	// US Eastern time on systems without one.
+	_ "time/tzdata"
+)
+
+// EasternTime is the US Eastern time zone, in which ETrade's trading hours
+// and access token expiry are defined.
+var EasternTime = mustLoadLocation("America/New_York")
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
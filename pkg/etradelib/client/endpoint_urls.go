@@ -1,7 +1,9 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -186,15 +188,59 @@ const (
 )
 
 func GetEndpointUrls(production bool) EndpointUrls {
-	var urlBase string
+	return newEndpointUrls(DefaultApiBaseUrl(production), authorizeApplicationUrlTemplate)
+}
+
+// DefaultApiBaseUrl returns the base URL of ETrade's production or sandbox
+// API.
+func DefaultApiBaseUrl(production bool) string {
 	if production {
-		urlBase = productionUrlBase
-	} else {
-		urlBase = sandboxUrlBase
+		return productionUrlBase
+	}
+	return sandboxUrlBase
+}
+
+// NewEndpointUrls returns the endpoint URLs for an API served from an
+// arbitrary base URL, such as a proxy or a local stand-in for ETrade. If
+// authorizeUrl is empty, ETrade's authorization URL is used.
+func NewEndpointUrls(baseUrl string, authorizeUrl string) (EndpointUrls, error) {
+	if err := validateEndpointUrl(baseUrl); err != nil {
+		return nil, fmt.Errorf("invalid API base URL '%s': %w", baseUrl, err)
+	}
+	if authorizeUrl == "" {
+		authorizeUrl = authorizeApplicationUrlTemplate
+	} else if err := validateEndpointUrl(authorizeUrl); err != nil {
+		return nil, fmt.Errorf("invalid authorize URL '%s': %w", authorizeUrl, err)
 	}
+	return newEndpointUrls(strings.TrimRight(baseUrl, "/"), authorizeUrl), nil
+}
+
+func validateEndpointUrl(endpointUrl string) error {
+	parsedUrl, err := url.Parse(endpointUrl)
+	if err != nil {
+		return err
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return errors.New("the URL must start with http:// or https://")
+	}
+	if parsedUrl.Host == "" {
+		return errors.New("the URL must include a host")
+	}
+	if parsedUrl.RawQuery != "" || parsedUrl.Fragment != "" {
+		return errors.New("the URL must not include a query or fragment")
+	}
+	// The URL is used as a format string for the endpoints that include
+	// parameters.
+	if strings.Contains(endpointUrl, "%") {
+		return errors.New("the URL must not include '%'")
+	}
+	return nil
+}
+
+func newEndpointUrls(urlBase string, authorizeUrl string) EndpointUrls {
 	return &endpointUrls{
 		getRequestTokenUrl:         renderUrlTemplateWithBase(getRequestTokenUrlTemplate, urlBase),
-		authorizeApplicationUrl:    renderUrlTemplateWithBase(authorizeApplicationUrlTemplate, urlBase),
+		authorizeApplicationUrl:    authorizeUrl,
 		getAccessTokenUrl:          renderUrlTemplateWithBase(getAccessTokenUrlTemplate, urlBase),
 		renewAccessTokenUrl:        renderUrlTemplateWithBase(renewAccessTokenUrlTemplate, urlBase),
 		revokeAccessTokenUrl:       renderUrlTemplateWithBase(revokeAccessTokenUrlTemplate, urlBase),
@@ -239,3 +239,66 @@ func TestProductionUrls(t *testing.T) {
 		urls.PlaceChangedOrderUrl("1234", "5678"),
 	)
 }
+
+func TestCustomUrls(t *testing.T) {
+	urls, err := NewEndpointUrls("http://localhost:8080/etrade/", "http://localhost:8080/authorize")
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		"http://localhost:8080/etrade/oauth/request_token",
+		urls.GetRequestTokenUrl(),
+	)
+	assert.Equal(
+		t,
+		"http://localhost:8080/authorize",
+		urls.AuthorizeApplicationUrl(),
+	)
+	assert.Equal(
+		t,
+		"http://localhost:8080/etrade/v1/accounts/1234/portfolio/5678",
+		urls.ListPositionLotsDetailsUrl("1234", 5678),
+	)
+	assert.Equal(
+		t,
+		"http://localhost:8080/etrade/v1/market/quote/GOOG",
+		urls.GetQuotesUrl("GOOG"),
+	)
+}
+
+func TestCustomUrlsDefaultAuthorizeUrl(t *testing.T) {
+	urls, err := NewEndpointUrls("http://localhost:8080", "")
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		"https://us.etrade.com/e/t/etws/authorize",
+		urls.AuthorizeApplicationUrl(),
+	)
+}
+
+func TestCustomUrlsInvalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseUrl      string
+		authorizeUrl string
+	}{
+		{"Empty Base URL", "", ""},
+		{"Missing Scheme", "localhost:8080", ""},
+		{"Unsupported Scheme", "ftp://localhost", ""},
+		{"Missing Host", "http://", ""},
+		{"Query", "http://localhost?a=b", ""},
+		{"Percent", "http://localhost/%41", ""},
+		{"Invalid Authorize URL", "http://localhost", "localhost/authorize"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				urls, err := NewEndpointUrls(tt.baseUrl, tt.authorizeUrl)
+				assert.Error(t, err)
+				assert.Nil(t, urls)
+			},
+		)
+	}
+}
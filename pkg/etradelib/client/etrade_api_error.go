@@ -0,0 +1,92 @@
+package client
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ETradeAPIError is returned when ETrade responds to a request with an error.
+// Use errors.As to get it from an error returned by the client.
+type ETradeAPIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Status is the HTTP status of the response (e.g. "400 Bad Request").
+	Status string
+	// Code is the ETrade error code, or 0 if the response did not include
+	// one.
+	Code int
+	// Message is the ETrade error message, or an empty string if the
+	// response did not include one.
+	Message string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *ETradeAPIError) Error() string {
+	var description string
+	if e.StatusCode == http.StatusUnauthorized {
+		description = ErrETradeAuthFailed.Error()
+	} else {
+		description = "request failed: " + e.Status
+	}
+	switch {
+	case e.Code != 0 && e.Message != "":
+		return fmt.Sprintf("%s (ETrade error %d: %s)", description, e.Code, e.Message)
+	case e.Code != 0:
+		return fmt.Sprintf("%s (ETrade error %d)", description, e.Code)
+	case e.Message != "":
+		return fmt.Sprintf("%s (%s)", description, e.Message)
+	default:
+		return description
+	}
+}
+
+// Unwrap allows errors.Is(err, ErrETradeAuthFailed) to detect authentication
+// failures.
+func (e *ETradeAPIError) Unwrap() error {
+	if e.StatusCode == http.StatusUnauthorized {
+		return ErrETradeAuthFailed
+	}
+	return nil
+}
+
+// AsETradeAPIError returns the ETradeAPIError in an error's chain, if there is
+// one.
+func AsETradeAPIError(err error) (*ETradeAPIError, bool) {
+	var apiError *ETradeAPIError
+	if errors.As(err, &apiError) {
+		return apiError, true
+	}
+	return nil, false
+}
+
+// eTradeErrorBody is the error that ETrade includes in the body of most error
+// responses. ETrade sometimes responds with XML even when JSON is requested,
+// so the body is decoded as either.
+type eTradeErrorBody struct {
+	Code    int    `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+}
+
+func newETradeAPIError(statusCode int, status string, body []byte) *ETradeAPIError {
+	apiError := &ETradeAPIError{
+		StatusCode: statusCode,
+		Status:     status,
+		Body:       body,
+	}
+	var jsonBody struct {
+		Error eTradeErrorBody `json:"Error"`
+	}
+	var xmlBody eTradeErrorBody
+	if err := json.Unmarshal(body, &jsonBody); err == nil {
+		apiError.Code = jsonBody.Error.Code
+		apiError.Message = jsonBody.Error.Message
+	} else if err = xml.Unmarshal(body, &xmlBody); err == nil {
+		apiError.Code = xmlBody.Code
+		apiError.Message = xmlBody.Message
+	}
+	return apiError
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestNewETradeAPIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		status        string
+		body          string
+		expectCode    int
+		expectMessage string
+		expectError   string
+	}{
+		{
+			name:          "JSON Error Body",
+			statusCode:    http.StatusBadRequest,
+			status:        "400 Bad Request",
+			body:          `{"Error":{"code":10033,"message":"The symbol is invalid"}}`,
+			expectCode:    10033,
+			expectMessage: "The symbol is invalid",
+			expectError:   "request failed: 400 Bad Request (ETrade error 10033: The symbol is invalid)",
+		},
+		{
+			name:          "XML Error Body",
+			statusCode:    http.StatusBadRequest,
+			status:        "400 Bad Request",
+			body:          `<?xml version="1.0"?><Error><code>10033</code><message>The symbol is invalid</message></Error>`,
+			expectCode:    10033,
+			expectMessage: "The symbol is invalid",
+			expectError:   "request failed: 400 Bad Request (ETrade error 10033: The symbol is invalid)",
+		},
+		{
+			name:          "Unrecognized Body",
+			statusCode:    http.StatusServiceUnavailable,
+			status:        "503 Service Unavailable",
+			body:          `Service Unavailable`,
+			expectCode:    0,
+			expectMessage: "",
+			expectError:   "request failed: 503 Service Unavailable",
+		},
+		{
+			name:          "Auth Failure",
+			statusCode:    http.StatusUnauthorized,
+			status:        "401 Unauthorized",
+			body:          `{"Error":{"message":"oauth_problem=token_rejected"}}`,
+			expectCode:    0,
+			expectMessage: "oauth_problem=token_rejected",
+			expectError:   "authentication failed (oauth_problem=token_rejected)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualError := newETradeAPIError(tt.statusCode, tt.status, []byte(tt.body))
+				assert.Equal(t, tt.statusCode, actualError.StatusCode)
+				assert.Equal(t, tt.expectCode, actualError.Code)
+				assert.Equal(t, tt.expectMessage, actualError.Message)
+				assert.Equal(t, []byte(tt.body), actualError.Body)
+				assert.Equal(t, tt.expectError, actualError.Error())
+				assert.Equal(t, tt.statusCode == http.StatusUnauthorized, IsAuthFailed(actualError))
+			},
+		)
+	}
+}
+
+func TestETradeClient_ReturnsETradeAPIError(t *testing.T) {
+	clientMock := new(httpClientMock)
+	clientMock.On("Do", "GET", "https://api.etrade.com/v1/accounts/list").Return(
+		http.StatusBadRequest, `{"Error":{"code":100,"message":"Test error"}}`, nil,
+	)
+	testClient := createMockClient(clientMock, nil, true, "", "", "", "", "", "")
+
+	// Call the Method Under Test
+	_, err := testClient.ListAccounts()
+	apiError, ok := AsETradeAPIError(errors.Join(errors.New("wrapper"), err))
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiError.StatusCode)
+	assert.Equal(t, 100, apiError.Code)
+	assert.Equal(t, "Test error", apiError.Message)
+	assert.False(t, IsAuthFailed(err))
+	clientMock.AssertExpectations(t)
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dghubble/oauth1"
@@ -11,14 +13,25 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type ETradeClient interface {
+	// WithContext returns a client that shares this client's authentication
+	// state and rate limiter but makes its requests with the given context,
+	// so that callers can set deadlines and cancel in-flight requests.
+	WithContext(ctx context.Context) ETradeClient
+
 	Authenticate() ([]byte, error)
 
 	Verify(verifyKey string) ([]byte, error)
 
+	RenewAccessToken() ([]byte, error)
+
+	RevokeAccessToken() ([]byte, error)
+
 	GetKeys() (consumerKey string, consumerSecret string, accessToken string, accessSecret string)
 
 	ListAccounts() ([]byte, error)
@@ -69,29 +82,79 @@ type ETradeClient interface {
 		toDate *time.Time, symbols []string, securityType constants.OrderSecurityType,
 		transactionType constants.OrderTransactionType, marketSession constants.MarketSession,
 	) ([]byte, error)
+
+	PreviewOrder(accountIdKey string, order *OrderRequest) ([]byte, error)
+
+	PlaceOrder(accountIdKey string, previewId int64, order *OrderRequest) ([]byte, error)
+
+	CancelOrder(accountIdKey string, orderId int64) ([]byte, error)
+
+	PreviewChangedOrder(accountIdKey string, orderId int64, order *OrderRequest) ([]byte, error)
+
+	PlaceChangedOrder(accountIdKey string, orderId int64, previewId int64, order *OrderRequest) ([]byte, error)
 }
 
 type eTradeClient struct {
 	urls           EndpointUrls
-	httpClient     HttpClient
 	logger         *slog.Logger
 	config         OAuthConfig
+	oauthContext   context.Context
 	consumerKey    string
 	consumerSecret string
-	requestToken   string
-	requestSecret  string
-	accessToken    string
-	accessSecret   string
+	session        *eTradeClientSession
+	ctx            context.Context
+	retryPolicy    RetryPolicy
+	rateLimiter    *RateLimiter
+	sleep          func(ctx context.Context, d time.Duration) error
+}
+
+// eTradeClientSession holds the authentication state that a client shares
+// with any copies of it made with WithContext. The tokens are never modified
+// in place. Changing them swaps in a new set, so that a request in flight
+// keeps the tokens it started with and concurrent requests never see a
+// partially-updated set.
+type eTradeClientSession struct {
+	// authMutex serializes the operations that change the tokens, so that,
+	// for example, a verification uses the request token from the most recent
+	// authentication.
+	authMutex sync.Mutex
+	tokens    atomic.Pointer[eTradeClientTokens]
+}
+
+// eTradeClientTokens is a set of tokens and the HTTP client that signs
+// requests with them. It must not be modified once it has been stored in a
+// session.
+type eTradeClientTokens struct {
+	httpClient    HttpClient
+	requestToken  string
+	requestSecret string
+	accessToken   string
+	accessSecret  string
+}
+
+func newETradeClientSession(tokens eTradeClientTokens) *eTradeClientSession {
+	session := &eTradeClientSession{}
+	session.tokens.Store(&tokens)
+	return session
+}
+
+// getTokens returns the session's current tokens.
+func (s *eTradeClientSession) getTokens() eTradeClientTokens {
+	return *s.tokens.Load()
+}
+
+// setTokens replaces the session's tokens.
+func (s *eTradeClientSession) setTokens(tokens eTradeClientTokens) {
+	s.tokens.Store(&tokens)
 }
 
 func CreateETradeClient(
-	logger *slog.Logger, production bool, consumerKey string, consumerSecret string, accessToken string,
-	accessSecret string,
+	logger *slog.Logger, urls EndpointUrls, consumerKey string, consumerSecret string, accessToken string,
+	accessSecret string, retryPolicy RetryPolicy, rateLimiter *RateLimiter, httpClient HttpClient,
 ) (ETradeClient, error) {
 	if consumerKey == "" || consumerSecret == "" {
 		return nil, errors.New("invalid consumer credentials provided")
 	}
-	urls := GetEndpointUrls(production)
 
 	authorizeEndpoint := oauth1.Endpoint{
 		RequestTokenURL: urls.GetRequestTokenUrl(),
@@ -106,34 +169,87 @@ func CreateETradeClient(
 		Endpoint:       authorizeEndpoint,
 	}
 
+	// If an HTTP client is provided (e.g. one that records or replays
+	// requests), then send every request through it, including the requests
+	// for OAuth tokens.
+	oauthContext := oauth1.NoContext
+	if httpClient != nil {
+		config.HTTPClient = &http.Client{Transport: &httpClientTransport{httpClient: httpClient}}
+		oauthContext = context.WithValue(oauth1.NoContext, oauth1.HTTPClient, config.HTTPClient)
+	}
+
 	token := oauth1.NewToken(accessToken, oauth1.PercentEncode(accessSecret))
-	httpClient := config.Client(oauth1.NoContext, token)
 
 	return &eTradeClient{
 		urls:           urls,
-		httpClient:     httpClient,
 		logger:         logger,
-		config:         &config,
+		config:         &contextOAuthConfig{Config: &config},
+		oauthContext:   oauthContext,
 		consumerKey:    consumerKey,
 		consumerSecret: consumerSecret,
-		accessToken:    accessToken,
-		accessSecret:   accessSecret,
+		session: newETradeClientSession(
+			eTradeClientTokens{
+				httpClient:   config.Client(oauthContext, token),
+				accessToken:  accessToken,
+				accessSecret: accessSecret,
+			},
+		),
+		ctx:         context.Background(),
+		retryPolicy: retryPolicy,
+		rateLimiter: rateLimiter,
+		sleep:       sleepWithContext,
 	}, nil
 }
 
 var ErrETradeAuthFailed = errors.New("authentication failed")
 
 func IsAuthFailed(err error) bool {
-	return err == ErrETradeAuthFailed
+	return errors.Is(err, ErrETradeAuthFailed)
+}
+
+// ErrInvalidArgument is wrapped by every error returned when a client method
+// is called with a missing or out-of-range argument, before any request is
+// sent to ETrade.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+func IsInvalidArgument(err error) bool {
+	return errors.Is(err, ErrInvalidArgument)
+}
+
+// invalidArgumentError wraps ErrInvalidArgument without changing an error's
+// message.
+type invalidArgumentError struct {
+	err error
+}
+
+func (e *invalidArgumentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidArgumentError) Unwrap() []error {
+	return []error{ErrInvalidArgument, e.err}
+}
+
+func newInvalidArgumentError(format string, a ...interface{}) error {
+	return &invalidArgumentError{err: fmt.Errorf(format, a...)}
 }
 
 const queryDateLayout = "01022006"
 
+func (c *eTradeClient) WithContext(ctx context.Context) ETradeClient {
+	if ctx == nil {
+		panic("nil context")
+	}
+	clientWithContext := *c
+	clientWithContext.ctx = ctx
+	return &clientWithContext
+}
+
 func (c *eTradeClient) Authenticate() ([]byte, error) {
-	_, err := c.doRequest("GET", c.urls.RenewAccessTokenUrl(), nil)
+	response, err := c.RenewAccessToken()
 	// If access token renewal succeeded, then we're done. Return success.
 	if err == nil {
-		return NewStatusResponse("success"), nil
+		return response, nil
 	}
 	// If the error is anything other than an auth failure, then fail.
 	if !IsAuthFailed(err) {
@@ -141,34 +257,64 @@ func (c *eTradeClient) Authenticate() ([]byte, error) {
 	}
 	// If access token renewal failed, then begin a new auth session by
 	// requesting a new token.
-	c.requestToken, c.requestSecret, err = c.config.RequestToken()
+	c.session.authMutex.Lock()
+	defer c.session.authMutex.Unlock()
+	tokens := c.session.getTokens()
+	tokens.requestToken, tokens.requestSecret, err = c.config.RequestToken(c.ctx)
 	if err != nil {
 		return nil, err
 	}
+	c.session.setTokens(tokens)
 	// Format and return the authorization string
 	authorizeUrl, err := url.Parse(c.urls.AuthorizeApplicationUrl())
 	values := authorizeUrl.Query()
 	values.Add("key", c.consumerKey)
-	values.Add("token", c.requestToken)
+	values.Add("token", tokens.requestToken)
 	authorizeUrl.RawQuery = values.Encode()
 	return NewStatusResponse("authorize", "authorizationUrl", authorizeUrl.String()), nil
 }
 
 func (c *eTradeClient) Verify(verifyKey string) ([]byte, error) {
+	c.session.authMutex.Lock()
+	defer c.session.authMutex.Unlock()
 	var err error
-	c.accessToken, c.accessSecret, err = c.config.AccessToken(
-		c.requestToken, oauth1.PercentEncode(c.requestSecret), verifyKey,
+	tokens := c.session.getTokens()
+	tokens.accessToken, tokens.accessSecret, err = c.config.AccessToken(
+		c.ctx, tokens.requestToken, oauth1.PercentEncode(tokens.requestSecret), verifyKey,
 	)
 	if err != nil {
 		return nil, err
 	}
-	token := oauth1.NewToken(c.accessToken, oauth1.PercentEncode(c.accessSecret))
-	c.httpClient = c.config.Client(oauth1.NoContext, token)
+	token := oauth1.NewToken(tokens.accessToken, oauth1.PercentEncode(tokens.accessSecret))
+	tokens.httpClient = c.config.Client(c.oauthContext, token)
+	c.session.setTokens(tokens)
+	return NewStatusResponse("success"), nil
+}
+
+func (c *eTradeClient) RenewAccessToken() ([]byte, error) {
+	if _, err := c.doRequest("GET", c.urls.RenewAccessTokenUrl(), nil); err != nil {
+		return nil, err
+	}
+	return NewStatusResponse("success"), nil
+}
+
+func (c *eTradeClient) RevokeAccessToken() ([]byte, error) {
+	c.session.authMutex.Lock()
+	defer c.session.authMutex.Unlock()
+	if _, err := c.doRequest("GET", c.urls.RevokeAccessTokenUrl(), nil); err != nil {
+		return nil, err
+	}
+	// The revoked token can no longer be used, so forget it.
+	tokens := c.session.getTokens()
+	tokens.accessToken, tokens.accessSecret = "", ""
+	tokens.httpClient = c.config.Client(c.oauthContext, oauth1.NewToken("", ""))
+	c.session.setTokens(tokens)
 	return NewStatusResponse("success"), nil
 }
 
 func (c *eTradeClient) GetKeys() (consumerKey string, consumerSecret string, accessToken string, accessSecret string) {
-	return c.consumerKey, c.consumerSecret, c.accessToken, c.accessSecret
+	tokens := c.session.getTokens()
+	return c.consumerKey, c.consumerSecret, tokens.accessToken, tokens.accessSecret
 }
 
 func (c *eTradeClient) ListAccounts() ([]byte, error) {
@@ -181,7 +327,7 @@ func (c *eTradeClient) ListAccounts() ([]byte, error) {
 
 func (c *eTradeClient) GetAccountBalances(accountIdKey string, realTimeNAV bool) ([]byte, error) {
 	if accountIdKey == "" {
-		return nil, errors.New("accountIdKey not provided")
+		return nil, newInvalidArgumentError("accountIdKey not provided")
 	}
 	queryValues := url.Values{}
 	queryValues.Add("instType", "BROKERAGE")
@@ -199,7 +345,7 @@ func (c *eTradeClient) ListTransactions(
 	count int,
 ) ([]byte, error) {
 	if accountIdKey == "" {
-		return nil, errors.New("accountIdKey not provided")
+		return nil, newInvalidArgumentError("accountIdKey not provided")
 	}
 	queryValues := url.Values{}
 	if startDate != nil {
@@ -227,10 +373,10 @@ func (c *eTradeClient) ListTransactions(
 
 func (c *eTradeClient) ListTransactionDetails(accountIdKey string, transactionId string) ([]byte, error) {
 	if accountIdKey == "" {
-		return nil, errors.New("accountIdKey not provided")
+		return nil, newInvalidArgumentError("accountIdKey not provided")
 	}
 	if transactionId == "" {
-		return nil, errors.New("transactionId not provided")
+		return nil, newInvalidArgumentError("transactionId not provided")
 	}
 	response, err := c.doRequest("GET", c.urls.ListTransactionDetailsUrl(accountIdKey, transactionId), nil)
 	if err != nil {
@@ -244,10 +390,10 @@ func (c *eTradeClient) ViewPortfolio(
 	marketSession constants.MarketSession, totalsRequired bool, lotsRequired bool, view constants.PortfolioView,
 ) ([]byte, error) {
 	if accountIdKey == "" {
-		return nil, errors.New("accountIdKey not provided")
+		return nil, newInvalidArgumentError("accountIdKey not provided")
 	}
 	if count > constants.PortfolioMaxCount {
-		return nil, fmt.Errorf(
+		return nil, newInvalidArgumentError(
 			"count of %d requested, which exceeds the maximum of %d", count, constants.PortfolioMaxCount,
 		)
 	}
@@ -282,7 +428,7 @@ func (c *eTradeClient) ViewPortfolio(
 
 func (c *eTradeClient) ListPositionLotsDetails(accountIdKey string, positionId int64) ([]byte, error) {
 	if accountIdKey == "" {
-		return nil, errors.New("accountIdKey not provided")
+		return nil, newInvalidArgumentError("accountIdKey not provided")
 	}
 
 	response, err := c.doRequest("GET", c.urls.ListPositionLotsDetailsUrl(accountIdKey, positionId), nil)
@@ -300,7 +446,7 @@ func (c *eTradeClient) ListAlerts(
 ) {
 	queryValues := url.Values{}
 	if count > constants.AlertsMaxCount {
-		return nil, fmt.Errorf(
+		return nil, newInvalidArgumentError(
 			"count of %d requested, which exceeds the maximum of %d", count, constants.AlertsMaxCount,
 		)
 	}
@@ -339,7 +485,7 @@ func (c *eTradeClient) ListAlertDetails(alertId string, htmlTags bool) ([]byte,
 }
 
 func (c *eTradeClient) DeleteAlerts(alertIds []string) ([]byte, error) {
-	response, err := c.doRequest("DELETE", c.urls.DeleteAlertUrl(strings.Join(alertIds, ",")), nil)
+	response, err := c.doRequestWithoutRetry("DELETE", c.urls.DeleteAlertUrl(strings.Join(alertIds, ",")), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -350,10 +496,10 @@ func (c *eTradeClient) GetQuotes(
 	symbols []string, detailFlag constants.QuoteDetailFlag, requireEarningsDate bool, skipMiniOptionsCheck bool,
 ) ([]byte, error) {
 	if len(symbols) < 1 {
-		return nil, errors.New("no symbols provided")
+		return nil, newInvalidArgumentError("no symbols provided")
 	}
 	if len(symbols) > constants.GetQuotesMaxSymbols {
-		return nil, fmt.Errorf(
+		return nil, newInvalidArgumentError(
 			"%d symbols requested, which exceeds the maximum of %d symbols in a request", len(symbols),
 			constants.GetQuotesMaxSymbols,
 		)
@@ -378,7 +524,7 @@ func (c *eTradeClient) GetQuotes(
 
 func (c *eTradeClient) LookupProduct(search string) ([]byte, error) {
 	if search == "" {
-		return nil, errors.New("no search string provided")
+		return nil, newInvalidArgumentError("no search string provided")
 	}
 	response, err := c.doRequest("GET", c.urls.LookUpProductUrl(search), nil)
 	if err != nil {
@@ -393,7 +539,7 @@ func (c *eTradeClient) GetOptionChains(
 	priceType constants.OptionPriceType,
 ) ([]byte, error) {
 	if symbol == "" {
-		return nil, errors.New("no symbol provided")
+		return nil, newInvalidArgumentError("no symbol provided")
 	}
 	queryValues := url.Values{}
 	queryValues.Add("symbol", symbol)
@@ -433,7 +579,7 @@ func (c *eTradeClient) GetOptionChains(
 
 func (c *eTradeClient) GetOptionExpireDates(symbol string, expiryType constants.OptionExpiryType) ([]byte, error) {
 	if symbol == "" {
-		return nil, errors.New("no symbol provided")
+		return nil, newInvalidArgumentError("no symbol provided")
 	}
 	queryValues := url.Values{}
 	queryValues.Add("symbol", symbol)
@@ -454,7 +600,7 @@ func (c *eTradeClient) ListOrders(
 	marketSession constants.MarketSession,
 ) ([]byte, error) {
 	if accountIdKey == "" {
-		return nil, errors.New("accountIdKey not provided")
+		return nil, newInvalidArgumentError("accountIdKey not provided")
 	}
 	queryValues := url.Values{}
 	if marker != "" {
@@ -474,7 +620,7 @@ func (c *eTradeClient) ListOrders(
 	}
 	if len(symbols) > 0 {
 		if len(symbols) > constants.ListOrdersMaxSymbols {
-			return nil, fmt.Errorf(
+			return nil, newInvalidArgumentError(
 				"%d symbols provided, which exceeds the limit of %d", len(symbols), constants.ListOrdersMaxSymbols,
 			)
 		}
@@ -497,30 +643,229 @@ func (c *eTradeClient) ListOrders(
 	return response, nil
 }
 
-func (c *eTradeClient) doRequest(method string, baseUrl string, queryValues url.Values) ([]byte, error) {
-	req, err := http.NewRequest(method, baseUrl, nil)
+func (c *eTradeClient) PreviewOrder(accountIdKey string, order *OrderRequest) ([]byte, error) {
+	if accountIdKey == "" {
+		return nil, newInvalidArgumentError("accountIdKey not provided")
+	}
+	if order == nil {
+		return nil, newInvalidArgumentError("order not provided")
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := c.doJsonRequest("POST", c.urls.PreviewOrderUrl(accountIdKey), order.AsPreviewRequestJsonMap())
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *eTradeClient) PlaceOrder(accountIdKey string, previewId int64, order *OrderRequest) ([]byte, error) {
+	if accountIdKey == "" {
+		return nil, newInvalidArgumentError("accountIdKey not provided")
+	}
+	if previewId <= 0 {
+		return nil, newInvalidArgumentError("previewId not provided")
+	}
+	if order == nil {
+		return nil, newInvalidArgumentError("order not provided")
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := c.doJsonRequestWithoutRetry(
+		"POST", c.urls.PlaceOrderUrl(accountIdKey), order.AsPlaceRequestJsonMap(previewId),
+	)
 	if err != nil {
 		return nil, err
 	}
+	return response, nil
+}
+
+func (c *eTradeClient) CancelOrder(accountIdKey string, orderId int64) ([]byte, error) {
+	if accountIdKey == "" {
+		return nil, newInvalidArgumentError("accountIdKey not provided")
+	}
+	if orderId <= 0 {
+		return nil, newInvalidArgumentError("orderId not provided")
+	}
+	requestBody := jsonmap.JsonMap{
+		"CancelOrderRequest": jsonmap.JsonMap{
+			"orderId": orderId,
+		},
+	}
+
+	response, err := c.doJsonRequestWithoutRetry("PUT", c.urls.CancelOrderUrl(accountIdKey), requestBody)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *eTradeClient) PreviewChangedOrder(accountIdKey string, orderId int64, order *OrderRequest) ([]byte, error) {
+	if accountIdKey == "" {
+		return nil, newInvalidArgumentError("accountIdKey not provided")
+	}
+	if orderId <= 0 {
+		return nil, newInvalidArgumentError("orderId not provided")
+	}
+	if order == nil {
+		return nil, newInvalidArgumentError("order not provided")
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := c.doJsonRequest(
+		"PUT", c.urls.ChangePreviewedOrderUrl(accountIdKey, fmt.Sprintf("%d", orderId)),
+		order.AsPreviewRequestJsonMap(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *eTradeClient) PlaceChangedOrder(
+	accountIdKey string, orderId int64, previewId int64, order *OrderRequest,
+) ([]byte, error) {
+	if accountIdKey == "" {
+		return nil, newInvalidArgumentError("accountIdKey not provided")
+	}
+	if orderId <= 0 {
+		return nil, newInvalidArgumentError("orderId not provided")
+	}
+	if previewId <= 0 {
+		return nil, newInvalidArgumentError("previewId not provided")
+	}
+	if order == nil {
+		return nil, newInvalidArgumentError("order not provided")
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := c.doJsonRequestWithoutRetry(
+		"PUT", c.urls.PlaceChangedOrderUrl(accountIdKey, fmt.Sprintf("%d", orderId)),
+		order.AsPlaceRequestJsonMap(previewId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *eTradeClient) doJsonRequest(method string, baseUrl string, body jsonmap.JsonMap) ([]byte, error) {
+	bodyBytes, err := body.ToJsonBytes(false, false)
+	if err != nil {
+		return nil, err
+	}
+	return c.doRequestWithBody(method, baseUrl, url.Values{}, bodyBytes, true)
+}
+
+// doJsonRequestWithoutRetry performs a JSON request that must not be retried
+// because repeating it could have additional effects (e.g. placing an order
+// twice).
+func (c *eTradeClient) doJsonRequestWithoutRetry(method string, baseUrl string, body jsonmap.JsonMap) (
+	[]byte, error,
+) {
+	bodyBytes, err := body.ToJsonBytes(false, false)
+	if err != nil {
+		return nil, err
+	}
+	return c.doRequestWithBody(method, baseUrl, url.Values{}, bodyBytes, false)
+}
+
+func (c *eTradeClient) doRequest(method string, baseUrl string, queryValues url.Values) ([]byte, error) {
+	return c.doRequestWithBody(method, baseUrl, queryValues, nil, true)
+}
+
+// doRequestWithoutRetry performs a request that must not be retried because
+// repeating it could have additional effects.
+func (c *eTradeClient) doRequestWithoutRetry(method string, baseUrl string, queryValues url.Values) (
+	[]byte, error,
+) {
+	return c.doRequestWithBody(method, baseUrl, queryValues, nil, false)
+}
+
+func (c *eTradeClient) doRequestWithBody(
+	method string, baseUrl string, queryValues url.Values, body []byte, retryable bool,
+) ([]byte, error) {
+	maxRetries := 0
+	if retryable {
+		maxRetries = c.retryPolicy.MaxRetries
+	}
+	for retry := 0; ; retry++ {
+		if retry > 0 {
+			c.logger.Debug(fmt.Sprintf("retrying %s %s (retry %d of %d)", method, baseUrl, retry, maxRetries))
+		}
+		responseBytes, retryAfter, err := c.doRequestOnce(method, baseUrl, queryValues, body)
+		if err == nil || retryAfter < 0 || retry >= maxRetries || c.ctx.Err() != nil {
+			return responseBytes, err
+		}
+		wait := retryAfter
+		if wait == 0 {
+			wait = c.retryPolicy.backoff(retry + 1)
+		}
+		c.logger.Debug(fmt.Sprintf("%s %s failed (%s); waiting %s to retry", method, baseUrl, err.Error(), wait))
+		if err = c.sleep(c.ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doRequestOnce performs a single request. If the request fails in a way that
+// may succeed when retried, retryAfter is zero or, if the server asked for a
+// specific delay, the time to wait before retrying. Otherwise, retryAfter is
+// negative.
+func (c *eTradeClient) doRequestOnce(
+	method string, baseUrl string, queryValues url.Values, body []byte,
+) (responseBytes []byte, retryAfter time.Duration, err error) {
+	const notRetryable = time.Duration(-1)
+
+	var bodyReader io.Reader = nil
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(c.ctx, method, baseUrl, bodyReader)
+	if err != nil {
+		return nil, notRetryable, err
+	}
 
 	// Request that the server respond with JSON
 	req.Header.Add("Accept", `application/json`)
+	if body != nil {
+		req.Header.Add("Content-Type", `application/json`)
+	}
 
 	// Parse any query parameters from the base URL and merge them with the provided query parameters and encode
 	urlQueryValues, err := url.ParseQuery(req.URL.RawQuery)
 	if err != nil {
-		return nil, err
+		return nil, notRetryable, err
 	}
-	for key, values := range urlQueryValues {
-		for _, value := range values {
-			queryValues.Add(key, value)
+	mergedQueryValues := url.Values{}
+	for _, values := range []url.Values{queryValues, urlQueryValues} {
+		for key, valueList := range values {
+			for _, value := range valueList {
+				mergedQueryValues.Add(key, value)
+			}
 		}
 	}
-	req.URL.RawQuery = queryValues.Encode()
+	req.URL.RawQuery = mergedQueryValues.Encode()
+
+	// Wait until the API module's rate limit allows the request
+	if err = c.rateLimiter.wait(c.ctx, req.URL.Path); err != nil {
+		return nil, notRetryable, err
+	}
 
 	// Perform the request
 	c.logger.Debug(method + " " + req.URL.String())
-	httpResponse, err := c.httpClient.Do(req)
+	if body != nil {
+		c.logger.Debug(string(body))
+	}
+	httpResponse, err := c.session.getTokens().httpClient.Do(req)
 	if httpResponse != nil {
 		defer func(Body io.ReadCloser) {
 			err := Body.Close()
@@ -530,24 +875,30 @@ func (c *eTradeClient) doRequest(method string, baseUrl string, queryValues url.
 		}(httpResponse.Body)
 	}
 	if err != nil {
-		return nil, err
+		// Transport errors (e.g. a dropped connection) may be transient.
+		return nil, 0, err
 	}
 
-	// Return an auth failure if the status code is a 401
-	if httpResponse.StatusCode == http.StatusUnauthorized {
-		return nil, ErrETradeAuthFailed
-	}
-	// Return a failure if the status code is not 200
+	// Return an ETrade API error, which includes the error from the response
+	// body, if the status code is not 200. A 401 is reported as an auth
+	// failure.
 	if httpResponse.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("request failed: %s", httpResponse.Status)
+		errorBytes, _ := io.ReadAll(httpResponse.Body)
+		c.logger.Debug(string(errorBytes))
+		err = newETradeAPIError(httpResponse.StatusCode, httpResponse.Status, errorBytes)
+		if !isRetryableStatus(httpResponse.StatusCode) {
+			return nil, notRetryable, err
+		}
+		retryAfter, _ = parseRetryAfter(httpResponse.Header.Get("Retry-After"), time.Now())
+		return nil, retryAfter, err
 	}
 	// Return the response bytes if no error
-	responseBytes, err := io.ReadAll(httpResponse.Body)
+	responseBytes, err = io.ReadAll(httpResponse.Body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	c.logger.Debug(string(responseBytes))
-	return responseBytes, nil
+	return responseBytes, notRetryable, nil
 }
 
 func NewStatusMap(status string, keysAndValues ...string) jsonmap.JsonMap {
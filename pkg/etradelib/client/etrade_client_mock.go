@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/stretchr/testify/mock"
 	"time"
@@ -10,6 +11,11 @@ type ETradeClientMock struct {
 	mock.Mock
 }
 
+func (c *ETradeClientMock) WithContext(ctx context.Context) ETradeClient {
+	args := c.Called(ctx)
+	return args.Get(0).(ETradeClient)
+}
+
 func (c *ETradeClientMock) Authenticate() ([]byte, error) {
 	args := c.Called()
 	return args.Get(0).([]byte), args.Error(1)
@@ -20,6 +26,16 @@ func (c *ETradeClientMock) Verify(verifyKey string) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (c *ETradeClientMock) RenewAccessToken() ([]byte, error) {
+	args := c.Called()
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (c *ETradeClientMock) RevokeAccessToken() ([]byte, error) {
+	args := c.Called()
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (c *ETradeClientMock) GetKeys() (
 	consumerKey string, consumerSecret string, accessToken string, accessSecret string,
 ) {
@@ -121,3 +137,30 @@ func (c *ETradeClientMock) ListOrders(
 	)
 	return args.Get(0).([]byte), args.Error(1)
 }
+
+func (c *ETradeClientMock) PreviewOrder(accountIdKey string, order *OrderRequest) ([]byte, error) {
+	args := c.Called(accountIdKey, order)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (c *ETradeClientMock) PlaceOrder(accountIdKey string, previewId int64, order *OrderRequest) ([]byte, error) {
+	args := c.Called(accountIdKey, previewId, order)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (c *ETradeClientMock) CancelOrder(accountIdKey string, orderId int64) ([]byte, error) {
+	args := c.Called(accountIdKey, orderId)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (c *ETradeClientMock) PreviewChangedOrder(accountIdKey string, orderId int64, order *OrderRequest) ([]byte, error) {
+	args := c.Called(accountIdKey, orderId, order)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (c *ETradeClientMock) PlaceChangedOrder(
+	accountIdKey string, orderId int64, previewId int64, order *OrderRequest,
+) ([]byte, error) {
+	args := c.Called(accountIdKey, orderId, previewId, order)
+	return args.Get(0).([]byte), args.Error(1)
+}
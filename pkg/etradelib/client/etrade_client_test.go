@@ -1,12 +1,15 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"github.com/dghubble/oauth1"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 )
@@ -18,15 +21,42 @@ func createMockClient(
 
 	return &eTradeClient{
 		urls:           GetEndpointUrls(production),
-		httpClient:     httpClient,
 		logger:         etradelibtest.CreateNullLogger(),
 		config:         config,
+		oauthContext:   oauth1.NoContext,
 		consumerKey:    consumerKey,
 		consumerSecret: consumerSecret,
-		requestToken:   requestToken,
-		requestSecret:  requestSecret,
-		accessToken:    accessToken,
-		accessSecret:   accessSecret,
+		session: newETradeClientSession(
+			eTradeClientTokens{
+				httpClient:    httpClient,
+				requestToken:  requestToken,
+				requestSecret: requestSecret,
+				accessToken:   accessToken,
+				accessSecret:  accessSecret,
+			},
+		),
+		ctx:         context.Background(),
+		retryPolicy: RetryPolicy{},
+		sleep:       sleepWithContext,
+	}
+}
+
+func createTestOrderRequest() *OrderRequest {
+	return &OrderRequest{
+		ClientOrderId: "TestClientOrderId",
+		OrderType:     constants.OrderTypeEquity,
+		PriceType:     constants.OrderPriceTypeLimit,
+		OrderTerm:     constants.OrderTermGoodForDay,
+		MarketSession: constants.MarketSessionRegular,
+		LimitPrice:    decimal.MustNewFromString("123.45"),
+		Instruments: []OrderInstrument{
+			{
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Symbol:       "GOOG",
+				OrderAction:  constants.OrderActionBuy,
+				Quantity:     10,
+			},
+		},
 	}
 }
 
@@ -63,12 +93,28 @@ func TestETradeClient_Authenticate(t *testing.T) {
 				clientMock.On(
 					"Do", "GET", "https://api.etrade.com/oauth/renew_access_token",
 				).Return(http.StatusUnauthorized, "", nil)
-				configMock.On("RequestToken").Return("TestToken", "TestKey", nil)
+				configMock.On("RequestToken", context.Background()).Return("TestToken", "TestKey", nil)
 				return testClient.Authenticate()
 			},
 			expectResponse: []byte(`{"authorizationUrl":"https://us.etrade.com/e/t/etws/authorize?key=TestConsumerKey&token=TestToken","status":"authorize"}` + "\n"),
 			expectErr:      false,
 		},
+		{
+			name:            "Authenticate Requests Token With Client Context",
+			testConsumerKey: "TestConsumerKey",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock, configMock *oAuthConfigMock) (
+				[]byte, error,
+			) {
+				ctx := context.WithValue(context.Background(), "testKey", "testValue")
+				clientMock.On(
+					"Do", "GET", "https://api.etrade.com/oauth/renew_access_token",
+				).Return(http.StatusUnauthorized, "", nil)
+				configMock.On("RequestToken", ctx).Return("TestToken", "TestKey", nil)
+				return testClient.WithContext(ctx).Authenticate()
+			},
+			expectResponse: []byte(`{"authorizationUrl":"https://us.etrade.com/e/t/etws/authorize?key=TestConsumerKey&token=TestToken","status":"authorize"}` + "\n"),
+			expectErr:      false,
+		},
 		{
 			name:            "Authenticate Fails On HTTP Error",
 			testConsumerKey: "TestConsumerKey",
@@ -92,7 +138,7 @@ func TestETradeClient_Authenticate(t *testing.T) {
 				clientMock.On(
 					"Do", "GET", "https://api.etrade.com/oauth/renew_access_token",
 				).Return(http.StatusUnauthorized, "", nil)
-				configMock.On("RequestToken").Return("", "", errors.New("test error"))
+				configMock.On("RequestToken", context.Background()).Return("", "", errors.New("test error"))
 				return testClient.Authenticate()
 			},
 			expectResponse: nil,
@@ -140,7 +186,7 @@ func TestETradeClient_Verify(t *testing.T) {
 				[]byte, error,
 			) {
 				configMock.On(
-					"AccessToken", "TestRequestToken", "TestRequestSecret", "TestVerifyKey",
+					"AccessToken", context.Background(), "TestRequestToken", "TestRequestSecret", "TestVerifyKey",
 				).Return("TestAccessToken", "TestAccessSecret", nil)
 				configMock.On(
 					"Client", oauth1.NoContext, oauth1.NewToken("TestAccessToken", "TestAccessSecret"),
@@ -158,7 +204,7 @@ func TestETradeClient_Verify(t *testing.T) {
 				[]byte, error,
 			) {
 				configMock.On(
-					"AccessToken", "TestRequestToken", "TestRequestSecret", "TestVerifyKey",
+					"AccessToken", context.Background(), "TestRequestToken", "TestRequestSecret", "TestVerifyKey",
 				).Return("", "", errors.New("test error"))
 				return testClient.Verify("TestVerifyKey")
 			},
@@ -194,6 +240,73 @@ func TestETradeClient_Verify(t *testing.T) {
 	}
 }
 
+func TestETradeClient_RevokeAccessToken(t *testing.T) {
+	type testFn func(testClient ETradeClient, clientMock *httpClientMock, configMock *oAuthConfigMock) ([]byte, error)
+
+	tests := []struct {
+		name              string
+		testFn            testFn
+		expectResponse    []byte
+		expectErr         bool
+		expectAccessToken string
+	}{
+		{
+			name: "Revoke Access Token Succeeds",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock, configMock *oAuthConfigMock) (
+				[]byte, error,
+			) {
+				clientMock.On(
+					"Do", "GET", "https://api.etrade.com/oauth/revoke_access_token",
+				).Return(http.StatusOK, "", nil)
+				configMock.On("Client", oauth1.NoContext, oauth1.NewToken("", "")).Return(&http.Client{}, nil)
+				return testClient.RevokeAccessToken()
+			},
+			expectResponse:    []byte(`{"status":"success"}` + "\n"),
+			expectErr:         false,
+			expectAccessToken: "",
+		},
+		{
+			name: "Revoke Access Token Fails On HTTP Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock, configMock *oAuthConfigMock) (
+				[]byte, error,
+			) {
+				clientMock.On(
+					"Do", "GET", "https://api.etrade.com/oauth/revoke_access_token",
+				).Return(http.StatusUnauthorized, "", nil)
+				return testClient.RevokeAccessToken()
+			},
+			expectResponse:    nil,
+			expectErr:         true,
+			expectAccessToken: "TestAccessToken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				configMock := new(oAuthConfigMock)
+				clientMock := new(httpClientMock)
+				testClient := createMockClient(
+					clientMock, configMock, true, "TestConsumerKey", "TestConsumerSecret", "", "", "TestAccessToken",
+					"TestAccessSecret",
+				)
+				// Call the Method Under Test
+				actualResponse, err := tt.testFn(testClient, clientMock, configMock)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectResponse, actualResponse)
+				_, _, actualAccessToken, _ := testClient.GetKeys()
+				assert.Equal(t, tt.expectAccessToken, actualAccessToken)
+				clientMock.AssertExpectations(t)
+				configMock.AssertExpectations(t)
+			},
+		)
+	}
+}
+
 func TestETradeClient_GetKeys(t *testing.T) {
 	expectedConsumerKey := "TestConsumerKey"
 	expectedConsumerSecret := "TestConsumerSecret"
@@ -211,6 +324,95 @@ func TestETradeClient_GetKeys(t *testing.T) {
 	assert.Equal(t, expectedAccessSecret, actualAccessSecret)
 }
 
+func TestETradeClient_WithContext(t *testing.T) {
+	t.Run(
+		"Request Fails When Context Is Cancelled", func(t *testing.T) {
+			testClient := createMockClient(
+				&http.Client{}, nil, true, "TestConsumerKey", "TestConsumerSecret", "", "", "TestAccessToken",
+				"TestAccessSecret",
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			// Call the Method Under Test
+			actualResponse, err := testClient.WithContext(ctx).ListAccounts()
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Nil(t, actualResponse)
+		},
+	)
+
+	t.Run(
+		"Client With Context Shares Authentication", func(t *testing.T) {
+			configMock := new(oAuthConfigMock)
+			configMock.On(
+				"AccessToken", context.Background(), "TestRequestToken", "TestRequestSecret", "TestVerifyKey",
+			).Return("TestAccessToken", "TestAccessSecret", nil)
+			configMock.On(
+				"Client", oauth1.NoContext, oauth1.NewToken("TestAccessToken", "TestAccessSecret"),
+			).Return(&http.Client{}, nil)
+			testClient := createMockClient(
+				nil, configMock, true, "TestConsumerKey", "TestConsumerSecret", "TestRequestToken",
+				"TestRequestSecret", "", "",
+			)
+			// Call the Method Under Test
+			_, err := testClient.WithContext(context.Background()).Verify("TestVerifyKey")
+			assert.Nil(t, err)
+			_, _, actualAccessToken, actualAccessSecret := testClient.GetKeys()
+			assert.Equal(t, "TestAccessToken", actualAccessToken)
+			assert.Equal(t, "TestAccessSecret", actualAccessSecret)
+			configMock.AssertExpectations(t)
+		},
+	)
+}
+
+func TestETradeClient_IsSafeForConcurrentUse(t *testing.T) {
+	clientMock := new(httpClientMock)
+	clientMock.On("Do", "GET", "https://api.etrade.com/v1/accounts/list").Return(http.StatusOK, "{}", nil)
+	verifiedClientMock := new(httpClientMock)
+	verifiedClientMock.On("Do", "GET", "https://api.etrade.com/v1/accounts/list").Return(http.StatusOK, "{}", nil)
+	configMock := new(oAuthConfigMock)
+	configMock.On(
+		"AccessToken", context.Background(), "TestRequestToken", "TestRequestSecret", "TestVerifyKey",
+	).Return("NewAccessToken", "NewAccessSecret", nil)
+	configMock.On(
+		"Client", oauth1.NoContext, oauth1.NewToken("NewAccessToken", "NewAccessSecret"),
+	).Return(&http.Client{Transport: &httpClientTransport{httpClient: verifiedClientMock}}, nil)
+	testClient := createMockClient(
+		clientMock, configMock, true, "TestConsumerKey", "TestConsumerSecret", "TestRequestToken",
+		"TestRequestSecret", "OldAccessToken", "OldAccessSecret",
+	)
+
+	// Call the Methods Under Test
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			_, err := testClient.WithContext(context.Background()).ListAccounts()
+			assert.Nil(t, err)
+		}()
+		go func() {
+			defer waitGroup.Done()
+			// The keys must change together, so the new token is never seen
+			// with the old secret.
+			_, _, accessToken, accessSecret := testClient.GetKeys()
+			assert.Contains(
+				t, []string{"OldAccessToken/OldAccessSecret", "NewAccessToken/NewAccessSecret"},
+				accessToken+"/"+accessSecret,
+			)
+		}()
+	}
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		_, err := testClient.Verify("TestVerifyKey")
+		assert.Nil(t, err)
+	}()
+	waitGroup.Wait()
+
+	_, _, actualAccessToken, _ := testClient.GetKeys()
+	assert.Equal(t, "NewAccessToken", actualAccessToken)
+}
+
 func TestETradeClient(t *testing.T) {
 	type testFn func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error)
 
@@ -235,6 +437,28 @@ func TestETradeClient(t *testing.T) {
 			expectResponse: []byte(testResponseData),
 			expectErr:      false,
 		},
+		{
+			name: "Renew Access Token",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", "https://api.etrade.com/oauth/renew_access_token").Return(
+					http.StatusOK, "", nil,
+				)
+				return testClient.RenewAccessToken()
+			},
+			expectResponse: []byte(`{"status":"success"}` + "\n"),
+			expectErr:      false,
+		},
+		{
+			name: "Renew Access Token Fails On Auth Failure",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", "https://api.etrade.com/oauth/renew_access_token").Return(
+					http.StatusUnauthorized, "", nil,
+				)
+				return testClient.RenewAccessToken()
+			},
+			expectResponse: []byte(nil),
+			expectErr:      true,
+		},
 		{
 			name: "List Accounts Fails On HTTP Error",
 			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
@@ -875,6 +1099,192 @@ func TestETradeClient(t *testing.T) {
 			expectResponse: nil,
 			expectErr:      true,
 		},
+		{
+			name: "Preview Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "POST", "https://api.etrade.com/v1/accounts/1234/orders/preview",
+				).Return(http.StatusOK, testResponseData, nil)
+
+				return testClient.PreviewOrder("1234", createTestOrderRequest())
+			},
+			expectResponse: []byte(testResponseData),
+			expectErr:      false,
+		},
+		{
+			name: "Preview Order Fails On HTTP Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "POST", "https://api.etrade.com/v1/accounts/1234/orders/preview",
+				).Return(0, "", errors.New("test error"))
+
+				return testClient.PreviewOrder("1234", createTestOrderRequest())
+			},
+			expectResponse: []byte(nil),
+			expectErr:      true,
+		},
+		{
+			name: "Preview Order Fails Without Account ID Key",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.PreviewOrder("", createTestOrderRequest())
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Preview Order Fails With Invalid Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				order := createTestOrderRequest()
+				order.LimitPrice = decimal.Zero
+				return testClient.PreviewOrder("1234", order)
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Place Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "POST", "https://api.etrade.com/v1/accounts/1234/orders/place",
+				).Return(http.StatusOK, testResponseData, nil)
+
+				return testClient.PlaceOrder("1234", 5678, createTestOrderRequest())
+			},
+			expectResponse: []byte(testResponseData),
+			expectErr:      false,
+		},
+		{
+			name: "Place Order Fails On HTTP Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "POST", "https://api.etrade.com/v1/accounts/1234/orders/place",
+				).Return(0, "", errors.New("test error"))
+
+				return testClient.PlaceOrder("1234", 5678, createTestOrderRequest())
+			},
+			expectResponse: []byte(nil),
+			expectErr:      true,
+		},
+		{
+			name: "Place Order Fails Without Preview ID",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.PlaceOrder("1234", 0, createTestOrderRequest())
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Cancel Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "PUT", "https://api.etrade.com/v1/accounts/1234/orders/cancel",
+				).Return(http.StatusOK, testResponseData, nil)
+
+				return testClient.CancelOrder("1234", 5678)
+			},
+			expectResponse: []byte(testResponseData),
+			expectErr:      false,
+		},
+		{
+			name: "Cancel Order Fails On HTTP Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "PUT", "https://api.etrade.com/v1/accounts/1234/orders/cancel",
+				).Return(0, "", errors.New("test error"))
+
+				return testClient.CancelOrder("1234", 5678)
+			},
+			expectResponse: []byte(nil),
+			expectErr:      true,
+		},
+		{
+			name: "Cancel Order Fails Without Account ID Key",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.CancelOrder("", 5678)
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Cancel Order Fails Without Order ID",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.CancelOrder("1234", 0)
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Preview Changed Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "PUT", "https://api.etrade.com/v1/accounts/1234/orders/5678/change/preview",
+				).Return(http.StatusOK, testResponseData, nil)
+
+				return testClient.PreviewChangedOrder("1234", 5678, createTestOrderRequest())
+			},
+			expectResponse: []byte(testResponseData),
+			expectErr:      false,
+		},
+		{
+			name: "Preview Changed Order Fails On HTTP Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "PUT", "https://api.etrade.com/v1/accounts/1234/orders/5678/change/preview",
+				).Return(0, "", errors.New("test error"))
+
+				return testClient.PreviewChangedOrder("1234", 5678, createTestOrderRequest())
+			},
+			expectResponse: []byte(nil),
+			expectErr:      true,
+		},
+		{
+			name: "Preview Changed Order Fails Without Order ID",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.PreviewChangedOrder("1234", 0, createTestOrderRequest())
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Preview Changed Order Fails With Invalid Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.PreviewChangedOrder("1234", 5678, &OrderRequest{})
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
+		{
+			name: "Place Changed Order",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "PUT", "https://api.etrade.com/v1/accounts/1234/orders/5678/change/place",
+				).Return(http.StatusOK, testResponseData, nil)
+
+				return testClient.PlaceChangedOrder("1234", 5678, 9012, createTestOrderRequest())
+			},
+			expectResponse: []byte(testResponseData),
+			expectErr:      false,
+		},
+		{
+			name: "Place Changed Order Fails On HTTP Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On(
+					"Do", "PUT", "https://api.etrade.com/v1/accounts/1234/orders/5678/change/place",
+				).Return(0, "", errors.New("test error"))
+
+				return testClient.PlaceChangedOrder("1234", 5678, 9012, createTestOrderRequest())
+			},
+			expectResponse: []byte(nil),
+			expectErr:      true,
+		},
+		{
+			name: "Place Changed Order Fails Without Preview ID",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				return testClient.PlaceChangedOrder("1234", 5678, 0, createTestOrderRequest())
+			},
+			expectResponse: nil,
+			expectErr:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -887,6 +1297,14 @@ func TestETradeClient(t *testing.T) {
 				actualResponse, err := tt.testFn(testClient, clientMock)
 				if tt.expectErr {
 					assert.Error(t, err)
+					// A method that fails without sending a request must have
+					// rejected one of its arguments or the order.
+					if len(clientMock.Calls) == 0 {
+						assert.True(
+							t, IsInvalidArgument(err) || IsInvalidOrder(err), "expected an invalid argument error: %v",
+							err,
+						)
+					}
 				} else {
 					assert.Nil(t, err)
 				}
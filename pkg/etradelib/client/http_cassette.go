@@ -0,0 +1,269 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Cassette holds the request/response pairs recorded by a RecordingHttpClient
+// and served by a ReplayHttpClient.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+func LoadCassette(reader io.Reader) (*Cassette, error) {
+	cassette := Cassette{}
+	if err := json.NewDecoder(reader).Decode(&cassette); err != nil {
+		return nil, err
+	}
+	return &cassette, nil
+}
+
+func LoadCassetteFromFile(filename string) (*Cassette, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return LoadCassette(file)
+}
+
+func SaveCassette(writer io.Writer, cassette *Cassette) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(cassette)
+}
+
+func SaveCassetteToFile(filename string, cassette *Cassette) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err = SaveCassette(file, cassette); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+const (
+	// scrubbedValue replaces secrets that a replayed request never needs
+	// (e.g. OAuth signatures and tokens).
+	scrubbedValue = "REDACTED"
+
+	// scrubbedAccountIdBase is added to the count of scrubbed account IDs to
+	// make their placeholders.
+	scrubbedAccountIdBase = 90000000
+
+	accountIdKey    = "accountId"
+	accountIdKeyKey = "accountIdKey"
+)
+
+// scrubbedHeaders are the headers whose values are replaced in recordings.
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// scrubbedFormKeys are the keys of URL-encoded form values (e.g. the tokens
+// in OAuth token responses) that are replaced in recordings.
+var scrubbedFormKeys = []string{"oauth_token", "oauth_token_secret", "oauth_verifier"}
+
+// cassetteScrubber removes OAuth credentials and account IDs from recorded
+// interactions. Each account ID and account ID key is replaced with a
+// placeholder that is the same everywhere it appears, so requests made with a
+// placeholder from a replayed response match the recorded requests.
+type cassetteScrubber struct {
+	replacements map[string]string
+	accountCount int
+	keyCount     int
+}
+
+func newCassetteScrubber() *cassetteScrubber {
+	return &cassetteScrubber{replacements: map[string]string{}}
+}
+
+func (s *cassetteScrubber) scrubInteraction(interaction *CassetteInteraction) {
+	// Learn the account IDs from both bodies before scrubbing anything, so
+	// that IDs are scrubbed from the request even when the response is the
+	// first place they appear.
+	s.learnUrl(interaction.Request.Url)
+	s.learnBody(interaction.Request.Body)
+	s.learnBody(interaction.Response.Body)
+
+	interaction.Request.Url = s.scrubUrl(interaction.Request.Url)
+	interaction.Request.Header = scrubHeader(interaction.Request.Header)
+	interaction.Request.Body = s.scrubBody(interaction.Request.Body)
+	interaction.Response.Header = scrubHeader(interaction.Response.Header)
+	interaction.Response.Body = s.scrubBody(interaction.Response.Body)
+}
+
+// learnUrl learns the account ID key in an ETrade accounts URL (e.g.
+// /v1/accounts/{accountIdKey}/portfolio).
+func (s *cassetteScrubber) learnUrl(rawUrl string) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return
+	}
+	segments := strings.Split(parsedUrl.Path, "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "accounts" && segments[i+1] != "list" && segments[i+1] != "" {
+			s.learn(accountIdKeyKey, segments[i+1])
+		}
+	}
+}
+
+func (s *cassetteScrubber) learnBody(body string) {
+	if value, ok := decodeJsonBody(body); ok {
+		s.learnJsonValue(value)
+	}
+}
+
+func (s *cassetteScrubber) learnJsonValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if key == accountIdKey || key == accountIdKeyKey {
+				s.learn(key, jsonScalarString(item))
+			}
+			s.learnJsonValue(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			s.learnJsonValue(item)
+		}
+	}
+}
+
+func (s *cassetteScrubber) learn(key string, value string) {
+	if value == "" {
+		return
+	}
+	if _, found := s.replacements[value]; found {
+		return
+	}
+	if key == accountIdKey {
+		s.accountCount++
+		// Account IDs are replaced with numbers, so that they're still valid
+		// wherever ETrade returns them as numbers.
+		s.replacements[value] = fmt.Sprintf("%d", scrubbedAccountIdBase+s.accountCount)
+	} else {
+		s.keyCount++
+		s.replacements[value] = fmt.Sprintf("scrubbedAccountIdKey%d", s.keyCount)
+	}
+}
+
+func (s *cassetteScrubber) scrubUrl(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return rawUrl
+	}
+	segments := strings.Split(parsedUrl.Path, "/")
+	for i, segment := range segments {
+		if replacement, found := s.replacements[segment]; found {
+			segments[i] = replacement
+		}
+	}
+	parsedUrl.Path = strings.Join(segments, "/")
+	parsedUrl.RawPath = ""
+	return parsedUrl.String()
+}
+
+func (s *cassetteScrubber) scrubBody(body string) string {
+	if value, ok := decodeJsonBody(body); ok {
+		scrubbedBody, err := json.Marshal(s.scrubJsonValue(value))
+		if err == nil {
+			return string(scrubbedBody)
+		}
+	}
+	if formValues, err := url.ParseQuery(body); err == nil && formValues.Has("oauth_token") {
+		for _, key := range scrubbedFormKeys {
+			if formValues.Has(key) {
+				formValues.Set(key, scrubbedValue)
+			}
+		}
+		return formValues.Encode()
+	}
+	for value, replacement := range s.replacements {
+		body = strings.ReplaceAll(body, value, replacement)
+	}
+	return body
+}
+
+func (s *cassetteScrubber) scrubJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = s.scrubJsonValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = s.scrubJsonValue(item)
+		}
+	case string:
+		if replacement, found := s.replacements[v]; found {
+			return replacement
+		}
+	case json.Number:
+		if replacement, found := s.replacements[v.String()]; found {
+			return json.Number(replacement)
+		}
+	}
+	return value
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, key := range scrubbedHeaders {
+		if scrubbed.Get(key) != "" {
+			scrubbed.Set(key, scrubbedValue)
+		}
+	}
+	return scrubbed
+}
+
+// decodeJsonBody decodes a JSON body, keeping numbers exactly as they appear.
+func decodeJsonBody(body string) (interface{}, bool) {
+	trimmedBody := strings.TrimSpace(body)
+	if !strings.HasPrefix(trimmedBody, "{") && !strings.HasPrefix(trimmedBody, "[") {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(trimmedBody)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func jsonScalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
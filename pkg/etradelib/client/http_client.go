@@ -5,3 +5,14 @@ import "net/http"
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
+
+// httpClientTransport lets an HttpClient be used as the transport of an
+// http.Client, so that requests can be sent through it after the OAuth
+// transport signs them.
+type httpClientTransport struct {
+	httpClient HttpClient
+}
+
+func (t *httpClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.httpClient.Do(req)
+}
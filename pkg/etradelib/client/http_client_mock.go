@@ -17,9 +17,15 @@ func (m *httpClientMock) Do(req *http.Request) (*http.Response, error) {
 	responseCode := args.Int(0)
 	responseBody := args.String(1)
 	err := args.Error(2)
+	// Response headers are optional
+	responseHeader := http.Header{}
+	if len(args) > 3 {
+		responseHeader = args.Get(3).(http.Header)
+	}
 
 	response := &http.Response{
 		StatusCode: responseCode,
+		Header:     responseHeader,
 		Body:       io.NopCloser(strings.NewReader(responseBody)),
 	}
 	return response, err
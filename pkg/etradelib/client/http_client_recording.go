@@ -0,0 +1,88 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RecordingHttpClient sends requests with another HttpClient and records each
+// request/response pair to a cassette file, with OAuth credentials and
+// account IDs scrubbed. The file is rewritten after every request, so it
+// holds a complete cassette even if the program exits early.
+type RecordingHttpClient struct {
+	httpClient HttpClient
+	filename   string
+	mutex      sync.Mutex
+	cassette   Cassette
+	scrubber   *cassetteScrubber
+}
+
+// NewRecordingHttpClient creates a client that sends requests with the given
+// HttpClient (or http.DefaultClient if it is nil) and records them to a new
+// cassette file, replacing any existing file.
+func NewRecordingHttpClient(httpClient HttpClient, filename string) (*RecordingHttpClient, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c := &RecordingHttpClient{
+		httpClient: httpClient,
+		filename:   filename,
+		cassette:   Cassette{Interactions: []CassetteInteraction{}},
+		scrubber:   newCassetteScrubber(),
+	}
+	// Write the empty cassette now, so that a bad filename is reported before
+	// any requests are sent.
+	if err := SaveCassetteToFile(filename, &c.cassette); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *RecordingHttpClient) Do(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			Url:    req.URL.String(),
+			Header: req.Header,
+			Body:   string(requestBody),
+		},
+		Response: CassetteResponse{
+			StatusCode: response.StatusCode,
+			Header:     response.Header,
+			Body:       string(responseBody),
+		},
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.scrubber.scrubInteraction(&interaction)
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+	if err = SaveCassetteToFile(c.filename, &c.cassette); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
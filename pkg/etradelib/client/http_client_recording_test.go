@@ -0,0 +1,128 @@
+package client
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	testRecordedAccountList = `{"AccountListResponse":{"Accounts":{"Account":[{"accountDesc":"Brokerage","accountId":"84910001","accountIdKey":"realAccountKey"}]}}}`
+	testRecordedBalance     = `{"BalanceResponse":{"accountId":"84910001","accountType":"CASH"}}`
+)
+
+// newRecordedETradeServer returns a server that answers a few ETrade API
+// requests with responses that contain account IDs.
+func newRecordedETradeServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/v1/accounts/list", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testRecordedAccountList))
+		},
+	)
+	mux.HandleFunc(
+		"/v1/accounts/realAccountKey/balance", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testRecordedBalance))
+		},
+	)
+	mux.HandleFunc(
+		"/oauth/renew_access_token", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		},
+	)
+	mux.HandleFunc(
+		"/oauth/request_token", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+			_, _ = w.Write([]byte("oauth_token=realToken&oauth_token_secret=realSecret&oauth_callback_confirmed=true"))
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// recordTestSession records a session that lists accounts and gets the
+// balance of the first account, and returns the cassette's filename.
+func recordTestSession(t *testing.T) string {
+	server := newRecordedETradeServer(t)
+	filename := filepath.Join(t.TempDir(), "cassette.json")
+	recordingClient, err := NewRecordingHttpClient(nil, filename)
+	require.Nil(t, err)
+	urls, err := NewEndpointUrls(server.URL, "")
+	require.Nil(t, err)
+	testClient, err := CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "consumerKey", "consumerSecret", "accessToken", "accessSecret",
+		RetryPolicy{}, nil, recordingClient,
+	)
+	require.Nil(t, err)
+
+	_, err = testClient.ListAccounts()
+	require.Nil(t, err)
+	_, err = testClient.GetAccountBalances("realAccountKey", true)
+	require.Nil(t, err)
+	return filename
+}
+
+func TestRecordingHttpClient_RecordsScrubbedInteractions(t *testing.T) {
+	// Call the Method Under Test
+	filename := recordTestSession(t)
+
+	cassette, err := LoadCassetteFromFile(filename)
+	require.Nil(t, err)
+	require.Len(t, cassette.Interactions, 2)
+
+	listAccounts := cassette.Interactions[0]
+	assert.Equal(t, "GET", listAccounts.Request.Method)
+	assert.Equal(t, "REDACTED", listAccounts.Request.Header.Get("Authorization"))
+	assert.Equal(t, http.StatusOK, listAccounts.Response.StatusCode)
+	assert.JSONEq(
+		t,
+		`{"AccountListResponse":{"Accounts":{"Account":[{"accountDesc":"Brokerage","accountId":"90000001","accountIdKey":"scrubbedAccountIdKey1"}]}}}`,
+		listAccounts.Response.Body,
+	)
+
+	getBalances := cassette.Interactions[1]
+	assert.Contains(t, getBalances.Request.Url, "/v1/accounts/scrubbedAccountIdKey1/balance?")
+	assert.NotContains(t, getBalances.Request.Url, "realAccountKey")
+	assert.Equal(t, "REDACTED", getBalances.Request.Header.Get("Authorization"))
+	assert.JSONEq(t, `{"BalanceResponse":{"accountId":"90000001","accountType":"CASH"}}`, getBalances.Response.Body)
+}
+
+func TestRecordingHttpClient_ScrubsOAuthTokens(t *testing.T) {
+	server := newRecordedETradeServer(t)
+	filename := filepath.Join(t.TempDir(), "cassette.json")
+	recordingClient, err := NewRecordingHttpClient(nil, filename)
+	require.Nil(t, err)
+	urls, err := NewEndpointUrls(server.URL, "")
+	require.Nil(t, err)
+	testClient, err := CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "consumerKey", "consumerSecret", "", "", RetryPolicy{}, nil,
+		recordingClient,
+	)
+	require.Nil(t, err)
+
+	// Call the Method Under Test
+	_, err = testClient.Authenticate()
+	require.Nil(t, err)
+
+	cassette, err := LoadCassetteFromFile(filename)
+	require.Nil(t, err)
+	require.Len(t, cassette.Interactions, 2)
+	requestToken := cassette.Interactions[1]
+	assert.Equal(t, "POST", requestToken.Request.Method)
+	assert.Equal(
+		t, "oauth_callback_confirmed=true&oauth_token=REDACTED&oauth_token_secret=REDACTED",
+		requestToken.Response.Body,
+	)
+}
+
+func TestNewRecordingHttpClient_FailsWithBadFilename(t *testing.T) {
+	// Call the Method Under Test
+	_, err := NewRecordingHttpClient(nil, filepath.Join(t.TempDir(), "missing", "cassette.json"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ReplayHttpClient serves responses from a cassette recorded by a
+// RecordingHttpClient instead of sending requests. A request is answered with
+// the next unused interaction that has the same method and URL (ignoring the
+// host, so a cassette recorded against one server can be replayed with
+// another's URLs). Once every matching interaction has been used, the last
+// one is served again.
+type ReplayHttpClient struct {
+	mutex        sync.Mutex
+	interactions []CassetteInteraction
+	used         []bool
+}
+
+// NewReplayHttpClient creates a client that serves responses from the given
+// cassette file.
+func NewReplayHttpClient(filename string) (*ReplayHttpClient, error) {
+	cassette, err := LoadCassetteFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayHttpClient{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+func (c *ReplayHttpClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	requestKey := replayKey(req.Method, req.URL)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lastMatch := -1
+	for i, interaction := range c.interactions {
+		recordedUrl, err := url.Parse(interaction.Request.Url)
+		if err != nil || replayKey(interaction.Request.Method, recordedUrl) != requestKey {
+			continue
+		}
+		lastMatch = i
+		if !c.used[i] {
+			c.used[i] = true
+			return newReplayedResponse(req, &interaction.Response), nil
+		}
+	}
+	if lastMatch >= 0 {
+		return newReplayedResponse(req, &c.interactions[lastMatch].Response), nil
+	}
+	return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.String())
+}
+
+// replayKey identifies a request by its method, path, and sorted query
+// values.
+func replayKey(method string, requestUrl *url.URL) string {
+	return method + " " + requestUrl.Path + "?" + requestUrl.Query().Encode()
+}
+
+func newReplayedResponse(req *http.Request, recorded *CassetteResponse) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplayHttpClient_ReplaysRecordedSession(t *testing.T) {
+	filename := recordTestSession(t)
+	replayClient, err := NewReplayHttpClient(filename)
+	require.Nil(t, err)
+	// The recording server is gone, so use a base URL that would fail if the
+	// client sent requests.
+	urls, err := NewEndpointUrls("http://replay.invalid", "")
+	require.Nil(t, err)
+	testClient, err := CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "consumerKey", "consumerSecret", "accessToken", "accessSecret",
+		RetryPolicy{}, nil, replayClient,
+	)
+	require.Nil(t, err)
+
+	// Call the Method Under Test
+	accountList, err := testClient.ListAccounts()
+	require.Nil(t, err)
+	balances, err := testClient.GetAccountBalances("scrubbedAccountIdKey1", true)
+	require.Nil(t, err)
+
+	assert.JSONEq(
+		t,
+		`{"AccountListResponse":{"Accounts":{"Account":[{"accountDesc":"Brokerage","accountId":"90000001","accountIdKey":"scrubbedAccountIdKey1"}]}}}`,
+		string(accountList),
+	)
+	assert.JSONEq(t, `{"BalanceResponse":{"accountId":"90000001","accountType":"CASH"}}`, string(balances))
+
+	// Requests that weren't recorded fail.
+	_, err = testClient.GetAccountBalances("realAccountKey", true)
+	assert.ErrorContains(t, err, "no recorded response for GET")
+}
+
+func TestReplayHttpClient_ServesInteractionsInOrder(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := &Cassette{
+		Interactions: []CassetteInteraction{
+			{
+				Request:  CassetteRequest{Method: "GET", Url: "https://api.etrade.com/v1/accounts/list"},
+				Response: CassetteResponse{StatusCode: http.StatusOK, Body: "first"},
+			},
+			{
+				Request:  CassetteRequest{Method: "GET", Url: "https://api.etrade.com/v1/accounts/list"},
+				Response: CassetteResponse{StatusCode: http.StatusTooManyRequests, Body: "second"},
+			},
+		},
+	}
+	require.Nil(t, SaveCassetteToFile(filename, cassette))
+	replayClient, err := NewReplayHttpClient(filename)
+	require.Nil(t, err)
+
+	expectResponses := []struct {
+		statusCode int
+		body       string
+	}{
+		{http.StatusOK, "first"},
+		{http.StatusTooManyRequests, "second"},
+		// Once every matching interaction has been used, the last is served
+		// again.
+		{http.StatusTooManyRequests, "second"},
+	}
+	for _, expectResponse := range expectResponses {
+		req, err := http.NewRequest("GET", "http://localhost:8889/v1/accounts/list", nil)
+		require.Nil(t, err)
+
+		// Call the Method Under Test
+		response, err := replayClient.Do(req)
+		require.Nil(t, err)
+
+		body, err := io.ReadAll(response.Body)
+		require.Nil(t, err)
+		assert.Equal(t, expectResponse.statusCode, response.StatusCode)
+		assert.Equal(t, expectResponse.body, string(body))
+	}
+}
+
+func TestNewReplayHttpClient_FailsWithMissingCassette(t *testing.T) {
+	// Call the Method Under Test
+	_, err := NewReplayHttpClient(filepath.Join(t.TempDir(), "cassette.json"))
+
+	assert.Error(t, err)
+}
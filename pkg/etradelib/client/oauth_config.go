@@ -9,7 +9,48 @@ import (
 
 type OAuthConfig interface {
 	Client(ctx context.Context, t *oauth1.Token) *http.Client
-	RequestToken() (requestToken, requestSecret string, err error)
+	RequestToken(ctx context.Context) (requestToken, requestSecret string, err error)
 	AuthorizationURL(requestToken string) (*url.URL, error)
-	AccessToken(requestToken, requestSecret, verifier string) (accessToken, accessSecret string, err error)
+	AccessToken(ctx context.Context, requestToken, requestSecret, verifier string) (
+		accessToken, accessSecret string, err error,
+	)
+}
+
+// contextOAuthConfig is an OAuthConfig that sends the requests for tokens
+// with a context, which oauth1.Config doesn't support on its own.
+type contextOAuthConfig struct {
+	*oauth1.Config
+}
+
+func (c *contextOAuthConfig) RequestToken(ctx context.Context) (requestToken, requestSecret string, err error) {
+	return c.withContext(ctx).RequestToken()
+}
+
+func (c *contextOAuthConfig) AccessToken(ctx context.Context, requestToken, requestSecret, verifier string) (
+	accessToken, accessSecret string, err error,
+) {
+	return c.withContext(ctx).AccessToken(requestToken, requestSecret, verifier)
+}
+
+// withContext returns a copy of the config whose requests for tokens are sent
+// with ctx, through the config's HTTP client if it has one.
+func (c *contextOAuthConfig) withContext(ctx context.Context) *oauth1.Config {
+	config := *c.Config
+	transport := http.DefaultTransport
+	if config.HTTPClient != nil && config.HTTPClient.Transport != nil {
+		transport = config.HTTPClient.Transport
+	}
+	config.HTTPClient = &http.Client{Transport: &contextTransport{ctx: ctx, transport: transport}}
+	return &config
+}
+
+// contextTransport sends every request through another transport with a
+// given context.
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req.WithContext(t.ctx))
 }
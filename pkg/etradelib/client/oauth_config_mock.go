@@ -17,8 +17,8 @@ func (m *oAuthConfigMock) Client(ctx context.Context, t *oauth1.Token) *http.Cli
 	return args.Get(0).(*http.Client)
 }
 
-func (m *oAuthConfigMock) RequestToken() (requestToken, requestSecret string, err error) {
-	args := m.Called()
+func (m *oAuthConfigMock) RequestToken(ctx context.Context) (requestToken, requestSecret string, err error) {
+	args := m.Called(ctx)
 	return args.String(0), args.String(1), args.Error(2)
 }
 
@@ -27,9 +27,9 @@ func (m *oAuthConfigMock) AuthorizationURL(requestToken string) (*url.URL, error
 	return args.Get(0).(*url.URL), args.Error(1)
 }
 
-func (m *oAuthConfigMock) AccessToken(requestToken, requestSecret, verifier string) (
+func (m *oAuthConfigMock) AccessToken(ctx context.Context, requestToken, requestSecret, verifier string) (
 	accessToken, accessSecret string, err error,
 ) {
-	args := m.Called(requestToken, requestSecret, verifier)
+	args := m.Called(ctx, requestToken, requestSecret, verifier)
 	return args.String(0), args.String(1), args.Error(2)
 }
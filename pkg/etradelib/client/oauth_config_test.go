@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"github.com/dghubble/oauth1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextOAuthConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		cancelCtx bool
+		testFn    func(config OAuthConfig, ctx context.Context) (string, string, error)
+	}{
+		{
+			name: "RequestToken Succeeds",
+			testFn: func(config OAuthConfig, ctx context.Context) (string, string, error) {
+				return config.RequestToken(ctx)
+			},
+		},
+		{
+			name:      "RequestToken Fails With Cancelled Context",
+			cancelCtx: true,
+			testFn: func(config OAuthConfig, ctx context.Context) (string, string, error) {
+				return config.RequestToken(ctx)
+			},
+		},
+		{
+			name: "AccessToken Succeeds",
+			testFn: func(config OAuthConfig, ctx context.Context) (string, string, error) {
+				return config.AccessToken(ctx, "TestRequestToken", "TestRequestSecret", "TestVerifier")
+			},
+		},
+		{
+			name:      "AccessToken Fails With Cancelled Context",
+			cancelCtx: true,
+			testFn: func(config OAuthConfig, ctx context.Context) (string, string, error) {
+				return config.AccessToken(ctx, "TestRequestToken", "TestRequestSecret", "TestVerifier")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				requested := false
+				server := httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							requested = true
+							w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+							_, _ = w.Write(
+								[]byte("oauth_token=TestToken&oauth_token_secret=TestSecret&oauth_callback_confirmed=true"),
+							)
+						},
+					),
+				)
+				defer server.Close()
+				config := &contextOAuthConfig{
+					Config: &oauth1.Config{
+						ConsumerKey:    "TestConsumerKey",
+						ConsumerSecret: "TestConsumerSecret",
+						CallbackURL:    "oob",
+						Endpoint: oauth1.Endpoint{
+							RequestTokenURL: server.URL + "/request_token",
+							AccessTokenURL:  server.URL + "/access_token",
+						},
+					},
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if tt.cancelCtx {
+					cancel()
+				}
+
+				// Call the Method Under Test
+				token, secret, err := tt.testFn(config, ctx)
+				if tt.cancelCtx {
+					assert.ErrorIs(t, err, context.Canceled)
+					assert.False(t, requested)
+				} else {
+					require.Nil(t, err)
+					assert.Equal(t, "TestToken", token)
+					assert.Equal(t, "TestSecret", secret)
+				}
+			},
+		)
+	}
+}
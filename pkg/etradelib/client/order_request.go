@@ -0,0 +1,379 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OrderRequest describes an order to preview or place.
+type OrderRequest struct {
+	// ClientOrderId is a caller-generated identifier for the order. ETrade
+	// requires the same ID for the preview and the subsequent placement.
+	ClientOrderId string
+	OrderType     constants.OrderType
+	PriceType     constants.OrderPriceType
+	OrderTerm     constants.OrderTerm
+	MarketSession constants.MarketSession
+	LimitPrice    decimal.Decimal
+	StopPrice     decimal.Decimal
+	AllOrNone     bool
+	Instruments   []OrderInstrument
+}
+
+// OrderInstrument describes a single security (or leg) within an order.
+type OrderInstrument struct {
+	SecurityType constants.OrderSecurityType
+	// Symbol is the equity symbol or, for options, the underlying symbol.
+	Symbol string
+
+	// The following fields apply only to options.
+	CallPut     constants.OptionCallPut
+	ExpiryYear  int
+	ExpiryMonth int
+	ExpiryDay   int
+	StrikePrice decimal.Decimal
+
+	OrderAction constants.OrderAction
+	Quantity    int
+}
+
+// occSymbolSuffixLength is the length of the portion of an OCC option
+// symbol that follows the root symbol: a six-digit YYMMDD expiration date,
+// a C or P, and an eight-digit strike price in thousandths of a dollar.
+const occSymbolSuffixLength = 15
+
+// occSymbolRootLength is the width to which the root symbol is padded in
+// an OCC option symbol.
+const occSymbolRootLength = 6
+
+// NewOptionInstrumentFromOccSymbol creates an option instrument from an OCC
+// option symbol such as "GOOG  240119C00150000". The padding between the
+// root symbol and the expiration date is optional.
+func NewOptionInstrumentFromOccSymbol(
+	occSymbol string, orderAction constants.OrderAction, quantity int,
+) (OrderInstrument, error) {
+	occSymbol = strings.TrimSpace(occSymbol)
+	if len(occSymbol) <= occSymbolSuffixLength {
+		return OrderInstrument{}, fmt.Errorf("'%s' is not a valid OCC option symbol", occSymbol)
+	}
+	root := strings.TrimSpace(occSymbol[:len(occSymbol)-occSymbolSuffixLength])
+	suffix := occSymbol[len(occSymbol)-occSymbolSuffixLength:]
+	if root == "" || len(root) > occSymbolRootLength {
+		return OrderInstrument{}, fmt.Errorf("'%s' is not a valid OCC option symbol", occSymbol)
+	}
+
+	expiry, err := time.Parse("060102", suffix[0:6])
+	if err != nil {
+		return OrderInstrument{}, fmt.Errorf("'%s' has an invalid expiration date", occSymbol)
+	}
+	var callPut constants.OptionCallPut
+	switch suffix[6] {
+	case 'C':
+		callPut = constants.OptionCallPutCall
+	case 'P':
+		callPut = constants.OptionCallPutPut
+	default:
+		return OrderInstrument{}, fmt.Errorf("'%s' must specify C or P after the expiration date", occSymbol)
+	}
+	strikeThousandths, err := strconv.ParseUint(suffix[7:], 10, 64)
+	if err != nil {
+		return OrderInstrument{}, fmt.Errorf("'%s' has an invalid strike price", occSymbol)
+	}
+
+	return OrderInstrument{
+		SecurityType: constants.OrderSecurityTypeOption,
+		Symbol:       root,
+		CallPut:      callPut,
+		ExpiryYear:   expiry.Year(),
+		ExpiryMonth:  int(expiry.Month()),
+		ExpiryDay:    expiry.Day(),
+		StrikePrice:  decimal.New(int64(strikeThousandths), 3),
+		OrderAction:  orderAction,
+		Quantity:     quantity,
+	}, nil
+}
+
+// OccSymbol returns the OCC option symbol for an option instrument, or the
+// plain symbol for any other instrument.
+func (i *OrderInstrument) OccSymbol() string {
+	if i.SecurityType != constants.OrderSecurityTypeOption {
+		return i.Symbol
+	}
+	callPut := "C"
+	if i.CallPut == constants.OptionCallPutPut {
+		callPut = "P"
+	}
+	strikeThousandths, _ := i.StrikePrice.Mul(decimal.NewFromInt(1000)).Round(0, decimal.RoundHalfUp).IntPart()
+	return fmt.Sprintf(
+		"%-*s%02d%02d%02d%s%08d", occSymbolRootLength, i.Symbol, i.ExpiryYear%100, i.ExpiryMonth, i.ExpiryDay,
+		callPut, strikeThousandths,
+	)
+}
+
+func (i *OrderInstrument) validate() error {
+	if i.Symbol == "" {
+		return errors.New("instrument symbol not provided")
+	}
+	if i.Quantity <= 0 {
+		return fmt.Errorf("quantity for %s must be greater than zero", i.OccSymbol())
+	}
+	switch i.SecurityType {
+	case constants.OrderSecurityTypeEquity:
+		switch i.OrderAction {
+		case constants.OrderActionBuy, constants.OrderActionSell, constants.OrderActionSellShort,
+			constants.OrderActionBuyToCover:
+		case constants.OrderActionNil:
+			return fmt.Errorf("order action not provided for %s", i.Symbol)
+		default:
+			return fmt.Errorf("order action %s is not valid for equity %s", i.OrderAction, i.Symbol)
+		}
+	case constants.OrderSecurityTypeOption:
+		if i.CallPut == constants.OptionCallPutNil {
+			return fmt.Errorf("call or put not provided for option on %s", i.Symbol)
+		}
+		expiry := time.Date(i.ExpiryYear, time.Month(i.ExpiryMonth), i.ExpiryDay, 0, 0, 0, 0, time.UTC)
+		if expiry.Year() != i.ExpiryYear || int(expiry.Month()) != i.ExpiryMonth || expiry.Day() != i.ExpiryDay {
+			return fmt.Errorf("invalid expiration date for option on %s", i.Symbol)
+		}
+		if !i.StrikePrice.IsPositive() {
+			return fmt.Errorf("strike price for option on %s must be greater than zero", i.Symbol)
+		}
+		switch i.OrderAction {
+		case constants.OrderActionBuyOpen, constants.OrderActionSellOpen, constants.OrderActionBuyClose,
+			constants.OrderActionSellClose:
+		case constants.OrderActionNil:
+			return fmt.Errorf("order action not provided for %s", i.OccSymbol())
+		default:
+			return fmt.Errorf("order action %s is not valid for option %s", i.OrderAction, i.OccSymbol())
+		}
+	case constants.OrderSecurityTypeNil:
+		return fmt.Errorf("security type not provided for %s", i.Symbol)
+	default:
+		return fmt.Errorf("security type %s is not supported for orders", i.SecurityType)
+	}
+	return nil
+}
+
+func (i *OrderInstrument) asJsonMap() jsonmap.JsonMap {
+	productMap := jsonmap.JsonMap{
+		"securityType": i.SecurityType.String(),
+		"symbol":       i.Symbol,
+	}
+	if i.SecurityType == constants.OrderSecurityTypeOption {
+		productMap["callPut"] = i.CallPut.String()
+		productMap["expiryYear"] = int64(i.ExpiryYear)
+		productMap["expiryMonth"] = int64(i.ExpiryMonth)
+		productMap["expiryDay"] = int64(i.ExpiryDay)
+		productMap["strikePrice"] = i.StrikePrice.JsonNumber()
+	}
+	return jsonmap.JsonMap{
+		"Product":      productMap,
+		"orderAction":  i.OrderAction.String(),
+		"quantityType": "QUANTITY",
+		"quantity":     int64(i.Quantity),
+	}
+}
+
+// clientOrderIdLength is the number of random bytes in a generated client
+// order ID. Each byte is hex-encoded as two characters, so this must be no
+// more than half of constants.ClientOrderIdMaxLength.
+const clientOrderIdLength = 8
+
+// NewClientOrderId generates a random client order ID.
+func NewClientOrderId() (string, error) {
+	idBytes := make([]byte, clientOrderIdLength)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(idBytes), nil
+}
+
+// ErrInvalidOrder is wrapped by every error returned by OrderRequest.Validate,
+// so that callers can tell a rejected order from a failed request.
+var ErrInvalidOrder = errors.New("invalid order")
+
+func IsInvalidOrder(err error) bool {
+	return errors.Is(err, ErrInvalidOrder)
+}
+
+// invalidOrderError marks a validation error as wrapping ErrInvalidOrder
+// without changing its message.
+type invalidOrderError struct {
+	err error
+}
+
+func (e *invalidOrderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidOrderError) Unwrap() []error {
+	return []error{ErrInvalidOrder, e.err}
+}
+
+// Validate checks that the order request has all the fields that its price
+// type and order type require. Any error it returns wraps ErrInvalidOrder.
+func (o *OrderRequest) Validate() error {
+	if err := o.validate(); err != nil {
+		return &invalidOrderError{err: err}
+	}
+	return nil
+}
+
+func (o *OrderRequest) validate() error {
+	if o.ClientOrderId == "" {
+		return errors.New("clientOrderId not provided")
+	}
+	if len(o.ClientOrderId) > constants.ClientOrderIdMaxLength {
+		return fmt.Errorf(
+			"clientOrderId '%s' exceeds the maximum of %d characters", o.ClientOrderId,
+			constants.ClientOrderIdMaxLength,
+		)
+	}
+	if o.OrderType == constants.OrderTypeNil {
+		return errors.New("orderType not provided")
+	}
+	if o.PriceType == constants.OrderPriceTypeNil {
+		return errors.New("priceType not provided")
+	}
+	if o.OrderTerm == constants.OrderTermNil {
+		return errors.New("orderTerm not provided")
+	}
+	if o.MarketSession == constants.MarketSessionNil {
+		return errors.New("marketSession not provided")
+	}
+	switch o.PriceType {
+	case constants.OrderPriceTypeLimit:
+		if !o.LimitPrice.IsPositive() {
+			return errors.New("limit orders require a limit price")
+		}
+	case constants.OrderPriceTypeStop:
+		if !o.StopPrice.IsPositive() {
+			return errors.New("stop orders require a stop price")
+		}
+	case constants.OrderPriceTypeStopLimit:
+		if !o.LimitPrice.IsPositive() || !o.StopPrice.IsPositive() {
+			return errors.New("stop limit orders require a limit price and a stop price")
+		}
+	case constants.OrderPriceTypeNetDebit, constants.OrderPriceTypeNetCredit:
+		if !o.LimitPrice.IsPositive() {
+			return errors.New("net debit and net credit orders require a limit price")
+		}
+	}
+	if len(o.Instruments) < 1 {
+		return errors.New("no instruments provided")
+	}
+	for _, instrument := range o.Instruments {
+		if err := instrument.validate(); err != nil {
+			return err
+		}
+	}
+	return o.validateLegs()
+}
+
+// validateLegs checks that the number and kind of instruments match the
+// order type.
+func (o *OrderRequest) validateLegs() error {
+	equityLegs, optionLegs := 0, 0
+	for _, instrument := range o.Instruments {
+		if instrument.SecurityType == constants.OrderSecurityTypeOption {
+			optionLegs++
+		} else {
+			equityLegs++
+		}
+	}
+
+	switch o.OrderType {
+	case constants.OrderTypeEquity:
+		if equityLegs != 1 || optionLegs != 0 {
+			return errors.New("equity orders require exactly one equity instrument")
+		}
+	case constants.OrderTypeOption:
+		if equityLegs != 0 || optionLegs != 1 {
+			return errors.New("option orders require exactly one option leg")
+		}
+	case constants.OrderTypeSpreads:
+		if equityLegs != 0 || optionLegs < 2 {
+			return errors.New("spread orders require two or more option legs")
+		}
+	case constants.OrderTypeBuyWrites:
+		if equityLegs != 1 || optionLegs != 1 {
+			return errors.New("buy-write orders require one equity leg and one option leg")
+		}
+	case constants.OrderTypeButterfly:
+		if equityLegs != 0 || optionLegs != 3 {
+			return errors.New("butterfly orders require exactly three option legs")
+		}
+	case constants.OrderTypeIronButterfly, constants.OrderTypeCondor, constants.OrderTypeIronCondor:
+		if equityLegs != 0 || optionLegs != 4 {
+			return fmt.Errorf("%s orders require exactly four option legs", o.OrderType)
+		}
+	}
+
+	switch o.PriceType {
+	case constants.OrderPriceTypeNetDebit, constants.OrderPriceTypeNetCredit, constants.OrderPriceTypeNetEven:
+		if len(o.Instruments) < 2 {
+			return fmt.Errorf("price type %s requires a multi-leg order", o.PriceType)
+		}
+	}
+	return nil
+}
+
+// AsPreviewRequestJsonMap returns the order formatted as the body of an
+// ETrade Preview Order request.
+func (o *OrderRequest) AsPreviewRequestJsonMap() jsonmap.JsonMap {
+	return jsonmap.JsonMap{
+		"PreviewOrderRequest": o.asJsonMap(),
+	}
+}
+
+// AsPlaceRequestJsonMap returns the order formatted as the body of an ETrade
+// Place Order request for a previously-previewed order.
+func (o *OrderRequest) AsPlaceRequestJsonMap(previewId int64) jsonmap.JsonMap {
+	requestMap := o.asJsonMap()
+	requestMap["PreviewIds"] = jsonmap.JsonSlice{
+		jsonmap.JsonMap{"previewId": previewId},
+	}
+	return jsonmap.JsonMap{
+		"PlaceOrderRequest": requestMap,
+	}
+}
+
+func (o *OrderRequest) asJsonMap() jsonmap.JsonMap {
+	instruments := make(jsonmap.JsonSlice, 0, len(o.Instruments))
+	for _, instrument := range o.Instruments {
+		instruments = append(instruments, instrument.asJsonMap())
+	}
+
+	orderMap := jsonmap.JsonMap{
+		"allOrNone":     o.AllOrNone,
+		"priceType":     o.PriceType.String(),
+		"orderTerm":     o.OrderTerm.String(),
+		"marketSession": o.MarketSession.String(),
+		"Instrument":    instruments,
+	}
+	switch o.PriceType {
+	case constants.OrderPriceTypeLimit:
+		orderMap["limitPrice"] = o.LimitPrice.JsonNumber()
+	case constants.OrderPriceTypeStop:
+		orderMap["stopPrice"] = o.StopPrice.JsonNumber()
+	case constants.OrderPriceTypeStopLimit:
+		orderMap["limitPrice"] = o.LimitPrice.JsonNumber()
+		orderMap["stopPrice"] = o.StopPrice.JsonNumber()
+	case constants.OrderPriceTypeNetDebit, constants.OrderPriceTypeNetCredit:
+		orderMap["limitPrice"] = o.LimitPrice.JsonNumber()
+	}
+
+	return jsonmap.JsonMap{
+		"orderType":     o.OrderType.String(),
+		"clientOrderId": o.ClientOrderId,
+		"Order":         jsonmap.JsonSlice{orderMap},
+	}
+}
@@ -0,0 +1,403 @@
+package client
+
+import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewClientOrderId(t *testing.T) {
+	id1, err := NewClientOrderId()
+	assert.Nil(t, err)
+	id2, err := NewClientOrderId()
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, id1, id2)
+	assert.LessOrEqual(t, len(id1), constants.ClientOrderIdMaxLength)
+}
+
+func TestOrderRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modifyFn  func(order *OrderRequest)
+		expectErr bool
+	}{
+		{
+			name:      "Valid Limit Order",
+			modifyFn:  func(order *OrderRequest) {},
+			expectErr: false,
+		},
+		{
+			name: "Valid Market Order Without Prices",
+			modifyFn: func(order *OrderRequest) {
+				order.PriceType = constants.OrderPriceTypeMarket
+				order.LimitPrice = decimal.Zero
+			},
+			expectErr: false,
+		},
+		{
+			name: "Fails Without Client Order ID",
+			modifyFn: func(order *OrderRequest) {
+				order.ClientOrderId = ""
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails With Long Client Order ID",
+			modifyFn: func(order *OrderRequest) {
+				order.ClientOrderId = "123456789012345678901"
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Without Order Term",
+			modifyFn: func(order *OrderRequest) {
+				order.OrderTerm = constants.OrderTermNil
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Limit Order Without Limit Price",
+			modifyFn: func(order *OrderRequest) {
+				order.LimitPrice = decimal.Zero
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Stop Order Without Stop Price",
+			modifyFn: func(order *OrderRequest) {
+				order.PriceType = constants.OrderPriceTypeStop
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Stop Limit Order Without Stop Price",
+			modifyFn: func(order *OrderRequest) {
+				order.PriceType = constants.OrderPriceTypeStopLimit
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Without Instruments",
+			modifyFn: func(order *OrderRequest) {
+				order.Instruments = nil
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails With Zero Quantity",
+			modifyFn: func(order *OrderRequest) {
+				order.Instruments[0].Quantity = 0
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Without Order Action",
+			modifyFn: func(order *OrderRequest) {
+				order.Instruments[0].OrderAction = constants.OrderActionNil
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Without Security Type",
+			modifyFn: func(order *OrderRequest) {
+				order.Instruments[0].SecurityType = constants.OrderSecurityTypeNil
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails With Option Action On Equity",
+			modifyFn: func(order *OrderRequest) {
+				order.Instruments[0].OrderAction = constants.OrderActionBuyOpen
+			},
+			expectErr: true,
+		},
+		{
+			name: "Valid Vertical Spread",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+			},
+			expectErr: false,
+		},
+		{
+			name: "Fails Spread With One Leg",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.Instruments = order.Instruments[:1]
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Iron Condor With Two Legs",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.OrderType = constants.OrderTypeIronCondor
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Net Debit Without Limit Price",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.LimitPrice = decimal.Zero
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Net Debit On Single Leg",
+			modifyFn: func(order *OrderRequest) {
+				order.PriceType = constants.OrderPriceTypeNetDebit
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails With Equity Action On Option",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.Instruments[0].OrderAction = constants.OrderActionBuy
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Option Without Strike Price",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.Instruments[0].StrikePrice = decimal.Zero
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Option With Invalid Expiration",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.Instruments[0].ExpiryMonth = 13
+			},
+			expectErr: true,
+		},
+		{
+			name: "Fails Buy Write Without Equity Leg",
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+				order.OrderType = constants.OrderTypeBuyWrites
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				order := createTestOrderRequest()
+				tt.modifyFn(order)
+				// Call the Method Under Test
+				err := order.Validate()
+				if tt.expectErr {
+					assert.Error(t, err)
+					assert.True(t, IsInvalidOrder(err))
+				} else {
+					assert.Nil(t, err)
+				}
+			},
+		)
+	}
+}
+
+func createTestSpreadOrderRequest() *OrderRequest {
+	return &OrderRequest{
+		ClientOrderId: "TestClientOrderId",
+		OrderType:     constants.OrderTypeSpreads,
+		PriceType:     constants.OrderPriceTypeNetDebit,
+		OrderTerm:     constants.OrderTermGoodForDay,
+		MarketSession: constants.MarketSessionRegular,
+		LimitPrice:    decimal.MustNewFromString("1.5"),
+		Instruments: []OrderInstrument{
+			{
+				SecurityType: constants.OrderSecurityTypeOption,
+				Symbol:       "GOOG",
+				CallPut:      constants.OptionCallPutCall,
+				ExpiryYear:   2024,
+				ExpiryMonth:  1,
+				ExpiryDay:    19,
+				StrikePrice:  decimal.NewFromInt(150),
+				OrderAction:  constants.OrderActionBuyOpen,
+				Quantity:     1,
+			},
+			{
+				SecurityType: constants.OrderSecurityTypeOption,
+				Symbol:       "GOOG",
+				CallPut:      constants.OptionCallPutCall,
+				ExpiryYear:   2024,
+				ExpiryMonth:  1,
+				ExpiryDay:    19,
+				StrikePrice:  decimal.NewFromInt(155),
+				OrderAction:  constants.OrderActionSellOpen,
+				Quantity:     1,
+			},
+		},
+	}
+}
+
+func TestNewOptionInstrumentFromOccSymbol(t *testing.T) {
+	tests := []struct {
+		name        string
+		occSymbol   string
+		expectErr   bool
+		expectValue OrderInstrument
+	}{
+		{
+			name:      "Parses Padded Symbol",
+			occSymbol: "GOOG  240119C00150000",
+			expectErr: false,
+			expectValue: OrderInstrument{
+				SecurityType: constants.OrderSecurityTypeOption,
+				Symbol:       "GOOG",
+				CallPut:      constants.OptionCallPutCall,
+				ExpiryYear:   2024,
+				ExpiryMonth:  1,
+				ExpiryDay:    19,
+				StrikePrice:  decimal.New(150000, 3),
+				OrderAction:  constants.OrderActionBuyOpen,
+				Quantity:     2,
+			},
+		},
+		{
+			name:      "Parses Compact Symbol With Fractional Strike",
+			occSymbol: "F240621P00012500",
+			expectErr: false,
+			expectValue: OrderInstrument{
+				SecurityType: constants.OrderSecurityTypeOption,
+				Symbol:       "F",
+				CallPut:      constants.OptionCallPutPut,
+				ExpiryYear:   2024,
+				ExpiryMonth:  6,
+				ExpiryDay:    21,
+				StrikePrice:  decimal.New(12500, 3),
+				OrderAction:  constants.OrderActionBuyOpen,
+				Quantity:     2,
+			},
+		},
+		{
+			name:        "Fails Without Root Symbol",
+			occSymbol:   "240119C00150000",
+			expectErr:   true,
+			expectValue: OrderInstrument{},
+		},
+		{
+			name:        "Fails With Bad Date",
+			occSymbol:   "GOOG  241319C00150000",
+			expectErr:   true,
+			expectValue: OrderInstrument{},
+		},
+		{
+			name:        "Fails With Bad Call Put",
+			occSymbol:   "GOOG  240119X00150000",
+			expectErr:   true,
+			expectValue: OrderInstrument{},
+		},
+		{
+			name:        "Fails With Bad Strike",
+			occSymbol:   "GOOG  240119C0015000A",
+			expectErr:   true,
+			expectValue: OrderInstrument{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := NewOptionInstrumentFromOccSymbol(tt.occSymbol, constants.OrderActionBuyOpen, 2)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestOrderInstrument_OccSymbol(t *testing.T) {
+	instrument := createTestSpreadOrderRequest().Instruments[0]
+	assert.Equal(t, "GOOG  240119C00150000", instrument.OccSymbol())
+
+	equityInstrument := createTestOrderRequest().Instruments[0]
+	assert.Equal(t, "GOOG", equityInstrument.OccSymbol())
+}
+
+func TestOrderRequest_AsPreviewRequestJsonMap(t *testing.T) {
+	order := createTestOrderRequest()
+	expectedValue := jsonmap.JsonMap{
+		"PreviewOrderRequest": jsonmap.JsonMap{
+			"orderType":     "EQ",
+			"clientOrderId": "TestClientOrderId",
+			"Order": jsonmap.JsonSlice{
+				jsonmap.JsonMap{
+					"allOrNone":     false,
+					"priceType":     "LIMIT",
+					"orderTerm":     "GOOD_FOR_DAY",
+					"marketSession": "REGULAR",
+					"limitPrice":    json.Number("123.45"),
+					"Instrument": jsonmap.JsonSlice{
+						jsonmap.JsonMap{
+							"Product": jsonmap.JsonMap{
+								"securityType": "EQ",
+								"symbol":       "GOOG",
+							},
+							"orderAction":  "BUY",
+							"quantityType": "QUANTITY",
+							"quantity":     int64(10),
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedValue, order.AsPreviewRequestJsonMap())
+}
+
+func TestOrderRequest_AsPlaceRequestJsonMap(t *testing.T) {
+	order := createTestOrderRequest()
+	order.PriceType = constants.OrderPriceTypeStopLimit
+	order.StopPrice = decimal.NewFromInt(120)
+
+	actualValue := order.AsPlaceRequestJsonMap(5678)
+
+	placeRequest, err := actualValue.GetMap("PlaceOrderRequest")
+	assert.Nil(t, err)
+	assert.Equal(
+		t, jsonmap.JsonSlice{jsonmap.JsonMap{"previewId": int64(5678)}}, placeRequest["PreviewIds"],
+	)
+	assert.Equal(t, json.Number("120"), placeRequest.GetValueAtPathWithDefault(".Order[0].stopPrice", nil))
+	assert.Equal(t, json.Number("123.45"), placeRequest.GetValueAtPathWithDefault(".Order[0].limitPrice", nil))
+}
+
+func TestOrderRequest_AsPreviewRequestJsonMap_Spread(t *testing.T) {
+	order := createTestSpreadOrderRequest()
+	actualValue := order.AsPreviewRequestJsonMap()
+
+	assert.Equal(t, "SPREADS", actualValue.GetValueAtPathWithDefault(".PreviewOrderRequest.orderType", nil))
+	assert.Equal(t, "NET_DEBIT", actualValue.GetValueAtPathWithDefault(".PreviewOrderRequest.Order[0].priceType", nil))
+	assert.Equal(t, json.Number("1.5"), actualValue.GetValueAtPathWithDefault(".PreviewOrderRequest.Order[0].limitPrice", nil))
+	expectedLeg := jsonmap.JsonMap{
+		"Product": jsonmap.JsonMap{
+			"securityType": "OPTN",
+			"symbol":       "GOOG",
+			"callPut":      "CALL",
+			"expiryYear":   int64(2024),
+			"expiryMonth":  int64(1),
+			"expiryDay":    int64(19),
+			"strikePrice":  json.Number("155"),
+		},
+		"orderAction":  "SELL_OPEN",
+		"quantityType": "QUANTITY",
+		"quantity":     int64(1),
+	}
+	assert.Equal(
+		t, expectedLeg, actualValue.GetValueAtPathWithDefault(".PreviewOrderRequest.Order[0].Instrument[1]", nil),
+	)
+}
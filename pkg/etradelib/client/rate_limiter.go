@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit limits the rate of requests to an ETrade API module with a token
+// bucket. RequestsPerSecond is the sustained rate and Burst is the number of
+// requests that may be made at once after a period of inactivity. A zero
+// RequestsPerSecond disables the limit.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RateLimits holds a separate limit for each ETrade API module, because ETrade
+// enforces a separate quota for each.
+type RateLimits struct {
+	Accounts RateLimit
+	Alerts   RateLimit
+	Market   RateLimit
+	Orders   RateLimit
+}
+
+type apiModule int
+
+const (
+	apiModuleNone apiModule = iota
+	apiModuleAccounts
+	apiModuleAlerts
+	apiModuleMarket
+	apiModuleOrders
+)
+
+// apiModuleForPath returns the ETrade API module that serves the given URL
+// path. Authorization requests do not belong to any module and are not rate
+// limited.
+func apiModuleForPath(path string) apiModule {
+	switch {
+	case strings.Contains(path, "/v1/market/"):
+		return apiModuleMarket
+	case strings.Contains(path, "/v1/user/alerts"):
+		return apiModuleAlerts
+	case strings.Contains(path, "/v1/accounts/") && strings.Contains(path, "/orders"):
+		return apiModuleOrders
+	case strings.Contains(path, "/v1/accounts/"):
+		return apiModuleAccounts
+	default:
+		return apiModuleNone
+	}
+}
+
+// RateLimiter holds a token bucket for each rate-limited API module. It is
+// safe for concurrent use, so a single limiter may be shared by every client
+// created for a customer.
+type RateLimiter struct {
+	buckets map[apiModule]*tokenBucket
+}
+
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	limiter := &RateLimiter{buckets: map[apiModule]*tokenBucket{}}
+	for module, limit := range map[apiModule]RateLimit{
+		apiModuleAccounts: limits.Accounts,
+		apiModuleAlerts:   limits.Alerts,
+		apiModuleMarket:   limits.Market,
+		apiModuleOrders:   limits.Orders,
+	} {
+		if limit.RequestsPerSecond > 0 {
+			limiter.buckets[module] = newTokenBucket(limit, time.Now, sleepWithContext)
+		}
+	}
+	return limiter
+}
+
+// wait blocks until a request may be made to the API module that serves the
+// given URL path, or until the context is done. A nil limiter never blocks.
+func (l *RateLimiter) wait(ctx context.Context, path string) error {
+	if l == nil {
+		return nil
+	}
+	bucket, found := l.buckets[apiModuleForPath(path)]
+	if !found {
+		return nil
+	}
+	return bucket.take(ctx)
+}
+
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func newTokenBucket(
+	limit RateLimit, now func() time.Time, sleep func(ctx context.Context, d time.Duration) error,
+) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   now(),
+		now:    now,
+		sleep:  sleep,
+	}
+}
+
+// take removes a token from the bucket, waiting for one to become available
+// if the bucket is empty. A caller that must wait reserves its token before
+// waiting so that concurrent callers queue up behind it rather than all
+// waking at once.
+func (b *tokenBucket) take(ctx context.Context) error {
+	b.mutex.Lock()
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mutex.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := b.sleep(ctx, wait); err != nil {
+		// Return the reserved token, since no request will be made with it.
+		b.mutex.Lock()
+		b.tokens++
+		b.mutex.Unlock()
+		return err
+	}
+	return nil
+}
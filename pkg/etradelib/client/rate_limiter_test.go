@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestApiModuleForPath(t *testing.T) {
+	urls := GetEndpointUrls(true)
+	tests := []struct {
+		name         string
+		url          string
+		expectModule apiModule
+	}{
+		{"Renew Access Token", urls.RenewAccessTokenUrl(), apiModuleNone},
+		{"List Accounts", urls.ListAccountsUrl(), apiModuleAccounts},
+		{"View Portfolio", urls.ViewPortfolioUrl("TestAccountIdKey"), apiModuleAccounts},
+		{"List Position Lots", urls.ListPositionLotsDetailsUrl("TestAccountIdKey", 1234), apiModuleAccounts},
+		{"List Alerts", urls.ListAlertsUrl(), apiModuleAlerts},
+		{"Delete Alert", urls.DeleteAlertUrl("1234"), apiModuleAlerts},
+		{"Get Quotes", urls.GetQuotesUrl("GOOG"), apiModuleMarket},
+		{"Get Option Chains", urls.GetOptionChainsUrl(), apiModuleMarket},
+		{"List Orders", urls.ListOrdersUrl("TestAccountIdKey"), apiModuleOrders},
+		{"Place Order", urls.PlaceOrderUrl("TestAccountIdKey"), apiModuleOrders},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				parsedUrl, err := url.Parse(tt.url)
+				assert.Nil(t, err)
+				// Call the Method Under Test
+				actualModule := apiModuleForPath(parsedUrl.Path)
+				assert.Equal(t, tt.expectModule, actualModule)
+			},
+		)
+	}
+}
+
+func TestTokenBucket_Take(t *testing.T) {
+	now := time.Date(2023, 6, 14, 14, 0, 0, 0, time.UTC)
+	var actualWaits []time.Duration
+	bucket := newTokenBucket(
+		RateLimit{RequestsPerSecond: 2, Burst: 2},
+		func() time.Time { return now },
+		func(ctx context.Context, d time.Duration) error {
+			actualWaits = append(actualWaits, d)
+			return nil
+		},
+	)
+
+	// The burst is available immediately.
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Empty(t, actualWaits)
+
+	// Once the burst is used, each request waits its turn.
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, actualWaits)
+
+	// Tokens refill over time, up to the burst.
+	now = now.Add(time.Minute)
+	actualWaits = nil
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, actualWaits)
+}
+
+func TestTokenBucket_TakeReturnsTokenWhenCancelled(t *testing.T) {
+	now := time.Date(2023, 6, 14, 14, 0, 0, 0, time.UTC)
+	var actualWaits []time.Duration
+	bucket := newTokenBucket(
+		RateLimit{RequestsPerSecond: 1, Burst: 1},
+		func() time.Time { return now },
+		func(ctx context.Context, d time.Duration) error {
+			actualWaits = append(actualWaits, d)
+			return ctx.Err()
+		},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Nil(t, bucket.take(ctx))
+	assert.ErrorIs(t, bucket.take(ctx), context.Canceled)
+	// The cancelled request's token was returned, so the next request waits
+	// no longer than it would have without the cancelled request.
+	assert.Nil(t, bucket.take(context.Background()))
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, actualWaits)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{Market: RateLimit{RequestsPerSecond: 1, Burst: 1}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Modules without a limit never wait.
+	assert.Nil(t, limiter.wait(ctx, "/v1/accounts/list"))
+	assert.Nil(t, limiter.wait(ctx, "/v1/accounts/list"))
+	// The limited module must wait once its burst is used.
+	assert.Nil(t, limiter.wait(ctx, "/v1/market/quote/GOOG"))
+	assert.ErrorIs(t, limiter.wait(ctx, "/v1/market/quote/GOOG"), context.Canceled)
+	// A nil limiter never waits.
+	var nilLimiter *RateLimiter
+	assert.Nil(t, nilLimiter.wait(ctx, "/v1/market/quote/GOOG"))
+}
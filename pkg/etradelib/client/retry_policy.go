@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a rate limit (429) or
+// server (5xx) error, or with a transport error, are retried. Retries wait
+// with exponential backoff and jitter, unless ETrade specifies how long to
+// wait with a Retry-After header.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried. Zero
+	// disables retries.
+	MaxRetries int
+	// InitialBackoff is the longest wait before the first retry. The wait
+	// doubles for each subsequent retry.
+	InitialBackoff time.Duration
+	// MaxBackoff limits the wait computed by exponential backoff. It does not
+	// limit a wait requested by a Retry-After header.
+	MaxBackoff time.Duration
+}
+
+const defaultMaxRetries = 3
+
+const defaultInitialBackoff = 500 * time.Millisecond
+
+const defaultMaxBackoff = 30 * time.Second
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     defaultMaxRetries,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+	}
+}
+
+// backoff returns how long to wait before the given retry (starting at 1).
+// The wait is chosen at random between half of and the full exponential
+// backoff so that clients that failed together do not retry together.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses a Retry-After header, which may be either a number
+// of seconds or an HTTP date. It returns false if the header is missing or
+// invalid.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleepWithContext waits for the given duration, returning early with the
+// context's error if the context is done first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		retry     int
+		expectMin time.Duration
+		expectMax time.Duration
+	}{
+		{
+			name:      "First Retry Uses Initial Backoff",
+			policy:    RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute},
+			retry:     1,
+			expectMin: 500 * time.Millisecond,
+			expectMax: time.Second,
+		},
+		{
+			name:      "Backoff Doubles For Each Retry",
+			policy:    RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute},
+			retry:     3,
+			expectMin: 2 * time.Second,
+			expectMax: 4 * time.Second,
+		},
+		{
+			name:      "Backoff Is Limited To Max Backoff",
+			policy:    RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 3 * time.Second},
+			retry:     10,
+			expectMin: 1500 * time.Millisecond,
+			expectMax: 3 * time.Second,
+		},
+		{
+			name:      "Zero Backoff",
+			policy:    RetryPolicy{},
+			retry:     1,
+			expectMin: 0,
+			expectMax: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				for i := 0; i < 100; i++ {
+					// Call the Method Under Test
+					actualValue := tt.policy.backoff(tt.retry)
+					assert.GreaterOrEqual(t, actualValue, tt.expectMin)
+					assert.LessOrEqual(t, actualValue, tt.expectMax)
+				}
+			},
+		)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 6, 14, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		header      string
+		expectValue time.Duration
+		expectOk    bool
+	}{
+		{
+			name:        "Seconds",
+			header:      "5",
+			expectValue: 5 * time.Second,
+			expectOk:    true,
+		},
+		{
+			name:        "HTTP Date",
+			header:      "Wed, 14 Jun 2023 14:00:30 GMT",
+			expectValue: 30 * time.Second,
+			expectOk:    true,
+		},
+		{
+			name:        "HTTP Date In The Past",
+			header:      "Wed, 14 Jun 2023 13:59:00 GMT",
+			expectValue: 0,
+			expectOk:    true,
+		},
+		{
+			name:        "Missing",
+			header:      "",
+			expectValue: 0,
+			expectOk:    false,
+		},
+		{
+			name:        "Invalid",
+			header:      "soon",
+			expectValue: 0,
+			expectOk:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, actualOk := parseRetryAfter(tt.header, now)
+				assert.Equal(t, tt.expectValue, actualValue)
+				assert.Equal(t, tt.expectOk, actualOk)
+			},
+		)
+	}
+}
+
+func TestETradeClient_Retry(t *testing.T) {
+	const listAccountsUrl = "https://api.etrade.com/v1/accounts/list"
+	const placeOrderUrl = "https://api.etrade.com/v1/accounts/TestAccountIdKey/orders/place"
+
+	type testFn func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error)
+
+	tests := []struct {
+		name           string
+		testFn         testFn
+		expectResponse []byte
+		expectErr      bool
+		expectWaits    []time.Duration
+	}{
+		{
+			name: "Retries Rate Limited Request",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusTooManyRequests, "", nil).Once()
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusOK, "TestResponse", nil).Once()
+				return testClient.ListAccounts()
+			},
+			expectResponse: []byte("TestResponse"),
+			expectErr:      false,
+			expectWaits:    []time.Duration{0},
+		},
+		{
+			name: "Retries Server Error And Transport Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusServiceUnavailable, "", nil).Once()
+				clientMock.On("Do", "GET", listAccountsUrl).Return(0, "", errors.New("test error")).Once()
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusOK, "TestResponse", nil).Once()
+				return testClient.ListAccounts()
+			},
+			expectResponse: []byte("TestResponse"),
+			expectErr:      false,
+			expectWaits:    []time.Duration{0, 0},
+		},
+		{
+			name: "Honors Retry After",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", listAccountsUrl).Return(
+					http.StatusTooManyRequests, "", nil, http.Header{"Retry-After": []string{"7"}},
+				).Once()
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusOK, "TestResponse", nil).Once()
+				return testClient.ListAccounts()
+			},
+			expectResponse: []byte("TestResponse"),
+			expectErr:      false,
+			expectWaits:    []time.Duration{7 * time.Second},
+		},
+		{
+			name: "Gives Up After Max Retries",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusInternalServerError, "", nil).Times(3)
+				return testClient.ListAccounts()
+			},
+			expectResponse: nil,
+			expectErr:      true,
+			expectWaits:    []time.Duration{0, 0},
+		},
+		{
+			name: "Does Not Retry Client Error",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusBadRequest, "", nil).Once()
+				return testClient.ListAccounts()
+			},
+			expectResponse: nil,
+			expectErr:      true,
+			expectWaits:    nil,
+		},
+		{
+			name: "Does Not Retry Auth Failure",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "GET", listAccountsUrl).Return(http.StatusUnauthorized, "", nil).Once()
+				return testClient.ListAccounts()
+			},
+			expectResponse: nil,
+			expectErr:      true,
+			expectWaits:    nil,
+		},
+		{
+			name: "Does Not Retry Order Placement",
+			testFn: func(testClient ETradeClient, clientMock *httpClientMock) ([]byte, error) {
+				clientMock.On("Do", "POST", placeOrderUrl).Return(http.StatusServiceUnavailable, "", nil).Once()
+				return testClient.PlaceOrder("TestAccountIdKey", 1234, createTestOrderRequest())
+			},
+			expectResponse: nil,
+			expectErr:      true,
+			expectWaits:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				clientMock := new(httpClientMock)
+				testClient := createMockClient(clientMock, nil, true, "", "", "", "", "", "").(*eTradeClient)
+				testClient.retryPolicy = RetryPolicy{MaxRetries: 2}
+				var actualWaits []time.Duration
+				testClient.sleep = func(ctx context.Context, d time.Duration) error {
+					actualWaits = append(actualWaits, d)
+					return nil
+				}
+				// Call the Method Under Test
+				actualResponse, err := tt.testFn(testClient, clientMock)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectResponse, actualResponse)
+				assert.Equal(t, tt.expectWaits, actualWaits)
+				clientMock.AssertExpectations(t)
+			},
+		)
+	}
+}
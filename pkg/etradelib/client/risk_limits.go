@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"strings"
+	"time"
+)
+
+// RiskLimits are pre-trade checks applied to every order before it is sent
+// to ETrade. A zero value for any limit disables that limit.
+type RiskLimits struct {
+	// MaxOrderNotional is the maximum value of a single order, in dollars.
+	MaxOrderNotional decimal.Decimal
+	// MaxShareQuantity is the maximum number of shares in any one leg of an
+	// order. Each option contract counts as 100 shares.
+	MaxShareQuantity int
+	// AllowedSymbols, if not empty, lists the only symbols (or, for options,
+	// underlying symbols) that may be traded.
+	AllowedSymbols []string
+	// TradingHoursOnly rejects orders outside regular market hours (9:30am
+	// to 4:00pm US Eastern, Monday through Friday) and orders for the
+	// extended session. Market holidays are not taken into account.
+	TradingHoursOnly bool
+	// TradingDisabled rejects all orders.
+	TradingDisabled bool
+}
+
+// ErrRiskLimitExceeded is wrapped by every error returned when an order is
+// rejected by risk limits.
+var ErrRiskLimitExceeded = errors.New("order rejected by risk limits")
+
+func IsRiskLimitExceeded(err error) bool {
+	return errors.Is(err, ErrRiskLimitExceeded)
+}
+
+// optionContractShares is the number of shares of the underlying security
+// represented by a standard option contract.
+const optionContractShares = 100
+
+// tradingHoursOpen and tradingHoursClose bound regular trading hours, in
+// minutes after midnight US Eastern time.
+const tradingHoursOpen = 9*60 + 30
+
+const tradingHoursClose = 16 * 60
+
+type riskLimitedETradeClient struct {
+	ETradeClient
+	limits RiskLimits
+	now    func() time.Time
+}
+
+// NewRiskLimitedETradeClient wraps an ETrade client so that every order it
+// previews or places is first checked against the given risk limits. Orders
+// that exceed a limit are rejected without contacting ETrade.
+func NewRiskLimitedETradeClient(eTradeClient ETradeClient, limits RiskLimits) ETradeClient {
+	return &riskLimitedETradeClient{
+		ETradeClient: eTradeClient,
+		limits:       limits,
+		now:          time.Now,
+	}
+}
+
+// WithContext must be overridden so that the client it returns is also risk
+// limited.
+func (c *riskLimitedETradeClient) WithContext(ctx context.Context) ETradeClient {
+	return &riskLimitedETradeClient{
+		ETradeClient: c.ETradeClient.WithContext(ctx),
+		limits:       c.limits,
+		now:          c.now,
+	}
+}
+
+func (c *riskLimitedETradeClient) PreviewOrder(accountIdKey string, order *OrderRequest) ([]byte, error) {
+	if err := c.checkOrder(order); err != nil {
+		return nil, err
+	}
+	return c.ETradeClient.PreviewOrder(accountIdKey, order)
+}
+
+func (c *riskLimitedETradeClient) PlaceOrder(accountIdKey string, previewId int64, order *OrderRequest) (
+	[]byte, error,
+) {
+	if err := c.checkOrder(order); err != nil {
+		return nil, err
+	}
+	return c.ETradeClient.PlaceOrder(accountIdKey, previewId, order)
+}
+
+func (c *riskLimitedETradeClient) PreviewChangedOrder(accountIdKey string, orderId int64, order *OrderRequest) (
+	[]byte, error,
+) {
+	if err := c.checkOrder(order); err != nil {
+		return nil, err
+	}
+	return c.ETradeClient.PreviewChangedOrder(accountIdKey, orderId, order)
+}
+
+func (c *riskLimitedETradeClient) PlaceChangedOrder(
+	accountIdKey string, orderId int64, previewId int64, order *OrderRequest,
+) ([]byte, error) {
+	if err := c.checkOrder(order); err != nil {
+		return nil, err
+	}
+	return c.ETradeClient.PlaceChangedOrder(accountIdKey, orderId, previewId, order)
+}
+
+func (c *riskLimitedETradeClient) checkOrder(order *OrderRequest) error {
+	if order == nil {
+		return errors.New("order not provided")
+	}
+	if c.limits.TradingDisabled {
+		return fmt.Errorf("%w: trading is disabled for this customer", ErrRiskLimitExceeded)
+	}
+	if c.limits.TradingHoursOnly {
+		if order.MarketSession == constants.MarketSessionExtended {
+			return fmt.Errorf("%w: extended session orders are not allowed", ErrRiskLimitExceeded)
+		}
+		if !isDuringTradingHours(c.now()) {
+			return fmt.Errorf("%w: orders are only allowed during regular trading hours", ErrRiskLimitExceeded)
+		}
+	}
+	if len(c.limits.AllowedSymbols) > 0 {
+		for _, instrument := range order.Instruments {
+			if !c.isSymbolAllowed(instrument.Symbol) {
+				return fmt.Errorf(
+					"%w: symbol %s is not in the allowed symbol list", ErrRiskLimitExceeded, instrument.Symbol,
+				)
+			}
+		}
+	}
+	if c.limits.MaxShareQuantity > 0 {
+		for _, instrument := range order.Instruments {
+			if shares := instrumentShares(&instrument); shares > c.limits.MaxShareQuantity {
+				return fmt.Errorf(
+					"%w: %d shares of %s exceeds the maximum of %d", ErrRiskLimitExceeded, shares,
+					instrument.OccSymbol(), c.limits.MaxShareQuantity,
+				)
+			}
+		}
+	}
+	if c.limits.MaxOrderNotional.IsPositive() {
+		notional, err := c.orderNotional(order)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrRiskLimitExceeded, err.Error())
+		}
+		if notional.GreaterThan(c.limits.MaxOrderNotional) {
+			return fmt.Errorf(
+				"%w: order value $%s exceeds the maximum of $%s", ErrRiskLimitExceeded, notional.StringFixed(2),
+				c.limits.MaxOrderNotional.StringFixed(2),
+			)
+		}
+	}
+	return nil
+}
+
+func (c *riskLimitedETradeClient) isSymbolAllowed(symbol string) bool {
+	for _, allowedSymbol := range c.limits.AllowedSymbols {
+		if strings.EqualFold(strings.TrimSpace(allowedSymbol), symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderNotional estimates the dollar value of an order as its price times
+// the largest number of shares in any leg. For market orders, the price is
+// the last trade price of the security. The notional value of market orders
+// with option legs cannot be estimated, so an error is returned for them.
+func (c *riskLimitedETradeClient) orderNotional(order *OrderRequest) (decimal.Decimal, error) {
+	maxShares := 0
+	for _, instrument := range order.Instruments {
+		if shares := instrumentShares(&instrument); shares > maxShares {
+			maxShares = shares
+		}
+	}
+
+	var price decimal.Decimal
+	switch order.PriceType {
+	case constants.OrderPriceTypeLimit, constants.OrderPriceTypeStopLimit, constants.OrderPriceTypeNetDebit,
+		constants.OrderPriceTypeNetCredit:
+		price = order.LimitPrice
+	case constants.OrderPriceTypeStop:
+		price = order.StopPrice
+	default:
+		if len(order.Instruments) != 1 || order.Instruments[0].SecurityType != constants.OrderSecurityTypeEquity {
+			return decimal.Zero, fmt.Errorf(
+				"unable to determine the value of a %s %s order", order.PriceType, order.OrderType,
+			)
+		}
+		var err error
+		if price, err = c.lastTradePrice(order.Instruments[0].Symbol); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	return price.Mul(decimal.NewFromInt(int64(maxShares))), nil
+}
+
+func (c *riskLimitedETradeClient) lastTradePrice(symbol string) (decimal.Decimal, error) {
+	response, err := c.ETradeClient.GetQuotes([]string{symbol}, constants.QuoteDetailFlagAll, false, false)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("unable to get a quote for %s (%s)", symbol, err.Error())
+	}
+	responseMap, err := jsonmap.NewJsonMapFromJsonBytes(response)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("unable to get a quote for %s (%s)", symbol, err.Error())
+	}
+	lastTrade, _ := responseMap.GetValueAtPath(".QuoteResponse.QuoteData[0].All.lastTrade")
+	lastTradeNumber, ok := lastTrade.(json.Number)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("unable to get a last trade price for %s", symbol)
+	}
+	price, err := decimal.NewFromJsonNumber(lastTradeNumber)
+	if err != nil || !price.IsPositive() {
+		return decimal.Zero, fmt.Errorf("unable to get a last trade price for %s", symbol)
+	}
+	return price, nil
+}
+
+func instrumentShares(instrument *OrderInstrument) int {
+	if instrument.SecurityType == constants.OrderSecurityTypeOption {
+		return instrument.Quantity * optionContractShares
+	}
+	return instrument.Quantity
+}
+
+func isDuringTradingHours(t time.Time) bool {
+	t = t.In(EasternTime)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= tradingHoursOpen && minutes < tradingHoursClose
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRiskLimitedETradeClient_PlaceOrder(t *testing.T) {
+	// Wednesday, June 14, 2023 at 10:00am US Eastern
+	duringTradingHours := time.Date(2023, 6, 14, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		limits         RiskLimits
+		now            time.Time
+		modifyFn       func(order *OrderRequest)
+		setupMock      func(clientMock *ETradeClientMock, order *OrderRequest)
+		expectRejected bool
+	}{
+		{
+			name:     "No Limits Allows Order",
+			limits:   RiskLimits{},
+			now:      duringTradingHours,
+			modifyFn: func(order *OrderRequest) {},
+		},
+		{
+			name:           "Trading Disabled Rejects Order",
+			limits:         RiskLimits{TradingDisabled: true},
+			now:            duringTradingHours,
+			modifyFn:       func(order *OrderRequest) {},
+			expectRejected: true,
+		},
+		{
+			name:     "Allowed Symbol Allows Order",
+			limits:   RiskLimits{AllowedSymbols: []string{"aapl", "goog"}},
+			now:      duringTradingHours,
+			modifyFn: func(order *OrderRequest) {},
+		},
+		{
+			name:           "Symbol Not In Allowed List Rejects Order",
+			limits:         RiskLimits{AllowedSymbols: []string{"AAPL"}},
+			now:            duringTradingHours,
+			modifyFn:       func(order *OrderRequest) {},
+			expectRejected: true,
+		},
+		{
+			name:     "Quantity At Maximum Allows Order",
+			limits:   RiskLimits{MaxShareQuantity: 10},
+			now:      duringTradingHours,
+			modifyFn: func(order *OrderRequest) {},
+		},
+		{
+			name:           "Quantity Over Maximum Rejects Order",
+			limits:         RiskLimits{MaxShareQuantity: 9},
+			now:            duringTradingHours,
+			modifyFn:       func(order *OrderRequest) {},
+			expectRejected: true,
+		},
+		{
+			name:   "Option Contracts Count As 100 Shares",
+			limits: RiskLimits{MaxShareQuantity: 99},
+			now:    duringTradingHours,
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+			},
+			expectRejected: true,
+		},
+		{
+			name:     "Limit Order Under Maximum Notional Allows Order",
+			limits:   RiskLimits{MaxOrderNotional: decimal.MustNewFromString("1234.50")},
+			now:      duringTradingHours,
+			modifyFn: func(order *OrderRequest) {},
+		},
+		{
+			name:           "Limit Order Over Maximum Notional Rejects Order",
+			limits:         RiskLimits{MaxOrderNotional: decimal.MustNewFromString("1234.49")},
+			now:            duringTradingHours,
+			modifyFn:       func(order *OrderRequest) {},
+			expectRejected: true,
+		},
+		{
+			name:   "Spread Notional Uses Contract Multiplier",
+			limits: RiskLimits{MaxOrderNotional: decimal.NewFromInt(149)},
+			now:    duringTradingHours,
+			modifyFn: func(order *OrderRequest) {
+				*order = *createTestSpreadOrderRequest()
+			},
+			expectRejected: true,
+		},
+		{
+			name:   "Market Order Notional Uses Last Trade Price",
+			limits: RiskLimits{MaxOrderNotional: decimal.NewFromInt(1000)},
+			now:    duringTradingHours,
+			modifyFn: func(order *OrderRequest) {
+				order.PriceType = constants.OrderPriceTypeMarket
+				order.LimitPrice = decimal.Zero
+			},
+			setupMock: func(clientMock *ETradeClientMock, order *OrderRequest) {
+				clientMock.On("GetQuotes", []string{"GOOG"}, constants.QuoteDetailFlagAll, false, false).Return(
+					[]byte(`{"QuoteResponse":{"QuoteData":[{"All":{"lastTrade":101.5}}]}}`), nil,
+				)
+			},
+			expectRejected: true,
+		},
+		{
+			name:   "Market Order Rejected When Quote Fails",
+			limits: RiskLimits{MaxOrderNotional: decimal.NewFromInt(1000000)},
+			now:    duringTradingHours,
+			modifyFn: func(order *OrderRequest) {
+				order.PriceType = constants.OrderPriceTypeMarket
+				order.LimitPrice = decimal.Zero
+			},
+			setupMock: func(clientMock *ETradeClientMock, order *OrderRequest) {
+				clientMock.On("GetQuotes", []string{"GOOG"}, constants.QuoteDetailFlagAll, false, false).Return(
+					[]byte(nil), errors.New("test error"),
+				)
+			},
+			expectRejected: true,
+		},
+		{
+			name:     "Trading Hours Allows Order During Regular Session",
+			limits:   RiskLimits{TradingHoursOnly: true},
+			now:      duringTradingHours,
+			modifyFn: func(order *OrderRequest) {},
+		},
+		{
+			name:   "Trading Hours Rejects Order Before Open",
+			limits: RiskLimits{TradingHoursOnly: true},
+			// Wednesday, June 14, 2023 at 9:29am US Eastern
+			now:            time.Date(2023, 6, 14, 13, 29, 0, 0, time.UTC),
+			modifyFn:       func(order *OrderRequest) {},
+			expectRejected: true,
+		},
+		{
+			name:   "Trading Hours Rejects Order On Weekend",
+			limits: RiskLimits{TradingHoursOnly: true},
+			// Saturday, June 17, 2023 at 10:00am US Eastern
+			now:            time.Date(2023, 6, 17, 14, 0, 0, 0, time.UTC),
+			modifyFn:       func(order *OrderRequest) {},
+			expectRejected: true,
+		},
+		{
+			name:   "Trading Hours Rejects Extended Session Order",
+			limits: RiskLimits{TradingHoursOnly: true},
+			now:    duringTradingHours,
+			modifyFn: func(order *OrderRequest) {
+				order.MarketSession = constants.MarketSessionExtended
+			},
+			expectRejected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				order := createTestOrderRequest()
+				tt.modifyFn(order)
+				clientMock := ETradeClientMock{}
+				if tt.setupMock != nil {
+					tt.setupMock(&clientMock, order)
+				}
+				if !tt.expectRejected {
+					clientMock.On("PlaceOrder", "TestAccountIdKey", int64(1234), order).Return(
+						[]byte("TestResponse"), nil,
+					)
+				}
+				testClient := &riskLimitedETradeClient{
+					ETradeClient: &clientMock,
+					limits:       tt.limits,
+					now:          func() time.Time { return tt.now },
+				}
+				// Call the Method Under Test
+				response, err := testClient.PlaceOrder("TestAccountIdKey", 1234, order)
+				if tt.expectRejected {
+					assert.True(t, IsRiskLimitExceeded(err))
+					assert.Nil(t, response)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, []byte("TestResponse"), response)
+				}
+				clientMock.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestRiskLimitedETradeClient_ChecksEveryOrderMethod(t *testing.T) {
+	clientMock := ETradeClientMock{}
+	testClient := NewRiskLimitedETradeClient(&clientMock, RiskLimits{TradingDisabled: true})
+	order := createTestOrderRequest()
+
+	_, err := testClient.PreviewOrder("TestAccountIdKey", order)
+	assert.True(t, IsRiskLimitExceeded(err))
+	_, err = testClient.PlaceOrder("TestAccountIdKey", 1234, order)
+	assert.True(t, IsRiskLimitExceeded(err))
+	_, err = testClient.PreviewChangedOrder("TestAccountIdKey", 5678, order)
+	assert.True(t, IsRiskLimitExceeded(err))
+	_, err = testClient.PlaceChangedOrder("TestAccountIdKey", 5678, 1234, order)
+	assert.True(t, IsRiskLimitExceeded(err))
+	// None of the calls should reach the underlying client.
+	clientMock.AssertExpectations(t)
+}
+
+func TestRiskLimitedETradeClient_WithContextKeepsLimits(t *testing.T) {
+	ctx := context.Background()
+	clientMock := ETradeClientMock{}
+	innerClientMock := ETradeClientMock{}
+	clientMock.On("WithContext", ctx).Return(&innerClientMock)
+	testClient := NewRiskLimitedETradeClient(&clientMock, RiskLimits{TradingDisabled: true})
+
+	// Call the Method Under Test
+	_, err := testClient.WithContext(ctx).PlaceOrder("TestAccountIdKey", 1234, createTestOrderRequest())
+	assert.True(t, IsRiskLimitExceeded(err))
+	clientMock.AssertExpectations(t)
+	innerClientMock.AssertExpectations(t)
+}
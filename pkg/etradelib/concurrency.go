@@ -0,0 +1,48 @@
+package etradelib
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// forEachConcurrently calls fn for every index from 0 to count-1, with no more
+// than concurrency calls running at once. Every call is made even if some
+// fail, unless the context is done, in which case no further calls are
+// started. The errors from the calls are joined in index order, so the result
+// doesn't depend on the order in which the calls finish.
+func forEachConcurrently(ctx context.Context, count int, concurrency int, fn func(index int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, count)
+	indexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < concurrency && i < count; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range indexes {
+				errs[index] = fn(index)
+			}
+		}()
+	}
+
+	var ctxErr error
+	for index := 0; index < count; index++ {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		select {
+		case indexes <- index:
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+		}
+		if ctxErr != nil {
+			break
+		}
+	}
+	close(indexes)
+	waitGroup.Wait()
+	return errors.Join(append(errs, ctxErr)...)
+}
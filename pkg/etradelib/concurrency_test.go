@@ -0,0 +1,78 @@
+package etradelib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrently_LimitsConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	var mutex sync.Mutex
+	called := make([]bool, 20)
+
+	// Call the Method Under Test
+	err := forEachConcurrently(
+		context.Background(), len(called), 3, func(index int) error {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			mutex.Lock()
+			if current > maxRunning {
+				maxRunning = current
+			}
+			called[index] = true
+			mutex.Unlock()
+			time.Sleep(time.Millisecond)
+			return nil
+		},
+	)
+
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	for index := range called {
+		assert.True(t, called[index], "index %d was not called", index)
+	}
+}
+
+func TestForEachConcurrently_JoinsErrorsInIndexOrder(t *testing.T) {
+	var calls int32
+
+	// Call the Method Under Test
+	err := forEachConcurrently(
+		context.Background(), 5, 5, func(index int) error {
+			atomic.AddInt32(&calls, 1)
+			if index%2 == 1 {
+				// Make the earlier failure finish last.
+				time.Sleep(time.Duration(5-index) * time.Millisecond)
+				return fmt.Errorf("error %d", index)
+			}
+			return nil
+		},
+	)
+
+	assert.EqualError(t, err, "error 1\nerror 3")
+	assert.Equal(t, int32(5), calls)
+}
+
+func TestForEachConcurrently_StopsWhenContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+
+	// Call the Method Under Test
+	err := forEachConcurrently(
+		ctx, 10, 1, func(index int) error {
+			if atomic.AddInt32(&calls, 1) == 2 {
+				cancel()
+			}
+			return nil
+		},
+	)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Less(t, calls, int32(10))
+}
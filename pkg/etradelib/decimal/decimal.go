@@ -0,0 +1,446 @@
+// Package decimal provides an exact decimal number type for prices,
+// quantities, and other monetary values. ETrade sends these as JSON numbers
+// with a few decimal places; converting them to float64 can change the last
+// digit, which is enough to make a reconciliation come out a penny off.
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number. It is stored as an integer coefficient
+// and a scale (the number of digits after the decimal point), so 12.30 is
+// 1230 with a scale of 2. Decimals are immutable: every operation returns a
+// new value. The zero value is 0.
+//
+// Two decimals with the same value but different scales (12.3 and 12.30) are
+// equal according to Cmp and Equal, but not according to ==.
+type Decimal struct {
+	// coefficient is nil when the value is zero.
+	coefficient *big.Int
+	scale       int32
+}
+
+// RoundingMode selects how a value is rounded when digits are dropped.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value, and rounds halves away from
+	// zero (2.5 -> 3, -2.5 -> -3). This is the usual rounding for money.
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfEven rounds to the nearest value, and rounds halves to the even
+	// neighbor (2.5 -> 2, 3.5 -> 4). This is also known as banker's rounding.
+	RoundHalfEven
+
+	// RoundHalfDown rounds to the nearest value, and rounds halves toward zero
+	// (2.5 -> 2, -2.5 -> -2).
+	RoundHalfDown
+
+	// RoundDown rounds toward zero, which truncates the dropped digits.
+	RoundDown
+
+	// RoundUp rounds away from zero.
+	RoundUp
+
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+)
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// MaxParsedScale bounds the scale of a parsed decimal (in either direction),
+// so that a string such as "1e100000000" is rejected rather than expanded
+// into an enormous integer. It is far beyond any price or quantity.
+const MaxParsedScale = 64
+
+// Zero is the decimal 0.
+var Zero = Decimal{}
+
+// New returns coefficient × 10^-scale. For example, New(1234, 2) is 12.34.
+func New(coefficient int64, scale int32) Decimal {
+	if scale < 0 {
+		return newDecimal(new(big.Int).Mul(big.NewInt(coefficient), pow10(-scale)), 0)
+	}
+	return newDecimal(big.NewInt(coefficient), scale)
+}
+
+// NewFromInt returns the decimal value of an integer.
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromString parses a decimal number such as "12.30", "-0.5", or "1.2e3".
+// The scale of the result is the number of digits after the decimal point,
+// so trailing zeros are kept. Values that would need more than MaxParsedScale
+// decimal places, or an exponent beyond MaxParsedScale, are rejected.
+func NewFromString(value string) (Decimal, error) {
+	return parse(value, MaxParsedScale)
+}
+
+// parse parses a decimal number whose scale may be at most scaleLimit in either
+// direction.
+func parse(value string, scaleLimit int64) (Decimal, error) {
+	mantissa, exponent := value, int64(0)
+	if i := strings.IndexAny(value, "eE"); i >= 0 {
+		var err error
+		mantissa = value[:i]
+		exponent, err = strconv.ParseInt(value[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("'%s' is not a decimal number", value)
+		}
+	}
+
+	digits := mantissa
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+	integerPart, fractionPart, _ := strings.Cut(digits, ".")
+	if integerPart == "" && fractionPart == "" || !isDigits(integerPart) || !isDigits(fractionPart) {
+		return Zero, fmt.Errorf("'%s' is not a decimal number", value)
+	}
+
+	coefficient, ok := new(big.Int).SetString(integerPart+fractionPart, 10)
+	if !ok {
+		return Zero, fmt.Errorf("'%s' is not a decimal number", value)
+	}
+	if mantissa[0] == '-' {
+		coefficient.Neg(coefficient)
+	}
+
+	scale := int64(len(fractionPart)) - exponent
+	if scale > scaleLimit || scale < -scaleLimit {
+		return Zero, fmt.Errorf("'%s' is out of range", value)
+	}
+	if scale < 0 {
+		return newDecimal(coefficient.Mul(coefficient, pow10(int32(-scale))), 0), nil
+	}
+	return newDecimal(coefficient, int32(scale)), nil
+}
+
+// MustNewFromString is like NewFromString, but panics if the value can't be
+// parsed. It is meant for constants and tests.
+func MustNewFromString(value string) Decimal {
+	d, err := NewFromString(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewFromJsonNumber returns the exact value of a JSON number.
+func NewFromJsonNumber(value json.Number) (Decimal, error) {
+	return NewFromString(value.String())
+}
+
+// NewFromFloat returns the shortest decimal that converts back to the same
+// float64, so NewFromFloat(0.1) is exactly 0.1. It panics if the value is NaN
+// or infinite.
+func NewFromFloat(value float64) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		panic(fmt.Sprintf("cannot convert %v to a decimal", value))
+	}
+	// A float64 has at most 1074 decimal places (and at most 308 digits
+	// before the point), so it can't be used to build an enormous value.
+	d, err := parse(strconv.FormatFloat(value, 'f', -1, 64), math.MaxInt32)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func newDecimal(coefficient *big.Int, scale int32) Decimal {
+	if coefficient.Sign() == 0 {
+		coefficient = nil
+	}
+	return Decimal{coefficient: coefficient, scale: scale}
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// value returns the coefficient, which is never nil.
+func (d Decimal) value() *big.Int {
+	if d.coefficient == nil {
+		return new(big.Int)
+	}
+	return d.coefficient
+}
+
+// rescaled returns the coefficient for the value at a scale that is no less
+// than d's scale.
+func (d Decimal) rescaled(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.value()
+	}
+	return new(big.Int).Mul(d.value(), pow10(scale-d.scale))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0, or 1 depending on whether d is negative, zero, or
+// positive.
+func (d Decimal) Sign() int {
+	if d.coefficient == nil {
+		return 0
+	}
+	return d.coefficient.Sign()
+}
+
+// IsZero returns true if d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IsPositive returns true if d is greater than 0.
+func (d Decimal) IsPositive() bool {
+	return d.Sign() > 0
+}
+
+// IsNegative returns true if d is less than 0.
+func (d Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
+
+// Cmp compares d and other, and returns -1 if d < other, 0 if d == other, and
+// 1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := maxScale(d, other)
+	return d.rescaled(scale).Cmp(other.rescaled(scale))
+}
+
+// Equal returns true if d and other have the same value, regardless of scale.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// LessThan returns true if d < other.
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+// GreaterThan returns true if d > other.
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+// Add returns d + other. The scale of the result is the larger of the two
+// scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return newDecimal(new(big.Int).Add(d.rescaled(scale), other.rescaled(scale)), scale)
+}
+
+// Sub returns d - other. The scale of the result is the larger of the two
+// scales.
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return newDecimal(new(big.Int).Sub(d.rescaled(scale), other.rescaled(scale)), scale)
+}
+
+// Mul returns d × other. The scale of the result is the sum of the two
+// scales, so the product is exact.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return newDecimal(new(big.Int).Mul(d.value(), other.value()), d.scale+other.scale)
+}
+
+// Div returns d ÷ other rounded to the given number of decimal places. It
+// panics if other is zero.
+func (d Decimal) Div(other Decimal, places int32, mode RoundingMode) Decimal {
+	if other.IsZero() {
+		panic(errors.New("decimal division by zero"))
+	}
+	// d / other × 10^places = d.coefficient × 10^(places - d.scale + other.scale) / other.coefficient
+	numerator := new(big.Int).Set(d.value())
+	denominator := new(big.Int).Set(other.value())
+	if exponent := places - d.scale + other.scale; exponent >= 0 {
+		numerator.Mul(numerator, pow10(exponent))
+	} else {
+		denominator.Mul(denominator, pow10(-exponent))
+	}
+	return newDecimal(divideAndRound(numerator, denominator, mode), places)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.value()), d.scale)
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	return newDecimal(new(big.Int).Abs(d.value()), d.scale)
+}
+
+// Round returns d with exactly the given number of decimal places. Dropped
+// digits are rounded with the given mode; if d has fewer places, zeros are
+// added.
+func (d Decimal) Round(places int32, mode RoundingMode) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if places >= d.scale {
+		return newDecimal(d.rescaled(places), places)
+	}
+	return newDecimal(divideAndRound(d.value(), pow10(d.scale-places), mode), places)
+}
+
+// divideAndRound returns numerator ÷ denominator rounded to an integer.
+func divideAndRound(numerator *big.Int, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// QuoRem truncates toward zero, so the quotient needs one step away from
+	// zero when rounding up in magnitude.
+	sign := remainder.Sign() * denominator.Sign()
+	half := new(big.Int).Abs(remainder)
+	half.Lsh(half, 1)
+	halfCmp := half.Cmp(new(big.Int).Abs(denominator))
+
+	var awayFromZero bool
+	switch mode {
+	case RoundHalfUp:
+		awayFromZero = halfCmp >= 0
+	case RoundHalfEven:
+		awayFromZero = halfCmp > 0 || halfCmp == 0 && quotient.Bit(0) == 1
+	case RoundHalfDown:
+		awayFromZero = halfCmp > 0
+	case RoundDown:
+		awayFromZero = false
+	case RoundUp:
+		awayFromZero = true
+	case RoundFloor:
+		awayFromZero = sign < 0
+	case RoundCeiling:
+		awayFromZero = sign > 0
+	default:
+		panic(fmt.Sprintf("unknown rounding mode %d", mode))
+	}
+	if awayFromZero {
+		if sign < 0 {
+			quotient.Sub(quotient, bigOne)
+		} else {
+			quotient.Add(quotient, bigOne)
+		}
+	}
+	return quotient
+}
+
+func maxScale(a Decimal, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// IntPart returns the integer part of d, truncated toward zero. It returns
+// an error if the integer part doesn't fit in an int64.
+func (d Decimal) IntPart() (int64, error) {
+	intPart := d.Round(0, RoundDown).value()
+	if !intPart.IsInt64() {
+		return 0, fmt.Errorf("%s is out of range for int64", d)
+	}
+	return intPart.Int64(), nil
+}
+
+// Float64 returns the nearest float64 to d. It is meant for display and for
+// APIs that require floats, not for further arithmetic.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d in plain decimal notation with all of its decimal places,
+// such as "12.30" or "-0.5".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	if d.scale > 0 {
+		if len(digits) <= int(d.scale) {
+			digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// StringFixed returns d rounded half up to the given number of decimal
+// places, padded with zeros if needed. StringFixed(2) formats a dollar amount
+// as dollars and cents.
+func (d Decimal) StringFixed(places int32) string {
+	return d.Round(places, RoundHalfUp).String()
+}
+
+// JsonNumber returns d as a json.Number, so that it can be placed in a
+// JsonMap and marshaled without losing precision.
+func (d Decimal) JsonNumber() json.Number {
+	return json.Number(d.String())
+}
+
+// MarshalJSON marshals d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, a string that contains a number, or
+// null (which leaves d unchanged).
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := NewFromString(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText marshals d in plain decimal notation.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a decimal number. It lets a Decimal be read from YAML
+// and other text formats.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := NewFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
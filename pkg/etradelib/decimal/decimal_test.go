@@ -0,0 +1,336 @@
+package decimal
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"testing"
+)
+
+func TestNewFromString(t *testing.T) {
+	tests := []struct {
+		name         string
+		testString   string
+		expectErr    bool
+		expectString string
+	}{
+		{
+			name:         "Parses Integer",
+			testString:   "123",
+			expectString: "123",
+		},
+		{
+			name:         "Parses Decimal And Keeps Trailing Zeros",
+			testString:   "12.30",
+			expectString: "12.30",
+		},
+		{
+			name:         "Parses Negative Fraction Without Integer Part",
+			testString:   "-.5",
+			expectString: "-0.5",
+		},
+		{
+			name:         "Parses Explicit Plus Sign",
+			testString:   "+0.01",
+			expectString: "0.01",
+		},
+		{
+			name:         "Parses Positive Exponent",
+			testString:   "1.25e3",
+			expectString: "1250",
+		},
+		{
+			name:         "Parses Negative Exponent",
+			testString:   "125E-4",
+			expectString: "0.0125",
+		},
+		{
+			name:         "Parses Digits Beyond Float64 Precision",
+			testString:   "12345678901234567890.123456789",
+			expectString: "12345678901234567890.123456789",
+		},
+		{
+			name:       "Fails With Empty String",
+			testString: "",
+			expectErr:  true,
+		},
+		{
+			name:       "Fails With Lone Point",
+			testString: ".",
+			expectErr:  true,
+		},
+		{
+			name:       "Fails With Two Points",
+			testString: "1.2.3",
+			expectErr:  true,
+		},
+		{
+			name:       "Fails With Letters",
+			testString: "12abc",
+			expectErr:  true,
+		},
+		{
+			name:       "Fails With Bad Exponent",
+			testString: "1e",
+			expectErr:  true,
+		},
+		{
+			name:         "Parses Largest Exponent",
+			testString:   "1e64",
+			expectString: "1" + strings.Repeat("0", 64),
+		},
+		{
+			name:       "Fails With Huge Exponent",
+			testString: "1e100000000",
+			expectErr:  true,
+		},
+		{
+			name:       "Fails With Huge Negative Exponent",
+			testString: "1e-10000000",
+			expectErr:  true,
+		},
+		{
+			name:       "Fails With Too Many Decimal Places",
+			testString: "0." + strings.Repeat("1", 65),
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actual, err := NewFromString(tt.testString)
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.expectString, actual.String())
+				}
+			},
+		)
+	}
+}
+
+func TestNewFromFloat(t *testing.T) {
+	// Call the Method Under Test
+	actual := NewFromFloat(0.1)
+
+	assert.Equal(t, "0.1", actual.String())
+}
+
+func TestNewFromFloat_AllowsScaleBeyondParsingLimit(t *testing.T) {
+	// Call the Method Under Test
+	actual := NewFromFloat(1e-70)
+
+	assert.Equal(t, int32(70), actual.Scale())
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, "12.34", New(1234, 2).String())
+	assert.Equal(t, "-0.05", New(-5, 2).String())
+	assert.Equal(t, "1200", New(12, -2).String())
+	assert.Equal(t, "0", Zero.String())
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name   string
+		testFn func() Decimal
+		expect string
+	}{
+		{
+			name:   "Adds Exactly",
+			testFn: func() Decimal { return MustNewFromString("0.1").Add(MustNewFromString("0.2")) },
+			expect: "0.3",
+		},
+		{
+			name:   "Adds With Larger Scale",
+			testFn: func() Decimal { return MustNewFromString("10").Add(MustNewFromString("0.05")) },
+			expect: "10.05",
+		},
+		{
+			name:   "Subtracts Below Zero",
+			testFn: func() Decimal { return MustNewFromString("1.00").Sub(MustNewFromString("1.01")) },
+			expect: "-0.01",
+		},
+		{
+			name:   "Multiplies Exactly",
+			testFn: func() Decimal { return MustNewFromString("19.99").Mul(MustNewFromString("3")) },
+			expect: "59.97",
+		},
+		{
+			name:   "Multiplies Fractions",
+			testFn: func() Decimal { return MustNewFromString("1.5").Mul(MustNewFromString("-0.25")) },
+			expect: "-0.375",
+		},
+		{
+			name: "Divides And Rounds",
+			testFn: func() Decimal {
+				return MustNewFromString("10").Div(MustNewFromString("3"), 2, RoundHalfUp)
+			},
+			expect: "3.33",
+		},
+		{
+			name: "Divides By Fraction",
+			testFn: func() Decimal {
+				return MustNewFromString("1").Div(MustNewFromString("0.08"), 4, RoundHalfUp)
+			},
+			expect: "12.5000",
+		},
+		{
+			name: "Divides Negative And Rounds Toward Floor",
+			testFn: func() Decimal {
+				return MustNewFromString("-2").Div(MustNewFromString("3"), 2, RoundFloor)
+			},
+			expect: "-0.67",
+		},
+		{
+			name:   "Negates",
+			testFn: func() Decimal { return MustNewFromString("12.30").Neg() },
+			expect: "-12.30",
+		},
+		{
+			name:   "Takes Absolute Value",
+			testFn: func() Decimal { return MustNewFromString("-0.07").Abs() },
+			expect: "0.07",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actual := tt.testFn()
+
+				assert.Equal(t, tt.expect, actual.String())
+			},
+		)
+	}
+}
+
+func TestDecimal_Div_PanicsOnDivisionByZero(t *testing.T) {
+	assert.Panics(
+		t, func() {
+			// Call the Method Under Test
+			MustNewFromString("1").Div(Zero, 2, RoundHalfUp)
+		},
+	)
+}
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		places int32
+		mode   RoundingMode
+		expect string
+	}{
+		{"Half Up Rounds Half Away From Zero", "2.345", 2, RoundHalfUp, "2.35"},
+		{"Half Up Rounds Negative Half Away From Zero", "-2.345", 2, RoundHalfUp, "-2.35"},
+		{"Half Up Rounds Below Half Down", "2.3449", 2, RoundHalfUp, "2.34"},
+		{"Half Even Rounds Half To Even", "2.345", 2, RoundHalfEven, "2.34"},
+		{"Half Even Rounds Odd Half Up", "2.355", 2, RoundHalfEven, "2.36"},
+		{"Half Even Rounds Above Half Up", "2.3451", 2, RoundHalfEven, "2.35"},
+		{"Half Down Rounds Half Toward Zero", "2.345", 2, RoundHalfDown, "2.34"},
+		{"Half Down Rounds Above Half Up", "2.3451", 2, RoundHalfDown, "2.35"},
+		{"Down Truncates", "2.349", 2, RoundDown, "2.34"},
+		{"Down Truncates Negative", "-2.349", 2, RoundDown, "-2.34"},
+		{"Up Rounds Away From Zero", "2.341", 2, RoundUp, "2.35"},
+		{"Up Rounds Negative Away From Zero", "-2.341", 2, RoundUp, "-2.35"},
+		{"Floor Rounds Toward Negative Infinity", "-2.341", 2, RoundFloor, "-2.35"},
+		{"Floor Rounds Positive Down", "2.349", 2, RoundFloor, "2.34"},
+		{"Ceiling Rounds Toward Positive Infinity", "2.341", 2, RoundCeiling, "2.35"},
+		{"Ceiling Rounds Negative Up", "-2.349", 2, RoundCeiling, "-2.34"},
+		{"Pads With Zeros", "2.5", 3, RoundHalfUp, "2.500"},
+		{"Rounds To Integer", "2.5", 0, RoundHalfEven, "2"},
+		{"Rounds Small Value To Zero", "0.004", 2, RoundHalfUp, "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actual := MustNewFromString(tt.value).Round(tt.places, tt.mode)
+
+				assert.Equal(t, tt.expect, actual.String())
+			},
+		)
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	assert.Equal(t, 0, MustNewFromString("12.3").Cmp(MustNewFromString("12.300")))
+	assert.Equal(t, -1, MustNewFromString("-1").Cmp(Zero))
+	assert.Equal(t, 1, MustNewFromString("0.011").Cmp(MustNewFromString("0.01")))
+	assert.True(t, MustNewFromString("0.00").Equal(Zero))
+	assert.True(t, MustNewFromString("99.99").LessThan(MustNewFromString("100")))
+	assert.True(t, MustNewFromString("100.01").GreaterThan(MustNewFromString("100")))
+	assert.True(t, MustNewFromString("0.00").IsZero())
+	assert.True(t, MustNewFromString("0.01").IsPositive())
+	assert.True(t, MustNewFromString("-0.01").IsNegative())
+}
+
+func TestDecimal_StringFixed(t *testing.T) {
+	assert.Equal(t, "1234.57", MustNewFromString("1234.565").StringFixed(2))
+	assert.Equal(t, "12.00", MustNewFromString("12").StringFixed(2))
+	assert.Equal(t, "-0.10", MustNewFromString("-0.1").StringFixed(2))
+}
+
+func TestDecimal_IntPart(t *testing.T) {
+	actual, err := MustNewFromString("-12.99").IntPart()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-12), actual)
+
+	_, err = MustNewFromString("1e20").IntPart()
+	assert.Error(t, err)
+}
+
+func TestDecimal_Float64(t *testing.T) {
+	assert.Equal(t, 12.34, MustNewFromString("12.34").Float64())
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type testStruct struct {
+		Price Decimal `json:"price"`
+	}
+
+	var fromNumber testStruct
+	require.Nil(t, json.Unmarshal([]byte(`{"price": 1234.50}`), &fromNumber))
+	assert.Equal(t, "1234.50", fromNumber.Price.String())
+
+	var fromString testStruct
+	require.Nil(t, json.Unmarshal([]byte(`{"price": "0.10"}`), &fromString))
+	assert.Equal(t, "0.10", fromString.Price.String())
+
+	var fromNull testStruct
+	require.Nil(t, json.Unmarshal([]byte(`{"price": null}`), &fromNull))
+	assert.True(t, fromNull.Price.IsZero())
+
+	var invalid testStruct
+	assert.Error(t, json.Unmarshal([]byte(`{"price": "abc"}`), &invalid))
+
+	marshaled, err := json.Marshal(testStruct{Price: MustNewFromString("0.10")})
+	require.Nil(t, err)
+	assert.Equal(t, `{"price":0.10}`, string(marshaled))
+}
+
+func TestNewFromJsonNumber(t *testing.T) {
+	actual, err := NewFromJsonNumber(json.Number("1234.567"))
+	assert.Nil(t, err)
+	assert.Equal(t, "1234.567", actual.String())
+}
+
+func TestDecimal_YAML(t *testing.T) {
+	type testStruct struct {
+		Price Decimal `yaml:"price"`
+	}
+
+	var actual testStruct
+	require.Nil(t, yaml.Unmarshal([]byte("price: 152.10\n"), &actual))
+	assert.Equal(t, "152.10", actual.Price.String())
+
+	assert.Error(t, yaml.Unmarshal([]byte("price: abc\n"), &actual))
+}
@@ -7,9 +7,22 @@ import (
 type ETradeAccount interface {
 	GetId() string
 	GetIdKey() string
+	Typed() (Account, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Account is a typed view of an ETrade account.
+type Account struct {
+	Id              string
+	IdKey           string
+	Mode            string
+	Description     string
+	Name            string
+	Type            string
+	InstitutionType string
+	Status          string
+}
+
 type eTradeAccount struct {
 	id      string
 	idKey   string
@@ -62,3 +75,18 @@ func (e *eTradeAccount) GetIdKey() string {
 func (e *eTradeAccount) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeAccount) Typed() (Account, error) {
+	r := newTypedReader(e.jsonMap)
+	account := Account{
+		Id:              e.id,
+		IdKey:           e.idKey,
+		Mode:            r.string(".accountMode"),
+		Description:     r.string(".accountDesc"),
+		Name:            r.string(".accountName"),
+		Type:            r.string(".accountType"),
+		InstitutionType: r.string(".institutionType"),
+		Status:          r.string(".accountStatus"),
+	}
+	return account, r.err
+}
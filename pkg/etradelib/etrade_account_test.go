@@ -118,3 +118,36 @@ func TestETradeAccount_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeAccount_Typed(t *testing.T) {
+	responseMap, err := NewNormalizedJsonMap(
+		[]byte(`
+{
+  "accountId": "84910001",
+  "accountIdKey": "fakeKey1",
+  "accountMode": "MARGIN",
+  "accountDesc": "Brokerage",
+  "accountName": "",
+  "accountType": "INDIVIDUAL",
+  "institutionType": "BROKERAGE",
+  "accountStatus": "ACTIVE"
+}`),
+	)
+	require.Nil(t, err)
+	account, err := CreateETradeAccountFromMap(responseMap)
+	require.Nil(t, err)
+	expectValue := Account{
+		Id:              "84910001",
+		IdKey:           "fakeKey1",
+		Mode:            "MARGIN",
+		Description:     "Brokerage",
+		Type:            "INDIVIDUAL",
+		InstitutionType: "BROKERAGE",
+		Status:          "ACTIVE",
+	}
+
+	// Call the Method Under Test
+	actualValue, err := account.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
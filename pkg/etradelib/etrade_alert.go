@@ -1,14 +1,25 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
 )
 
 type ETradeAlert interface {
 	GetId() int64
+	Typed() (Alert, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Alert is a typed view of an ETrade alert.
+type Alert struct {
+	Id         int64
+	CreateTime time.Time
+	Subject    string
+	Status     constants.AlertStatus
+}
+
 type eTradeAlert struct {
 	id      int64
 	jsonMap jsonmap.JsonMap
@@ -44,3 +55,14 @@ func (e *eTradeAlert) GetId() int64 {
 func (e *eTradeAlert) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeAlert) Typed() (Alert, error) {
+	r := newTypedReader(e.jsonMap)
+	alert := Alert{
+		Id:         e.id,
+		CreateTime: r.seconds(".createTime"),
+		Subject:    r.string(".subject"),
+		Status:     readEnum(r, ".status", constants.AlertStatusFromString),
+	}
+	return alert, r.err
+}
@@ -2,10 +2,12 @@ package etradelib
 
 import (
 	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeAlert(t *testing.T) {
@@ -85,3 +87,29 @@ func TestETradeAlert_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeAlert_Typed(t *testing.T) {
+	responseMap, err := NewNormalizedJsonMap(
+		[]byte(`
+{
+  "id": 601,
+  "createTime": 1695758400,
+  "subject": "GOOG is up 5%",
+  "status": "UNREAD"
+}`),
+	)
+	require.Nil(t, err)
+	alert, err := CreateETradeAlert(responseMap)
+	require.Nil(t, err)
+	expectValue := Alert{
+		Id:         601,
+		CreateTime: time.Unix(1695758400, 0),
+		Subject:    "GOOG is up 5%",
+		Status:     constants.AlertStatusUnread,
+	}
+
+	// Call the Method Under Test
+	actualValue, err := alert.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
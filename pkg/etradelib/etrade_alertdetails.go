@@ -1,12 +1,27 @@
 package etradelib
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
+)
 
 type ETradeAlertDetails interface {
 	GetId() int64
+	Typed() (AlertDetails, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// AlertDetails is a typed view of an ETrade alert's details.
+type AlertDetails struct {
+	Id         int64
+	CreateTime time.Time
+	Subject    string
+	Message    string
+	Symbol     string
+	ReadTime   time.Time
+	DeleteTime time.Time
+}
+
 type eTradeAlertDetails struct {
 	id      int64
 	jsonMap jsonmap.JsonMap
@@ -61,3 +76,17 @@ func (e *eTradeAlertDetails) GetId() int64 {
 func (e *eTradeAlertDetails) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeAlertDetails) Typed() (AlertDetails, error) {
+	r := newTypedReader(e.jsonMap)
+	alertDetails := AlertDetails{
+		Id:         e.id,
+		CreateTime: r.seconds(".createTime"),
+		Subject:    r.string(".subject"),
+		Message:    r.string(".msgText"),
+		Symbol:     r.string(".symbol"),
+		ReadTime:   r.seconds(".readTime"),
+		DeleteTime: r.seconds(".deleteTime"),
+	}
+	return alertDetails, r.err
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeAlertDetailsFromResponse(t *testing.T) {
@@ -106,3 +108,32 @@ func TestETradeAlertDetails_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeAlertDetails_Typed(t *testing.T) {
+	alertDetails, err := CreateETradeAlertDetailsFromResponse(
+		[]byte(`
+{
+  "AlertDetailsResponse": {
+    "id": 601,
+    "createTime": 1695758400,
+    "subject": "GOOG is up 5%",
+    "msgText": "GOOG rose 5% today.",
+    "readTime": 0,
+    "symbol": "GOOG"
+  }
+}`),
+	)
+	require.Nil(t, err)
+	expectValue := AlertDetails{
+		Id:         601,
+		CreateTime: time.Unix(1695758400, 0),
+		Subject:    "GOOG is up 5%",
+		Message:    "GOOG rose 5% today.",
+		Symbol:     "GOOG",
+	}
+
+	// Call the Method Under Test
+	actualValue, err := alertDetails.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
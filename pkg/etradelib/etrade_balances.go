@@ -1,11 +1,35 @@
 package etradelib
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
 
 type ETradeBalances interface {
+	Typed() (Balances, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Balances is a typed view of an ETrade account's balances. Amounts are
+// exactly as ETrade sent them.
+type Balances struct {
+	AccountId                  string
+	AccountType                string
+	AccountDescription         string
+	OptionLevel                string
+	CashAvailableForInvestment decimal.Decimal
+	CashAvailableForWithdrawal decimal.Decimal
+	NetCash                    decimal.Decimal
+	CashBalance                decimal.Decimal
+	CashBuyingPower            decimal.Decimal
+	MarginBuyingPower          decimal.Decimal
+	AccountBalance             decimal.Decimal
+	TotalAccountValue          decimal.Decimal
+	NetMarketValue             decimal.Decimal
+	NetMarketValueLong         decimal.Decimal
+	NetMarketValueShort        decimal.Decimal
+}
+
 type eTradeBalances struct {
 	balancesMap jsonmap.JsonMap
 }
@@ -46,3 +70,25 @@ func CreateETradeBalances(responseMap jsonmap.JsonMap) (ETradeBalances, error) {
 func (e *eTradeBalances) AsJsonMap() jsonmap.JsonMap {
 	return e.balancesMap
 }
+
+func (e *eTradeBalances) Typed() (Balances, error) {
+	r := newTypedReader(e.balancesMap)
+	balances := Balances{
+		AccountId:                  r.string(".accountId"),
+		AccountType:                r.string(".accountType"),
+		AccountDescription:         r.string(".accountDescription"),
+		OptionLevel:                r.string(".optionLevel"),
+		CashAvailableForInvestment: r.number(".computed.cashAvailableForInvestment"),
+		CashAvailableForWithdrawal: r.number(".computed.cashAvailableForWithdrawal"),
+		NetCash:                    r.number(".computed.netCash"),
+		CashBalance:                r.number(".computed.cashBalance"),
+		CashBuyingPower:            r.number(".computed.cashBuyingPower"),
+		MarginBuyingPower:          r.number(".computed.marginBuyingPower"),
+		AccountBalance:             r.number(".computed.accountBalance"),
+		TotalAccountValue:          r.number(".computed.realTimeValues.totalAccountValue"),
+		NetMarketValue:             r.number(".computed.realTimeValues.netMv"),
+		NetMarketValueLong:         r.number(".computed.realTimeValues.netMvLong"),
+		NetMarketValueShort:        r.number(".computed.realTimeValues.netMvShort"),
+	}
+	return balances, r.err
+}
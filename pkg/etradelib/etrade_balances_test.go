@@ -1,8 +1,10 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -81,3 +83,41 @@ func TestETradeBalances_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectValue, actualValue)
 }
+
+func TestETradeBalances_Typed(t *testing.T) {
+	balances, err := CreateETradeBalancesFromResponse(
+		[]byte(`
+{
+  "BalanceResponse": {
+    "accountId": "84910001",
+    "accountType": "INDIVIDUAL",
+    "optionLevel": "LEVEL_2",
+    "Computed": {
+      "cashAvailableForInvestment": 1000.01,
+      "netCash": 1000.01,
+      "cashBuyingPower": 1000.01,
+      "RealTimeValues": {
+        "totalAccountValue": 9800.11,
+        "netMv": 8800.10
+      }
+    }
+  }
+}`),
+	)
+	require.Nil(t, err)
+	expectValue := Balances{
+		AccountId:                  "84910001",
+		AccountType:                "INDIVIDUAL",
+		OptionLevel:                "LEVEL_2",
+		CashAvailableForInvestment: decimal.MustNewFromString("1000.01"),
+		NetCash:                    decimal.MustNewFromString("1000.01"),
+		CashBuyingPower:            decimal.MustNewFromString("1000.01"),
+		TotalAccountValue:          decimal.MustNewFromString("9800.11"),
+		NetMarketValue:             decimal.MustNewFromString("8800.10"),
+	}
+
+	// Call the Method Under Test
+	actualValue, err := balances.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
@@ -0,0 +1,71 @@
+package etradelib
+
+import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+
+type ETradeCancelOrder interface {
+	GetOrderId() int64
+	AsJsonMap() jsonmap.JsonMap
+}
+
+type eTradeCancelOrder struct {
+	orderId int64
+	jsonMap jsonmap.JsonMap
+}
+
+const (
+	// The cancel order response JSON looks like this:
+	// {
+	//   "CancelOrderResponse": {
+	//     "accountId": "<account ID>",
+	//     "orderId": 1234,
+	//     "cancelTime": 1234567890000,
+	//     "Messages": {
+	//       "Message": [
+	//         {
+	//           <message info>
+	//         }
+	//       ]
+	//     }
+	//   }
+	// }
+
+	// cancelOrderResponsePath is the path to the map of cancellation info
+	cancelOrderResponsePath = ".cancelOrderResponse"
+
+	// cancelOrderOrderIdResponseKey is the key for the order ID within the
+	// cancellation info map
+	cancelOrderOrderIdResponseKey = "orderId"
+)
+
+func CreateETradeCancelOrderFromResponse(response []byte) (ETradeCancelOrder, error) {
+	responseMap, err := NewNormalizedJsonMap(response)
+	if err != nil {
+		return nil, err
+	}
+	return CreateETradeCancelOrder(responseMap)
+}
+
+func CreateETradeCancelOrder(responseMap jsonmap.JsonMap) (ETradeCancelOrder, error) {
+	cancelMap, err := responseMap.GetMapAtPath(cancelOrderResponsePath)
+	if err != nil {
+		return nil, err
+	}
+
+	orderId, err := cancelMap.GetInt(cancelOrderOrderIdResponseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eTradeCancelOrder{
+		orderId: orderId,
+		jsonMap: cancelMap,
+	}, nil
+}
+
+func (e *eTradeCancelOrder) GetOrderId() int64 {
+	return e.orderId
+}
+
+func (e *eTradeCancelOrder) AsJsonMap() jsonmap.JsonMap {
+	return e.jsonMap
+}
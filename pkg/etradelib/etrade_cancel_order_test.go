@@ -0,0 +1,94 @@
+package etradelib
+
+import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateETradeCancelOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		testJson    string
+		expectErr   bool
+		expectValue ETradeCancelOrder
+	}{
+		{
+			name: "Creates Cancel Order",
+			testJson: `
+{
+  "CancelOrderResponse": {
+    "accountId": "TestAccountId",
+    "orderId": 1234,
+    "cancelTime": 1234567890000
+  }
+}`,
+			expectErr: false,
+			expectValue: &eTradeCancelOrder{
+				orderId: 1234,
+				jsonMap: jsonmap.JsonMap{
+					"accountId":  "TestAccountId",
+					"orderId":    json.Number("1234"),
+					"cancelTime": json.Number("1234567890000"),
+				},
+			},
+		},
+		{
+			name: "Fails Without Order ID",
+			testJson: `
+{
+  "CancelOrderResponse": {
+    "accountId": "TestAccountId"
+  }
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails On Bad JSON",
+			testJson: `
+{
+  "CancelOrderResponse": {
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := CreateETradeCancelOrderFromResponse([]byte(tt.testJson))
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestETradeCancelOrder_GetOrderId(t *testing.T) {
+	testObject := &eTradeCancelOrder{
+		orderId: 1234,
+		jsonMap: jsonmap.JsonMap{},
+	}
+	assert.Equal(t, int64(1234), testObject.GetOrderId())
+}
+
+func TestETradeCancelOrder_AsJsonMap(t *testing.T) {
+	testObject := &eTradeCancelOrder{
+		orderId: 1234,
+		jsonMap: jsonmap.JsonMap{
+			"orderId": json.Number("1234"),
+		},
+	}
+	expectedValue := jsonmap.JsonMap{
+		"orderId": json.Number("1234"),
+	}
+	assert.Equal(t, expectedValue, testObject.AsJsonMap())
+}
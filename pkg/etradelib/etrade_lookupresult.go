@@ -5,9 +5,17 @@ import (
 )
 
 type ETradeLookupResult interface {
+	Typed() (LookupResult, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// LookupResult is a typed view of an ETrade product lookup result.
+type LookupResult struct {
+	Symbol      string
+	Description string
+	Type        string
+}
+
 type eTradeLookupResult struct {
 	jsonMap jsonmap.JsonMap
 }
@@ -21,3 +29,13 @@ func CreateETradeLookupResult(responseMap jsonmap.JsonMap) (ETradeLookupResult,
 func (e *eTradeLookupResult) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeLookupResult) Typed() (LookupResult, error) {
+	r := newTypedReader(e.jsonMap)
+	lookupResult := LookupResult{
+		Symbol:      r.string(".symbol"),
+		Description: r.string(".description"),
+		Type:        r.string(".type"),
+	}
+	return lookupResult, r.err
+}
@@ -60,3 +60,23 @@ func TestETradeLookupResult_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeLookupResult_Typed(t *testing.T) {
+	lookupResult := &eTradeLookupResult{
+		jsonMap: jsonmap.JsonMap{
+			"symbol":      "GOOG",
+			"description": "ALPHABET INC CAP STK CL C",
+			"type":        "EQUITY",
+		},
+	}
+	expectValue := LookupResult{
+		Symbol:      "GOOG",
+		Description: "ALPHABET INC CAP STK CL C",
+		Type:        "EQUITY",
+	}
+
+	// Call the Method Under Test
+	actualValue, err := lookupResult.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
@@ -1,13 +1,53 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
 )
 
 type ETradeOptionChainPair interface {
+	Typed() (OptionChainPair, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// OptionChainPair is a typed view of an ETrade option chain pair. Either
+// option is nil if ETrade didn't send it (e.g. if only calls were requested).
+type OptionChainPair struct {
+	Call *OptionDetails
+	Put  *OptionDetails
+}
+
+// OptionDetails is a typed view of one of the options in an ETrade option
+// chain pair. Prices and greeks are exactly as ETrade sent them.
+type OptionDetails struct {
+	Symbol         string
+	RootSymbol     string
+	DisplaySymbol  string
+	OsiKey         string
+	CallPut        constants.OptionCallPut
+	StrikePrice    decimal.Decimal
+	Bid            decimal.Decimal
+	Ask            decimal.Decimal
+	BidSize        int64
+	AskSize        int64
+	LastPrice      decimal.Decimal
+	NetChange      decimal.Decimal
+	Volume         int64
+	OpenInterest   int64
+	InTheMoney     bool
+	Delta          decimal.Decimal
+	Gamma          decimal.Decimal
+	Theta          decimal.Decimal
+	Vega           decimal.Decimal
+	Rho            decimal.Decimal
+	Iv             decimal.Decimal
+	TimeStamp      time.Time
+	AdjustedFlag   bool
+	OptionCategory string
+}
+
 type eTradeOptionChainPair struct {
 	jsonMap jsonmap.JsonMap
 }
@@ -21,3 +61,44 @@ func CreateETradeOptionChainPair(responseMap jsonmap.JsonMap) (ETradeOptionChain
 func (e *eTradeOptionChainPair) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeOptionChainPair) Typed() (OptionChainPair, error) {
+	r := newTypedReader(e.jsonMap)
+	optionChainPair := OptionChainPair{
+		Call: readOptionDetails(r, ".call"),
+		Put:  readOptionDetails(r, ".put"),
+	}
+	return optionChainPair, r.err
+}
+
+func readOptionDetails(r *typedReader, path string) *OptionDetails {
+	if r.value(path) == nil {
+		return nil
+	}
+	return &OptionDetails{
+		Symbol:         r.string(path + ".symbol"),
+		RootSymbol:     r.string(path + ".optionRootSymbol"),
+		DisplaySymbol:  r.string(path + ".displaySymbol"),
+		OsiKey:         r.string(path + ".osiKey"),
+		CallPut:        readEnum(r, path+".optionType", constants.OptionCallPutFromString),
+		StrikePrice:    r.number(path + ".strikePrice"),
+		Bid:            r.number(path + ".bid"),
+		Ask:            r.number(path + ".ask"),
+		BidSize:        r.int(path + ".bidSize"),
+		AskSize:        r.int(path + ".askSize"),
+		LastPrice:      r.number(path + ".lastPrice"),
+		NetChange:      r.number(path + ".netChange"),
+		Volume:         r.int(path + ".volume"),
+		OpenInterest:   r.int(path + ".openInterest"),
+		InTheMoney:     r.bool(path + ".inTheMoney"),
+		Delta:          r.number(path + ".optionGreeks.delta"),
+		Gamma:          r.number(path + ".optionGreeks.gamma"),
+		Theta:          r.number(path + ".optionGreeks.theta"),
+		Vega:           r.number(path + ".optionGreeks.vega"),
+		Rho:            r.number(path + ".optionGreeks.rho"),
+		Iv:             r.number(path + ".optionGreeks.iv"),
+		TimeStamp:      r.seconds(path + ".timeStamp"),
+		AdjustedFlag:   r.bool(path + ".adjustedFlag"),
+		OptionCategory: r.string(path + ".optionCategory"),
+	}
+}
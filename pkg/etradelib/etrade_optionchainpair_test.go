@@ -1,10 +1,13 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeOptionChainPair(t *testing.T) {
@@ -60,3 +63,59 @@ func TestETradeOptionChainPair_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeOptionChainPair_Typed(t *testing.T) {
+	responseMap, err := NewNormalizedJsonMap(
+		[]byte(`
+{
+  "Call": {
+    "optionCategory": "STANDARD",
+    "optionRootSymbol": "GOOG",
+    "displaySymbol": "GOOG Oct 20 '23 $140 Call",
+    "osiKey": "GOOG--231020C00140000",
+    "optionType": "CALL",
+    "strikePrice": 140,
+    "bid": 3.1,
+    "ask": 3.2,
+    "bidSize": 10,
+    "askSize": 12,
+    "volume": 300,
+    "openInterest": 1500,
+    "inTheMoney": "y",
+    "timeStamp": 1695758400,
+    "OptionGreeks": {
+      "delta": 0.5123,
+      "iv": 0.2761
+    }
+  }
+}`),
+	)
+	require.Nil(t, err)
+	optionChainPair, err := CreateETradeOptionChainPair(responseMap)
+	require.Nil(t, err)
+	expectValue := OptionChainPair{
+		Call: &OptionDetails{
+			RootSymbol:     "GOOG",
+			DisplaySymbol:  "GOOG Oct 20 '23 $140 Call",
+			OsiKey:         "GOOG--231020C00140000",
+			CallPut:        constants.OptionCallPutCall,
+			StrikePrice:    decimal.MustNewFromString("140"),
+			Bid:            decimal.MustNewFromString("3.1"),
+			Ask:            decimal.MustNewFromString("3.2"),
+			BidSize:        10,
+			AskSize:        12,
+			Volume:         300,
+			OpenInterest:   1500,
+			InTheMoney:     true,
+			Delta:          decimal.MustNewFromString("0.5123"),
+			Iv:             decimal.MustNewFromString("0.2761"),
+			TimeStamp:      time.Unix(1695758400, 0),
+			OptionCategory: "STANDARD",
+		},
+	}
+
+	// Call the Method Under Test
+	actualValue, err := optionChainPair.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
@@ -1,6 +1,7 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 )
 
@@ -13,7 +14,7 @@ type eTradeOptionChainPairList struct {
 	optionChainPairs []ETradeOptionChainPair
 	timeStamp        int64
 	quoteType        string
-	nearPrice        float64
+	nearPrice        decimal.Decimal
 	selectedED       jsonmap.JsonMap
 }
 
@@ -119,11 +120,11 @@ func CreateETradeOptionChainPairList(responseMap jsonmap.JsonMap) (ETradeOptionC
 		return nil, err
 	}
 
-	nearPrice, err := responseMap.GetFloatAtPathWithDefault(
-		optionChainPairListNearPriceResponsePath, 0,
-	)
-	if err != nil {
-		return nil, err
+	// The near price is read exactly rather than as a float.
+	nearPriceReader := newTypedReader(responseMap)
+	nearPrice := nearPriceReader.number(optionChainPairListNearPriceResponsePath)
+	if nearPriceReader.err != nil {
+		return nil, nearPriceReader.err
 	}
 
 	selectedED, err := responseMap.GetMapAtPathWithDefault(
@@ -170,7 +171,7 @@ func (e *eTradeOptionChainPairList) AsJsonMap() jsonmap.JsonMap {
 		panic(err)
 	}
 
-	err = optionChainPairListMap.SetFloatAtPath(OptionChainPairListNearPricePath, e.nearPrice)
+	err = optionChainPairListMap.SetValueAtPath(OptionChainPairListNearPricePath, e.nearPrice.JsonNumber())
 	if err != nil {
 		panic(err)
 	}
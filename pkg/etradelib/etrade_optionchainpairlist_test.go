@@ -2,6 +2,7 @@ package etradelib
 
 import (
 	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -43,7 +44,7 @@ func TestCreateETradeOptionChainPairListFromResponse(t *testing.T) {
 				},
 				timeStamp: 1234,
 				quoteType: "1234",
-				nearPrice: 123.4,
+				nearPrice: decimal.MustNewFromString("123.4"),
 				selectedED: jsonmap.JsonMap{
 					"key2": "value2",
 				},
@@ -69,7 +70,7 @@ func TestCreateETradeOptionChainPairListFromResponse(t *testing.T) {
 				optionChainPairs: []ETradeOptionChainPair{},
 				timeStamp:        1234,
 				quoteType:        "1234",
-				nearPrice:        123.4,
+				nearPrice:        decimal.MustNewFromString("123.4"),
 				selectedED: jsonmap.JsonMap{
 					"key1": "value1",
 				},
@@ -134,7 +135,7 @@ func TestCreateETradeOptionChainPairListFromResponse(t *testing.T) {
 				},
 				timeStamp: 0,
 				quoteType: "1234",
-				nearPrice: 123.4,
+				nearPrice: decimal.MustNewFromString("123.4"),
 				selectedED: jsonmap.JsonMap{
 					"key2": "value2",
 				},
@@ -169,7 +170,7 @@ func TestCreateETradeOptionChainPairListFromResponse(t *testing.T) {
 				},
 				timeStamp: 1234,
 				quoteType: "",
-				nearPrice: 123.4,
+				nearPrice: decimal.MustNewFromString("123.4"),
 				selectedED: jsonmap.JsonMap{
 					"key2": "value2",
 				},
@@ -204,7 +205,7 @@ func TestCreateETradeOptionChainPairListFromResponse(t *testing.T) {
 				},
 				timeStamp: 1234,
 				quoteType: "1234",
-				nearPrice: 0,
+				nearPrice: decimal.Zero,
 				selectedED: jsonmap.JsonMap{
 					"key2": "value2",
 				},
@@ -239,7 +240,7 @@ func TestCreateETradeOptionChainPairListFromResponse(t *testing.T) {
 				},
 				timeStamp:  1234,
 				quoteType:  "1234",
-				nearPrice:  123.4,
+				nearPrice:  decimal.MustNewFromString("123.4"),
 				selectedED: nil,
 			},
 		},
@@ -411,7 +412,7 @@ func TestETradeOptionChainPairList_AsJsonMap(t *testing.T) {
 		},
 		timeStamp: 1234,
 		quoteType: "1234",
-		nearPrice: 123.4,
+		nearPrice: decimal.MustNewFromString("123.4"),
 		selectedED: jsonmap.JsonMap{
 			"key2": "value2",
 		},
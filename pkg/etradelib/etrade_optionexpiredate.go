@@ -1,13 +1,23 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
 )
 
 type ETradeOptionExpireDate interface {
+	Typed() (OptionExpireDate, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// OptionExpireDate is a typed view of an ETrade option expiration date. The
+// date is midnight UTC on the day of expiration.
+type OptionExpireDate struct {
+	Date       time.Time
+	ExpiryType constants.OptionExpiryType
+}
+
 type eTradeOptionExpireDate struct {
 	jsonMap jsonmap.JsonMap
 }
@@ -21,3 +31,15 @@ func CreateETradeOptionExpireDate(responseMap jsonmap.JsonMap) (ETradeOptionExpi
 func (e *eTradeOptionExpireDate) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeOptionExpireDate) Typed() (OptionExpireDate, error) {
+	r := newTypedReader(e.jsonMap)
+	optionExpireDate := OptionExpireDate{
+		ExpiryType: readEnum(r, ".expiryType", constants.OptionExpiryTypeFromString),
+	}
+	year, month, day := r.int(".year"), r.int(".month"), r.int(".day")
+	if year != 0 {
+		optionExpireDate.Date = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+	}
+	return optionExpireDate, r.err
+}
@@ -1,10 +1,13 @@
 package etradelib
 
 import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeOptionExpireDate(t *testing.T) {
@@ -60,3 +63,23 @@ func TestETradeOptionExpireDate_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeOptionExpireDate_Typed(t *testing.T) {
+	optionExpireDate := &eTradeOptionExpireDate{
+		jsonMap: jsonmap.JsonMap{
+			"year":       json.Number("2023"),
+			"month":      json.Number("10"),
+			"day":        json.Number("20"),
+			"expiryType": "MONTHLY",
+		},
+	}
+	expectValue := OptionExpireDate{
+		Date:       time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC),
+		ExpiryType: constants.OptionExpiryTypeMonthly,
+	}
+
+	// Call the Method Under Test
+	actualValue, err := optionExpireDate.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
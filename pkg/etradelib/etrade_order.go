@@ -1,12 +1,57 @@
 package etradelib
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
+)
 
 type ETradeOrder interface {
 	GetId() int64
+	Typed() (Order, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Order is a typed view of an ETrade order. Prices and quantities are exactly
+// as ETrade sent them.
+type Order struct {
+	Id      int64
+	Type    constants.OrderType
+	Details []OrderDetail
+}
+
+// OrderDetail is a typed view of one of the details of an ETrade order (an
+// order has several details when ETrade reports each of its events).
+type OrderDetail struct {
+	PlacedTime    time.Time
+	ExecutedTime  time.Time
+	Status        constants.OrderStatus
+	Term          constants.OrderTerm
+	PriceType     constants.OrderPriceType
+	MarketSession constants.MarketSession
+	AllOrNone     bool
+	LimitPrice    decimal.Decimal
+	StopPrice     decimal.Decimal
+	NetPrice      decimal.Decimal
+	OrderValue    decimal.Decimal
+	Instruments   []OrderInstrument
+}
+
+// OrderInstrument is a typed view of one of the instruments in an ETrade
+// order detail.
+type OrderInstrument struct {
+	Symbol                string
+	SecurityType          constants.OrderSecurityType
+	Description           string
+	Action                constants.OrderAction
+	QuantityType          string
+	OrderedQuantity       decimal.Decimal
+	FilledQuantity        decimal.Decimal
+	AverageExecutionPrice decimal.Decimal
+	EstimatedCommission   decimal.Decimal
+}
+
 type eTradeOrder struct {
 	id      int64
 	jsonMap jsonmap.JsonMap
@@ -42,3 +87,53 @@ func (e *eTradeOrder) GetId() int64 {
 func (e *eTradeOrder) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeOrder) Typed() (Order, error) {
+	r := newTypedReader(e.jsonMap)
+	order := Order{
+		Id:   e.id,
+		Type: readEnum(r, ".orderType", constants.OrderTypeFromString),
+	}
+	for _, detailMap := range r.maps(".orderDetail") {
+		detailReader := newTypedReader(detailMap)
+		detail := OrderDetail{
+			PlacedTime:    detailReader.millis(".placedTime"),
+			ExecutedTime:  detailReader.millis(".executedTime"),
+			Status:        readEnum(detailReader, ".status", constants.OrderStatusFromString),
+			Term:          readEnum(detailReader, ".orderTerm", constants.OrderTermFromString),
+			PriceType:     readEnum(detailReader, ".priceType", constants.OrderPriceTypeFromString),
+			MarketSession: readEnum(detailReader, ".marketSession", constants.MarketSessionFromString),
+			AllOrNone:     detailReader.bool(".allOrNone"),
+			LimitPrice:    detailReader.number(".limitPrice"),
+			StopPrice:     detailReader.number(".stopPrice"),
+			NetPrice:      detailReader.number(".netPrice"),
+			OrderValue:    detailReader.number(".orderValue"),
+		}
+		for _, instrumentMap := range detailReader.maps(".instrument") {
+			instrumentReader := newTypedReader(instrumentMap)
+			detail.Instruments = append(
+				detail.Instruments, OrderInstrument{
+					Symbol: instrumentReader.string(".product.symbol"),
+					SecurityType: readEnum(
+						instrumentReader, ".product.securityType", constants.OrderSecurityTypeFromString,
+					),
+					Description:           instrumentReader.string(".symbolDescription"),
+					Action:                readEnum(instrumentReader, ".orderAction", constants.OrderActionFromString),
+					QuantityType:          instrumentReader.string(".quantityType"),
+					OrderedQuantity:       instrumentReader.number(".orderedQuantity"),
+					FilledQuantity:        instrumentReader.number(".filledQuantity"),
+					AverageExecutionPrice: instrumentReader.number(".averageExecutionPrice"),
+					EstimatedCommission:   instrumentReader.number(".estimatedCommission"),
+				},
+			)
+			if instrumentReader.err != nil && detailReader.err == nil {
+				detailReader.err = instrumentReader.err
+			}
+		}
+		order.Details = append(order.Details, detail)
+		if detailReader.err != nil && r.err == nil {
+			r.err = detailReader.err
+		}
+	}
+	return order, r.err
+}
@@ -0,0 +1,87 @@
+package etradelib
+
+import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+
+type ETradeOrderPlaced interface {
+	GetOrderIds() []int64
+	AsJsonMap() jsonmap.JsonMap
+}
+
+type eTradeOrderPlaced struct {
+	orderIds []int64
+	jsonMap  jsonmap.JsonMap
+}
+
+const (
+	// The place order response JSON looks like this:
+	// {
+	//   "PlaceOrderResponse": {
+	//     "orderType": "EQ",
+	//     "clientOrderId": "<client order ID>",
+	//     "OrderIds": [
+	//       {
+	//         "orderId": 1234
+	//       }
+	//     ],
+	//     "Order": [
+	//       {
+	//         <order info>
+	//       }
+	//     ],
+	//     <other placed order keys/values>
+	//   }
+	// }
+
+	// orderPlacedResponsePath is the path to the map of placed order info
+	orderPlacedResponsePath = ".placeOrderResponse"
+
+	// orderPlacedOrderIdsResponseKey is the key for a slice of order IDs
+	// within the placed order info map
+	orderPlacedOrderIdsResponseKey = "orderIds"
+
+	// orderPlacedOrderIdResponseKey is the key for the order ID within each
+	// element of the order IDs slice
+	orderPlacedOrderIdResponseKey = "orderId"
+)
+
+func CreateETradeOrderPlacedFromResponse(response []byte) (ETradeOrderPlaced, error) {
+	responseMap, err := NewNormalizedJsonMap(response)
+	if err != nil {
+		return nil, err
+	}
+	return CreateETradeOrderPlaced(responseMap)
+}
+
+func CreateETradeOrderPlaced(responseMap jsonmap.JsonMap) (ETradeOrderPlaced, error) {
+	placedMap, err := responseMap.GetMapAtPath(orderPlacedResponsePath)
+	if err != nil {
+		return nil, err
+	}
+
+	orderIdsSlice, err := placedMap.GetSliceOfMaps(orderPlacedOrderIdsResponseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	orderIds := make([]int64, 0, len(orderIdsSlice))
+	for _, orderIdMap := range orderIdsSlice {
+		orderId, err := orderIdMap.GetInt(orderPlacedOrderIdResponseKey)
+		if err != nil {
+			return nil, err
+		}
+		orderIds = append(orderIds, orderId)
+	}
+
+	return &eTradeOrderPlaced{
+		orderIds: orderIds,
+		jsonMap:  placedMap,
+	}, nil
+}
+
+func (e *eTradeOrderPlaced) GetOrderIds() []int64 {
+	return e.orderIds
+}
+
+func (e *eTradeOrderPlaced) AsJsonMap() jsonmap.JsonMap {
+	return e.jsonMap
+}
@@ -0,0 +1,115 @@
+package etradelib
+
+import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateETradeOrderPlaced(t *testing.T) {
+	tests := []struct {
+		name        string
+		testJson    string
+		expectErr   bool
+		expectValue ETradeOrderPlaced
+	}{
+		{
+			name: "Creates Placed Order",
+			testJson: `
+{
+  "PlaceOrderResponse": {
+    "orderType": "EQ",
+    "OrderIds": [
+      {
+        "orderId": 1234
+      }
+    ]
+  }
+}`,
+			expectErr: false,
+			expectValue: &eTradeOrderPlaced{
+				orderIds: []int64{1234},
+				jsonMap: jsonmap.JsonMap{
+					"orderType": "EQ",
+					"orderIds": jsonmap.JsonSlice{
+						jsonmap.JsonMap{
+							"orderId": json.Number("1234"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Fails Without Order IDs",
+			testJson: `
+{
+  "PlaceOrderResponse": {
+    "orderType": "EQ"
+  }
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails With Bad Order ID",
+			testJson: `
+{
+  "PlaceOrderResponse": {
+    "OrderIds": [
+      {
+        "orderId": "bogus"
+      }
+    ]
+  }
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails On Bad JSON",
+			testJson: `
+{
+  "PlaceOrderResponse": {
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := CreateETradeOrderPlacedFromResponse([]byte(tt.testJson))
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestETradeOrderPlaced_GetOrderIds(t *testing.T) {
+	testObject := &eTradeOrderPlaced{
+		orderIds: []int64{1234, 5678},
+		jsonMap:  jsonmap.JsonMap{},
+	}
+	assert.Equal(t, []int64{1234, 5678}, testObject.GetOrderIds())
+}
+
+func TestETradeOrderPlaced_AsJsonMap(t *testing.T) {
+	testObject := &eTradeOrderPlaced{
+		orderIds: []int64{1234},
+		jsonMap: jsonmap.JsonMap{
+			"orderType": "EQ",
+		},
+	}
+	expectedValue := jsonmap.JsonMap{
+		"orderType": "EQ",
+	}
+	assert.Equal(t, expectedValue, testObject.AsJsonMap())
+}
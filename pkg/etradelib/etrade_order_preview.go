@@ -0,0 +1,87 @@
+package etradelib
+
+import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+
+type ETradeOrderPreview interface {
+	GetPreviewIds() []int64
+	AsJsonMap() jsonmap.JsonMap
+}
+
+type eTradeOrderPreview struct {
+	previewIds []int64
+	jsonMap    jsonmap.JsonMap
+}
+
+const (
+	// The preview order response JSON looks like this:
+	// {
+	//   "PreviewOrderResponse": {
+	//     "orderType": "EQ",
+	//     "clientOrderId": "<client order ID>",
+	//     "PreviewIds": [
+	//       {
+	//         "previewId": 1234
+	//       }
+	//     ],
+	//     "Order": [
+	//       {
+	//         <order info>
+	//       }
+	//     ],
+	//     <other preview keys/values>
+	//   }
+	// }
+
+	// orderPreviewResponsePath is the path to the map of preview info
+	orderPreviewResponsePath = ".previewOrderResponse"
+
+	// orderPreviewPreviewIdsResponseKey is the key for a slice of preview IDs
+	// within the preview info map
+	orderPreviewPreviewIdsResponseKey = "previewIds"
+
+	// orderPreviewPreviewIdResponseKey is the key for the preview ID within
+	// each element of the preview IDs slice
+	orderPreviewPreviewIdResponseKey = "previewId"
+)
+
+func CreateETradeOrderPreviewFromResponse(response []byte) (ETradeOrderPreview, error) {
+	responseMap, err := NewNormalizedJsonMap(response)
+	if err != nil {
+		return nil, err
+	}
+	return CreateETradeOrderPreview(responseMap)
+}
+
+func CreateETradeOrderPreview(responseMap jsonmap.JsonMap) (ETradeOrderPreview, error) {
+	previewMap, err := responseMap.GetMapAtPath(orderPreviewResponsePath)
+	if err != nil {
+		return nil, err
+	}
+
+	previewIdsSlice, err := previewMap.GetSliceOfMaps(orderPreviewPreviewIdsResponseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	previewIds := make([]int64, 0, len(previewIdsSlice))
+	for _, previewIdMap := range previewIdsSlice {
+		previewId, err := previewIdMap.GetInt(orderPreviewPreviewIdResponseKey)
+		if err != nil {
+			return nil, err
+		}
+		previewIds = append(previewIds, previewId)
+	}
+
+	return &eTradeOrderPreview{
+		previewIds: previewIds,
+		jsonMap:    previewMap,
+	}, nil
+}
+
+func (e *eTradeOrderPreview) GetPreviewIds() []int64 {
+	return e.previewIds
+}
+
+func (e *eTradeOrderPreview) AsJsonMap() jsonmap.JsonMap {
+	return e.jsonMap
+}
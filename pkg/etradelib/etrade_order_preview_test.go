@@ -0,0 +1,115 @@
+package etradelib
+
+import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateETradeOrderPreview(t *testing.T) {
+	tests := []struct {
+		name        string
+		testJson    string
+		expectErr   bool
+		expectValue ETradeOrderPreview
+	}{
+		{
+			name: "Creates Order Preview",
+			testJson: `
+{
+  "PreviewOrderResponse": {
+    "orderType": "EQ",
+    "PreviewIds": [
+      {
+        "previewId": 1234
+      }
+    ]
+  }
+}`,
+			expectErr: false,
+			expectValue: &eTradeOrderPreview{
+				previewIds: []int64{1234},
+				jsonMap: jsonmap.JsonMap{
+					"orderType": "EQ",
+					"previewIds": jsonmap.JsonSlice{
+						jsonmap.JsonMap{
+							"previewId": json.Number("1234"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Fails Without Preview IDs",
+			testJson: `
+{
+  "PreviewOrderResponse": {
+    "orderType": "EQ"
+  }
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails With Bad Preview ID",
+			testJson: `
+{
+  "PreviewOrderResponse": {
+    "PreviewIds": [
+      {
+        "previewId": "bogus"
+      }
+    ]
+  }
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+		{
+			name: "Fails On Bad JSON",
+			testJson: `
+{
+  "PreviewOrderResponse": {
+}`,
+			expectErr:   true,
+			expectValue: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Call the Method Under Test
+				actualValue, err := CreateETradeOrderPreviewFromResponse([]byte(tt.testJson))
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+				}
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
+
+func TestETradeOrderPreview_GetPreviewIds(t *testing.T) {
+	testObject := &eTradeOrderPreview{
+		previewIds: []int64{1234, 5678},
+		jsonMap:    jsonmap.JsonMap{},
+	}
+	assert.Equal(t, []int64{1234, 5678}, testObject.GetPreviewIds())
+}
+
+func TestETradeOrderPreview_AsJsonMap(t *testing.T) {
+	testObject := &eTradeOrderPreview{
+		previewIds: []int64{1234},
+		jsonMap: jsonmap.JsonMap{
+			"orderType": "EQ",
+		},
+	}
+	expectedValue := jsonmap.JsonMap{
+		"orderType": "EQ",
+	}
+	assert.Equal(t, expectedValue, testObject.AsJsonMap())
+}
@@ -2,10 +2,13 @@ package etradelib
 
 import (
 	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeOrder(t *testing.T) {
@@ -85,3 +88,86 @@ func TestETradeOrder_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeOrder_Typed(t *testing.T) {
+	responseMap, err := NewNormalizedJsonMap(
+		[]byte(`
+{
+  "orderId": 501,
+  "orderType": "EQ",
+  "OrderDetail": [
+    {
+      "placedTime": 1695758400000,
+      "status": "OPEN",
+      "orderTerm": "GOOD_FOR_DAY",
+      "priceType": "LIMIT",
+      "marketSession": "REGULAR",
+      "allOrNone": false,
+      "limitPrice": 120,
+      "Instrument": [
+        {
+          "Product": {"symbol": "GOOG", "securityType": "EQ"},
+          "symbolDescription": "ALPHABET INC CAP STK CL C",
+          "orderAction": "BUY",
+          "quantityType": "QUANTITY",
+          "orderedQuantity": 10,
+          "filledQuantity": 0
+        }
+      ]
+    }
+  ]
+}`),
+	)
+	require.Nil(t, err)
+	order, err := CreateETradeOrder(responseMap)
+	require.Nil(t, err)
+	expectValue := Order{
+		Id:   501,
+		Type: constants.OrderTypeEquity,
+		Details: []OrderDetail{
+			{
+				PlacedTime:    time.UnixMilli(1695758400000),
+				Status:        constants.OrderStatusOpen,
+				Term:          constants.OrderTermGoodForDay,
+				PriceType:     constants.OrderPriceTypeLimit,
+				MarketSession: constants.MarketSessionRegular,
+				LimitPrice:    decimal.MustNewFromString("120"),
+				Instruments: []OrderInstrument{
+					{
+						Symbol:          "GOOG",
+						SecurityType:    constants.OrderSecurityTypeEquity,
+						Description:     "ALPHABET INC CAP STK CL C",
+						Action:          constants.OrderActionBuy,
+						QuantityType:    "QUANTITY",
+						OrderedQuantity: decimal.MustNewFromString("10"),
+						FilledQuantity:  decimal.MustNewFromString("0"),
+					},
+				},
+			},
+		},
+	}
+
+	// Call the Method Under Test
+	actualValue, err := order.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
+
+func TestETradeOrder_Typed_ReadsUnknownEnumValuesAsNil(t *testing.T) {
+	order := &eTradeOrder{
+		id: 501,
+		jsonMap: jsonmap.JsonMap{
+			"orderDetail": jsonmap.JsonSlice{
+				jsonmap.JsonMap{
+					"status": "SOME_NEW_STATUS",
+				},
+			},
+		},
+	}
+
+	// Call the Method Under Test
+	actualValue, err := order.Typed()
+	assert.Nil(t, err)
+	require.Len(t, actualValue.Details, 1)
+	assert.Equal(t, constants.OrderStatusNil, actualValue.Details[0].Status)
+}
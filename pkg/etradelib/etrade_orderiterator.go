@@ -0,0 +1,39 @@
+package etradelib
+
+import (
+	"context"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"time"
+)
+
+// OrderIterator returns an account's orders one at a time, fetching each page
+// of orders as it is needed.
+type OrderIterator = Iterator[ETradeOrder]
+
+// NewOrderIterator creates an iterator over the orders for an account that
+// match the given filters. If maxItems is greater than zero, the iterator
+// stops after that many orders.
+func NewOrderIterator(
+	eTradeClient client.ETradeClient, accountIdKey string, status constants.OrderStatus, fromDate *time.Time,
+	toDate *time.Time, symbols []string, securityType constants.OrderSecurityType,
+	transactionType constants.OrderTransactionType, marketSession constants.MarketSession, maxItems int,
+) OrderIterator {
+	count := pageSize(constants.OrdersMaxCount, maxItems)
+	return NewPageIterator(
+		func(ctx context.Context, marker string) ([]ETradeOrder, string, error) {
+			response, err := eTradeClient.WithContext(ctx).ListOrders(
+				accountIdKey, marker, count, status, fromDate, toDate, symbols, securityType, transactionType,
+				marketSession,
+			)
+			if err != nil {
+				return nil, "", err
+			}
+			orderList, err := CreateETradeOrderListFromResponse(response)
+			if err != nil {
+				return nil, "", err
+			}
+			return orderList.GetAllOrders(), orderList.NextPage(), nil
+		}, maxItems,
+	)
+}
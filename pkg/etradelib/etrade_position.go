@@ -1,14 +1,62 @@
 package etradelib
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
+)
 
 type ETradePosition interface {
 	GetId() int64
 	AddLots(responseMap jsonmap.JsonMap) error
 	AddLotsFromResponse(response []byte) error
+	Typed() (Position, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Position is a typed view of an ETrade portfolio position. Amounts and
+// quantities are exactly as ETrade sent them.
+type Position struct {
+	Id                int64
+	Symbol            string
+	SymbolDescription string
+	SecurityType      constants.OrderSecurityType
+	DateAcquired      time.Time
+	Quantity          decimal.Decimal
+	PositionIndicator string
+	PositionType      string
+	PricePaid         decimal.Decimal
+	Commissions       decimal.Decimal
+	OtherFees         decimal.Decimal
+	CostPerShare      decimal.Decimal
+	TotalCost         decimal.Decimal
+	MarketValue       decimal.Decimal
+	DaysGain          decimal.Decimal
+	DaysGainPct       decimal.Decimal
+	TotalGain         decimal.Decimal
+	TotalGainPct      decimal.Decimal
+	PctOfPortfolio    decimal.Decimal
+	Lots              []PositionLot
+}
+
+// PositionLot is a typed view of one of the lots of an ETrade portfolio
+// position.
+type PositionLot struct {
+	Id                int64
+	PositionId        int64
+	AcquiredDate      time.Time
+	Price             decimal.Decimal
+	OriginalQuantity  decimal.Decimal
+	RemainingQuantity decimal.Decimal
+	AvailableQuantity decimal.Decimal
+	TotalCost         decimal.Decimal
+	MarketValue       decimal.Decimal
+	DaysGain          decimal.Decimal
+	TotalGain         decimal.Decimal
+	TermCode          int64
+}
+
 type eTradePosition struct {
 	id      int64
 	jsonMap jsonmap.JsonMap
@@ -91,3 +139,51 @@ func (e *eTradePosition) AddLotsFromResponse(response []byte) error {
 	}
 	return e.AddLots(responseMap)
 }
+
+func (e *eTradePosition) Typed() (Position, error) {
+	r := newTypedReader(e.jsonMap)
+	position := Position{
+		Id:                e.id,
+		Symbol:            r.string(".product.symbol"),
+		SymbolDescription: r.string(".symbolDescription"),
+		SecurityType:      readEnum(r, ".product.securityType", constants.OrderSecurityTypeFromString),
+		DateAcquired:      r.millis(".dateAcquired"),
+		Quantity:          r.number(".quantity"),
+		PositionIndicator: r.string(".positionIndicator"),
+		PositionType:      r.string(".positionType"),
+		PricePaid:         r.number(".pricePaid"),
+		Commissions:       r.number(".commissions"),
+		OtherFees:         r.number(".otherFees"),
+		CostPerShare:      r.number(".costPerShare"),
+		TotalCost:         r.number(".totalCost"),
+		MarketValue:       r.number(".marketValue"),
+		DaysGain:          r.number(".daysGain"),
+		DaysGainPct:       r.number(".daysGainPct"),
+		TotalGain:         r.number(".totalGain"),
+		TotalGainPct:      r.number(".totalGainPct"),
+		PctOfPortfolio:    r.number(".pctOfPortfolio"),
+	}
+	for _, lotMap := range r.maps(PositionLotsPath) {
+		lotReader := newTypedReader(lotMap)
+		position.Lots = append(
+			position.Lots, PositionLot{
+				Id:                lotReader.int(".positionLotId"),
+				PositionId:        lotReader.int(".positionId"),
+				AcquiredDate:      lotReader.millis(".acquiredDate"),
+				Price:             lotReader.number(".price"),
+				OriginalQuantity:  lotReader.number(".originalQty"),
+				RemainingQuantity: lotReader.number(".remainingQty"),
+				AvailableQuantity: lotReader.number(".availableQty"),
+				TotalCost:         lotReader.number(".totalCost"),
+				MarketValue:       lotReader.number(".marketValue"),
+				DaysGain:          lotReader.number(".daysGain"),
+				TotalGain:         lotReader.number(".totalGain"),
+				TermCode:          lotReader.int(".termCode"),
+			},
+		)
+		if lotReader.err != nil && r.err == nil {
+			r.err = lotReader.err
+		}
+	}
+	return position, r.err
+}
@@ -2,10 +2,13 @@ package etradelib
 
 import (
 	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradePortfolioPosition(t *testing.T) {
@@ -171,3 +174,75 @@ func TestETradePortfolioPosition_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradePosition_Typed(t *testing.T) {
+	responseMap, err := NewNormalizedJsonMap(
+		[]byte(`
+{
+  "positionId": 1234,
+  "Product": {"symbol": "GOOG", "securityType": "EQ"},
+  "symbolDescription": "ALPHABET INC CAP STK CL C",
+  "dateAcquired": 1694750400000,
+  "quantity": 10,
+  "positionIndicator": "LONG",
+  "pricePaid": 120.05,
+  "totalCost": 1200.50,
+  "marketValue": "1400.10",
+  "totalGain": 199.6,
+  "lots": [
+    {
+      "positionLotId": 5678,
+      "positionId": 1234,
+      "acquiredDate": 1694750400000,
+      "price": 120.05,
+      "remainingQty": 10,
+      "termCode": 1
+    }
+  ]
+}`),
+	)
+	require.Nil(t, err)
+	position, err := CreateETradePosition(responseMap)
+	require.Nil(t, err)
+	expectValue := Position{
+		Id:                1234,
+		Symbol:            "GOOG",
+		SymbolDescription: "ALPHABET INC CAP STK CL C",
+		SecurityType:      constants.OrderSecurityTypeEquity,
+		DateAcquired:      time.UnixMilli(1694750400000),
+		Quantity:          decimal.MustNewFromString("10"),
+		PositionIndicator: "LONG",
+		PricePaid:         decimal.MustNewFromString("120.05"),
+		TotalCost:         decimal.MustNewFromString("1200.50"),
+		MarketValue:       decimal.MustNewFromString("1400.10"),
+		TotalGain:         decimal.MustNewFromString("199.6"),
+		Lots: []PositionLot{
+			{
+				Id:                5678,
+				PositionId:        1234,
+				AcquiredDate:      time.UnixMilli(1694750400000),
+				Price:             decimal.MustNewFromString("120.05"),
+				RemainingQuantity: decimal.MustNewFromString("10"),
+				TermCode:          1,
+			},
+		},
+	}
+
+	// Call the Method Under Test
+	actualValue, err := position.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
+
+func TestETradePosition_Typed_FailsWithInvalidValue(t *testing.T) {
+	position := &eTradePosition{
+		id: 1234,
+		jsonMap: jsonmap.JsonMap{
+			"quantity": "ten",
+		},
+	}
+
+	// Call the Method Under Test
+	_, err := position.Typed()
+	assert.ErrorContains(t, err, ".quantity")
+}
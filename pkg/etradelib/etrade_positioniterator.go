@@ -0,0 +1,91 @@
+package etradelib
+
+import (
+	"context"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+// PositionIterator returns the positions in an account's portfolio one at a
+// time, fetching each page of positions as it is needed.
+type PositionIterator interface {
+	Iterator[ETradePosition]
+
+	// Totals returns the portfolio totals, or nil if they weren't requested.
+	// ETrade sends the totals with each page, so they are only available
+	// after the first call to Next.
+	Totals() jsonmap.JsonMap
+}
+
+// DefaultLotsConcurrency is the default number of positions whose lots are
+// fetched at once.
+const DefaultLotsConcurrency = 4
+
+type positionIterator struct {
+	Iterator[ETradePosition]
+	totals jsonmap.JsonMap
+}
+
+// NewPositionIterator creates an iterator over the positions in an account's
+// portfolio. If withLots is set, the lots for each position are fetched along
+// with the page that contains the position, for up to lotsConcurrency
+// positions at once. If maxItems is greater than zero, the iterator stops
+// after that many positions.
+func NewPositionIterator(
+	eTradeClient client.ETradeClient, accountIdKey string, sortBy constants.PortfolioSortBy,
+	sortOrder constants.SortOrder, marketSession constants.MarketSession, totalsRequired bool,
+	portfolioView constants.PortfolioView, withLots bool, lotsConcurrency int, maxItems int,
+) PositionIterator {
+	count := pageSize(constants.PortfolioMaxCount, maxItems)
+	iterator := &positionIterator{}
+	iterator.Iterator = NewPageIterator(
+		func(ctx context.Context, marker string) ([]ETradePosition, string, error) {
+			// Requests made with the context stop when it is cancelled, which
+			// includes the lots requests for the page.
+			clientWithContext := eTradeClient.WithContext(ctx)
+			response, err := clientWithContext.ViewPortfolio(
+				accountIdKey, count, sortBy, sortOrder, marker, marketSession, totalsRequired, true, portfolioView,
+			)
+			if err != nil {
+				return nil, "", err
+			}
+			positionList, err := CreateETradePositionListFromResponse(response)
+			if err != nil {
+				return nil, "", err
+			}
+			if iterator.totals == nil {
+				iterator.totals = positionList.GetTotals()
+			}
+			positions := positionList.GetAllPositions()
+			if withLots {
+				// Each position gets its own lots, so the positions stay in the
+				// order that ETrade returned them regardless of which lots
+				// arrive first.
+				err = forEachConcurrently(
+					ctx, len(positions), lotsConcurrency, func(index int) error {
+						position := positions[index]
+						lotsResponse, err := clientWithContext.ListPositionLotsDetails(accountIdKey, position.GetId())
+						if err == nil {
+							err = position.AddLotsFromResponse(lotsResponse)
+						}
+						if err != nil {
+							return fmt.Errorf("unable to get lots for position %d: %w", position.GetId(), err)
+						}
+						return nil
+					},
+				)
+				if err != nil {
+					return nil, "", err
+				}
+			}
+			return positions, positionList.NextPage(), nil
+		}, maxItems,
+	)
+	return iterator
+}
+
+func (i *positionIterator) Totals() jsonmap.JsonMap {
+	return i.totals
+}
@@ -0,0 +1,189 @@
+package etradelib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPositionIterator(t *testing.T) {
+	const firstPage = `
+{
+  "PortfolioResponse": {
+    "Totals": {
+      "totalMarketValue": 100
+    },
+    "AccountPortfolio": [
+      {
+        "nextPageNo": "2",
+        "Position": [
+          {
+            "positionId": 1
+          }
+        ]
+      }
+    ]
+  }
+}`
+	const secondPage = `
+{
+  "PortfolioResponse": {
+    "Totals": {
+      "totalMarketValue": 100
+    },
+    "AccountPortfolio": [
+      {
+        "Position": [
+          {
+            "positionId": 2
+          }
+        ]
+      }
+    ]
+  }
+}`
+	const twoPositionPage = `
+{
+  "PortfolioResponse": {
+    "AccountPortfolio": [
+      {
+        "Position": [
+          {
+            "positionId": 3
+          },
+          {
+            "positionId": 4
+          }
+        ]
+      }
+    ]
+  }
+}`
+	const lots = `
+{
+  "PositionLotsResponse": {
+    "PositionLot": [
+      {
+        "lotId": 10
+      }
+    ]
+  }
+}`
+	tests := []struct {
+		name         string
+		withLots     bool
+		mockSetup    func(m *client.ETradeClientMock)
+		expectErr    string
+		expectValue  []jsonmap.JsonMap
+		expectTotals jsonmap.JsonMap
+	}{
+		{
+			name: "Returns Positions From Every Page",
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On(
+					"ViewPortfolio", "key", constants.PortfolioMaxCount, constants.PortfolioSortByNil,
+					constants.SortOrderNil, "", constants.MarketSessionNil, true, true,
+					constants.PortfolioViewNil,
+				).Return([]byte(firstPage), nil).Once()
+				m.On(
+					"ViewPortfolio", "key", constants.PortfolioMaxCount, constants.PortfolioSortByNil,
+					constants.SortOrderNil, "2", constants.MarketSessionNil, true, true,
+					constants.PortfolioViewNil,
+				).Return([]byte(secondPage), nil).Once()
+			},
+			expectValue: []jsonmap.JsonMap{
+				{"positionId": json.Number("1")},
+				{"positionId": json.Number("2")},
+			},
+			expectTotals: jsonmap.JsonMap{"totalMarketValue": json.Number("100")},
+		},
+		{
+			name:     "Fetches Lots With Each Page",
+			withLots: true,
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On(
+					"ViewPortfolio", "key", constants.PortfolioMaxCount, constants.PortfolioSortByNil,
+					constants.SortOrderNil, "", constants.MarketSessionNil, true, true,
+					constants.PortfolioViewNil,
+				).Return([]byte(secondPage), nil).Once()
+				m.On("ListPositionLotsDetails", "key", int64(2)).Return([]byte(lots), nil).Once()
+			},
+			expectValue: []jsonmap.JsonMap{
+				{
+					"positionId": json.Number("2"),
+					"lots":       jsonmap.JsonSlice{jsonmap.JsonMap{"lotId": json.Number("10")}},
+				},
+			},
+			expectTotals: jsonmap.JsonMap{"totalMarketValue": json.Number("100")},
+		},
+		{
+			name:     "Keeps Position Order When Fetching Lots Concurrently",
+			withLots: true,
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On(
+					"ViewPortfolio", "key", constants.PortfolioMaxCount, constants.PortfolioSortByNil,
+					constants.SortOrderNil, "", constants.MarketSessionNil, true, true,
+					constants.PortfolioViewNil,
+				).Return([]byte(twoPositionPage), nil).Once()
+				m.On("ListPositionLotsDetails", "key", int64(3)).Return([]byte(lots), nil).Once()
+				m.On("ListPositionLotsDetails", "key", int64(4)).Return([]byte(lots), nil).Once()
+			},
+			expectValue: []jsonmap.JsonMap{
+				{
+					"positionId": json.Number("3"),
+					"lots":       jsonmap.JsonSlice{jsonmap.JsonMap{"lotId": json.Number("10")}},
+				},
+				{
+					"positionId": json.Number("4"),
+					"lots":       jsonmap.JsonSlice{jsonmap.JsonMap{"lotId": json.Number("10")}},
+				},
+			},
+		},
+		{
+			name:     "Fails With Every Lots Error",
+			withLots: true,
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On(
+					"ViewPortfolio", "key", constants.PortfolioMaxCount, constants.PortfolioSortByNil,
+					constants.SortOrderNil, "", constants.MarketSessionNil, true, true,
+					constants.PortfolioViewNil,
+				).Return([]byte(twoPositionPage), nil).Once()
+				m.On("ListPositionLotsDetails", "key", int64(3)).Return([]byte{}, errors.New("error 3")).Once()
+				m.On("ListPositionLotsDetails", "key", int64(4)).Return([]byte{}, errors.New("error 4")).Once()
+			},
+			expectErr: "unable to get lots for position 3: error 3\nunable to get lots for position 4: error 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				eTradeClient := client.ETradeClientMock{}
+				tt.mockSetup(&eTradeClient)
+				// Every request is made with the context passed to the iterator.
+				ctx := context.WithValue(context.Background(), "testKey", "testValue")
+				eTradeClient.On("WithContext", ctx).Return(&eTradeClient)
+				iterator := NewPositionIterator(
+					&eTradeClient, "key", constants.PortfolioSortByNil, constants.SortOrderNil,
+					constants.MarketSessionNil, true, constants.PortfolioViewNil, tt.withLots, 2, 0,
+				)
+
+				// Call the Method Under Test
+				actualValue, err := CollectIterator(ctx, NewJsonMapIterator[ETradePosition](iterator))
+				if tt.expectErr != "" {
+					assert.EqualError(t, err, tt.expectErr)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.expectValue, actualValue)
+					assert.Equal(t, tt.expectTotals, iterator.Totals())
+				}
+				eTradeClient.AssertExpectations(t)
+			},
+		)
+	}
+}
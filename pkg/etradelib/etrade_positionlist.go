@@ -5,6 +5,7 @@ import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 type ETradePositionList interface {
 	GetAllPositions() []ETradePosition
 	GetPositionById(positionID int64) ETradePosition
+	GetTotals() jsonmap.JsonMap
 	NextPage() string
 	AddPage(responseMap jsonmap.JsonMap) error
 	AddPageFromResponse(response []byte) error
@@ -110,6 +111,10 @@ func (e *eTradePositionList) GetPositionById(positionID int64) ETradePosition {
 	return nil
 }
 
+func (e *eTradePositionList) GetTotals() jsonmap.JsonMap {
+	return e.totalsMap
+}
+
 func (e *eTradePositionList) NextPage() string {
 	return e.nextPage
 }
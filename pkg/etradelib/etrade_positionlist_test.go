@@ -356,6 +356,22 @@ func TestETradePositionList_AddPageFromResponse(t *testing.T) {
 	}
 }
 
+func TestETradePositionList_GetTotals(t *testing.T) {
+	testObject := &eTradePositionList{
+		positions: []ETradePosition{},
+		totalsMap: jsonmap.JsonMap{"totalMarketValue": json.Number("1234.56")},
+		nextPage:  "",
+	}
+	assert.Equal(t, jsonmap.JsonMap{"totalMarketValue": json.Number("1234.56")}, testObject.GetTotals())
+
+	testObject = &eTradePositionList{
+		positions: []ETradePosition{},
+		totalsMap: nil,
+		nextPage:  "",
+	}
+	assert.Nil(t, testObject.GetTotals())
+}
+
 func TestETradePositionList_NextPage(t *testing.T) {
 	testObject := &eTradePositionList{
 		positions: []ETradePosition{},
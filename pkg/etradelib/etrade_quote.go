@@ -1,13 +1,38 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
 )
 
 type ETradeQuote interface {
+	Typed() (Quote, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Quote is a typed view of an ETrade quote. Prices are read from whichever
+// of the quote's detail sections (e.g. "All" or "Intraday") ETrade sent, and
+// are exactly as ETrade sent them.
+type Quote struct {
+	Symbol                string
+	SecurityType          constants.OrderSecurityType
+	Time                  time.Time
+	Status                string
+	CompanyName           string
+	LastTrade             decimal.Decimal
+	Bid                   decimal.Decimal
+	Ask                   decimal.Decimal
+	Open                  decimal.Decimal
+	High                  decimal.Decimal
+	Low                   decimal.Decimal
+	PreviousClose         decimal.Decimal
+	ChangeClose           decimal.Decimal
+	ChangeClosePercentage decimal.Decimal
+	TotalVolume           int64
+}
+
 type eTradeQuote struct {
 	jsonMap jsonmap.JsonMap
 }
@@ -21,3 +46,36 @@ func CreateETradeQuote(responseMap jsonmap.JsonMap) (ETradeQuote, error) {
 func (e *eTradeQuote) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+// quoteDetailPaths are the paths to the quote detail sections that have
+// prices, in the order they're checked.
+var quoteDetailPaths = []string{".all", ".intraday", ".quick", ".option", ".mutualFund"}
+
+func (e *eTradeQuote) Typed() (Quote, error) {
+	r := newTypedReader(e.jsonMap)
+	detailPath := quoteDetailPaths[0]
+	for _, path := range quoteDetailPaths {
+		if r.value(path) != nil {
+			detailPath = path
+			break
+		}
+	}
+	quote := Quote{
+		Symbol:                r.string(".product.symbol"),
+		SecurityType:          readEnum(r, ".product.securityType", constants.OrderSecurityTypeFromString),
+		Time:                  r.seconds(".dateTimeUTC"),
+		Status:                r.string(".quoteStatus"),
+		CompanyName:           r.string(detailPath + ".companyName"),
+		LastTrade:             r.number(detailPath + ".lastTrade"),
+		Bid:                   r.number(detailPath + ".bid"),
+		Ask:                   r.number(detailPath + ".ask"),
+		Open:                  r.number(detailPath + ".open"),
+		High:                  r.number(detailPath + ".high"),
+		Low:                   r.number(detailPath + ".low"),
+		PreviousClose:         r.number(detailPath + ".previousClose"),
+		ChangeClose:           r.number(detailPath + ".changeClose"),
+		ChangeClosePercentage: r.number(detailPath + ".changeClosePercentage"),
+		TotalVolume:           r.int(detailPath + ".totalVolume"),
+	}
+	return quote, r.err
+}
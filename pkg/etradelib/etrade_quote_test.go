@@ -1,10 +1,13 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeQuote(t *testing.T) {
@@ -60,3 +63,70 @@ func TestETradeQuote_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeQuote_Typed(t *testing.T) {
+	tests := []struct {
+		name        string
+		testJson    string
+		expectValue Quote
+	}{
+		{
+			name: "Reads All Details",
+			testJson: `
+{
+  "dateTimeUTC": 1695758400,
+  "quoteStatus": "CLOSING",
+  "Product": {"symbol": "GOOG", "securityType": "EQ"},
+  "All": {
+    "companyName": "ALPHABET INC CAP STK CL C",
+    "lastTrade": 140.01,
+    "bid": 140,
+    "ask": 140.02,
+    "totalVolume": 123456
+  }
+}`,
+			expectValue: Quote{
+				Symbol:       "GOOG",
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Time:         time.Unix(1695758400, 0),
+				Status:       "CLOSING",
+				CompanyName:  "ALPHABET INC CAP STK CL C",
+				LastTrade:    decimal.MustNewFromString("140.01"),
+				Bid:          decimal.MustNewFromString("140"),
+				Ask:          decimal.MustNewFromString("140.02"),
+				TotalVolume:  123456,
+			},
+		},
+		{
+			name: "Reads Intraday Details",
+			testJson: `
+{
+  "Product": {"symbol": "GOOG", "securityType": "EQ"},
+  "Intraday": {
+    "lastTrade": 140.01
+  }
+}`,
+			expectValue: Quote{
+				Symbol:       "GOOG",
+				SecurityType: constants.OrderSecurityTypeEquity,
+				LastTrade:    decimal.MustNewFromString("140.01"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				responseMap, err := NewNormalizedJsonMap([]byte(tt.testJson))
+				require.Nil(t, err)
+				quote, err := CreateETradeQuote(responseMap)
+				require.Nil(t, err)
+
+				// Call the Method Under Test
+				actualValue, err := quote.Typed()
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectValue, actualValue)
+			},
+		)
+	}
+}
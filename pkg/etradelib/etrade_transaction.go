@@ -1,12 +1,42 @@
 package etradelib
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"time"
+)
 
 type ETradeTransaction interface {
 	GetId() string
+	Typed() (Transaction, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
+// Transaction is a typed view of an ETrade transaction. Amounts and
+// quantities are exactly as ETrade sent them.
+type Transaction struct {
+	Id          string
+	AccountId   string
+	Date        time.Time
+	PostDate    time.Time
+	Amount      decimal.Decimal
+	Description string
+	Type        string
+	Brokerage   TransactionBrokerage
+}
+
+// TransactionBrokerage is a typed view of the brokerage details of an ETrade
+// transaction.
+type TransactionBrokerage struct {
+	Symbol         string
+	SecurityType   constants.OrderSecurityType
+	Quantity       decimal.Decimal
+	Price          decimal.Decimal
+	Fee            decimal.Decimal
+	SettlementDate time.Time
+}
+
 type eTradeTransaction struct {
 	id      string
 	jsonMap jsonmap.JsonMap
@@ -42,3 +72,31 @@ func (e *eTradeTransaction) GetId() string {
 func (e *eTradeTransaction) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeTransaction) Typed() (Transaction, error) {
+	r := newTypedReader(e.jsonMap)
+	transaction := readTransaction(r, e.id)
+	return transaction, r.err
+}
+
+// readTransaction reads the values that transactions and transaction details
+// have in common.
+func readTransaction(r *typedReader, id string) Transaction {
+	return Transaction{
+		Id:          id,
+		AccountId:   r.string(".accountId"),
+		Date:        r.millis(".transactionDate"),
+		PostDate:    r.millis(".postDate"),
+		Amount:      r.number(".amount"),
+		Description: r.string(".description"),
+		Type:        r.string(".transactionType"),
+		Brokerage: TransactionBrokerage{
+			Symbol:         r.string(".brokerage.product.symbol"),
+			SecurityType:   readEnum(r, ".brokerage.product.securityType", constants.OrderSecurityTypeFromString),
+			Quantity:       r.number(".brokerage.quantity"),
+			Price:          r.number(".brokerage.price"),
+			Fee:            r.number(".brokerage.fee"),
+			SettlementDate: r.millis(".brokerage.settlementDate"),
+		},
+	}
+}
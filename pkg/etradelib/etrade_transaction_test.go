@@ -1,10 +1,13 @@
 package etradelib
 
 import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeTransaction(t *testing.T) {
@@ -84,3 +87,46 @@ func TestETradeTransaction_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeTransaction_Typed(t *testing.T) {
+	responseMap, err := NewNormalizedJsonMap(
+		[]byte(`
+{
+  "transactionId": "23091500001",
+  "accountId": "84910001",
+  "transactionDate": 1694750400000,
+  "amount": -1200.55,
+  "description": "Bought 10 GOOG",
+  "transactionType": "Bought",
+  "brokerage": {
+    "product": {"symbol": "GOOG", "securityType": "EQ"},
+    "quantity": 10,
+    "price": 120.05,
+    "fee": 0.05
+  }
+}`),
+	)
+	require.Nil(t, err)
+	transaction, err := CreateETradeTransaction(responseMap)
+	require.Nil(t, err)
+	expectValue := Transaction{
+		Id:          "23091500001",
+		AccountId:   "84910001",
+		Date:        time.UnixMilli(1694750400000),
+		Amount:      decimal.MustNewFromString("-1200.55"),
+		Description: "Bought 10 GOOG",
+		Type:        "Bought",
+		Brokerage: TransactionBrokerage{
+			Symbol:       "GOOG",
+			SecurityType: constants.OrderSecurityTypeEquity,
+			Quantity:     decimal.MustNewFromString("10"),
+			Price:        decimal.MustNewFromString("120.05"),
+			Fee:          decimal.MustNewFromString("0.05"),
+		},
+	}
+
+	// Call the Method Under Test
+	actualValue, err := transaction.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
@@ -1,9 +1,13 @@
 package etradelib
 
-import "github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"strconv"
+)
 
 type ETradeTransactionDetails interface {
 	GetId() int64
+	Typed() (Transaction, error)
 	AsJsonMap() jsonmap.JsonMap
 }
 
@@ -63,3 +67,9 @@ func (e *eTradeTransactionDetails) GetId() int64 {
 func (e *eTradeTransactionDetails) AsJsonMap() jsonmap.JsonMap {
 	return e.jsonMap
 }
+
+func (e *eTradeTransactionDetails) Typed() (Transaction, error) {
+	r := newTypedReader(e.jsonMap)
+	transaction := readTransaction(r, strconv.FormatInt(e.id, 10))
+	return transaction, r.err
+}
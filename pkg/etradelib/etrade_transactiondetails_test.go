@@ -2,9 +2,12 @@ package etradelib
 
 import (
 	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
 	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCreateETradeTransactionDetailsFromResponse(t *testing.T) {
@@ -95,3 +98,31 @@ func TestETradeTransactionDetails_AsJsonMap(t *testing.T) {
 	actualValue := testObject.AsJsonMap()
 	assert.Equal(t, expectedValue, actualValue)
 }
+
+func TestETradeTransactionDetails_Typed(t *testing.T) {
+	transactionDetails, err := CreateETradeTransactionDetailsFromResponse(
+		[]byte(`
+{
+  "TransactionDetailsResponse": {
+    "transactionId": 23091500001,
+    "accountId": "84910001",
+    "transactionDate": 1694750400000,
+    "amount": -1200.55,
+    "description": "Bought 10 GOOG"
+  }
+}`),
+	)
+	require.Nil(t, err)
+	expectValue := Transaction{
+		Id:          "23091500001",
+		AccountId:   "84910001",
+		Date:        time.UnixMilli(1694750400000),
+		Amount:      decimal.MustNewFromString("-1200.55"),
+		Description: "Bought 10 GOOG",
+	}
+
+	// Call the Method Under Test
+	actualValue, err := transactionDetails.Typed()
+	assert.Nil(t, err)
+	assert.Equal(t, expectValue, actualValue)
+}
@@ -0,0 +1,37 @@
+package etradelib
+
+import (
+	"context"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"time"
+)
+
+// TransactionIterator returns an account's transactions one at a time,
+// fetching each page of transactions as it is needed.
+type TransactionIterator = Iterator[ETradeTransaction]
+
+// NewTransactionIterator creates an iterator over the transactions for an
+// account. If maxItems is greater than zero, the iterator stops after that
+// many transactions.
+func NewTransactionIterator(
+	eTradeClient client.ETradeClient, accountIdKey string, startDate *time.Time, endDate *time.Time,
+	sortOrder constants.SortOrder, maxItems int,
+) TransactionIterator {
+	count := pageSize(constants.TransactionsMaxCount, maxItems)
+	return NewPageIterator(
+		func(ctx context.Context, marker string) ([]ETradeTransaction, string, error) {
+			response, err := eTradeClient.WithContext(ctx).ListTransactions(
+				accountIdKey, startDate, endDate, sortOrder, marker, count,
+			)
+			if err != nil {
+				return nil, "", err
+			}
+			transactionList, err := CreateETradeTransactionListFromResponse(response)
+			if err != nil {
+				return nil, "", err
+			}
+			return transactionList.GetAllTransactions(), transactionList.NextPage(), nil
+		}, maxItems,
+	)
+}
@@ -0,0 +1,103 @@
+package etradelib
+
+import (
+	"context"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTransactionIterator(t *testing.T) {
+	const firstPage = `
+{
+  "TransactionListResponse": {
+    "marker": "page2",
+    "Transaction": [
+      {
+        "transactionId": "1"
+      },
+      {
+        "transactionId": "2"
+      }
+    ]
+  }
+}`
+	const secondPage = `
+{
+  "TransactionListResponse": {
+    "Transaction": [
+      {
+        "transactionId": "3"
+      }
+    ]
+  }
+}`
+	tests := []struct {
+		name        string
+		maxItems    int
+		mockSetup   func(m *client.ETradeClientMock)
+		expectErr   bool
+		expectValue []jsonmap.JsonMap
+	}{
+		{
+			name: "Returns Transactions From Every Page",
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On("ListTransactions", "key", (*time.Time)(nil), (*time.Time)(nil), constants.SortOrderDesc, "", 50).
+					Return([]byte(firstPage), nil).Once()
+				m.On("ListTransactions", "key", (*time.Time)(nil), (*time.Time)(nil), constants.SortOrderDesc, "page2", 50).
+					Return([]byte(secondPage), nil).Once()
+			},
+			expectValue: []jsonmap.JsonMap{
+				{"transactionId": "1"}, {"transactionId": "2"}, {"transactionId": "3"},
+			},
+		},
+		{
+			name:     "Stops At Max Items Without Fetching More Pages",
+			maxItems: 2,
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On("ListTransactions", "key", (*time.Time)(nil), (*time.Time)(nil), constants.SortOrderDesc, "", 2).
+					Return([]byte(firstPage), nil).Once()
+			},
+			expectValue: []jsonmap.JsonMap{
+				{"transactionId": "1"}, {"transactionId": "2"},
+			},
+		},
+		{
+			name: "Fails If Client Fails",
+			mockSetup: func(m *client.ETradeClientMock) {
+				m.On("ListTransactions", "key", (*time.Time)(nil), (*time.Time)(nil), constants.SortOrderDesc, "", 50).
+					Return([]byte{}, errors.New("test error")).Once()
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				eTradeClient := client.ETradeClientMock{}
+				tt.mockSetup(&eTradeClient)
+				// Every request is made with the context passed to the iterator.
+				ctx := context.WithValue(context.Background(), "testKey", "testValue")
+				eTradeClient.On("WithContext", ctx).Return(&eTradeClient)
+				iterator := NewTransactionIterator(
+					&eTradeClient, "key", nil, nil, constants.SortOrderDesc, tt.maxItems,
+				)
+
+				// Call the Method Under Test
+				actualValue, err := CollectIterator(ctx, NewJsonMapIterator(iterator))
+				if tt.expectErr {
+					assert.Error(t, err)
+				} else {
+					assert.Nil(t, err)
+					assert.Equal(t, tt.expectValue, actualValue)
+				}
+				eTradeClient.AssertExpectations(t)
+			},
+		)
+	}
+}
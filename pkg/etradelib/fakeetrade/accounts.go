@@ -0,0 +1,215 @@
+package fakeetrade
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultPortfolioCount    = 50
+	defaultTransactionsCount = 50
+
+	// queryDateLayout is the layout of dates in query parameters (MMDDYYYY).
+	queryDateLayout = "01022006"
+)
+
+func (s *Server) listAccounts(w http.ResponseWriter, _ *http.Request) {
+	accounts := make(jsonmap.JsonSlice, 0, len(s.state.Accounts))
+	for _, account := range s.state.Accounts {
+		accounts = append(accounts, account.Account)
+	}
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"AccountListResponse": jsonmap.JsonMap{
+				"Accounts": jsonmap.JsonMap{
+					"Account": accounts,
+				},
+			},
+		},
+	)
+}
+
+func (s *Server) getAccountBalances(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"BalanceResponse": account.Balance})
+}
+
+func (s *Server) viewPortfolio(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	count, err := queryInt(r, "count", defaultPortfolioCount)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	pageNumber, err := queryInt(r, "pageNumber", 1)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	if count == 0 || pageNumber == 0 {
+		s.writeError(w, newBadRequestError("count and pageNumber must be greater than zero"))
+		return
+	}
+
+	start, end, next := page(len(account.Positions), (pageNumber-1)*count, count)
+	positions := make(jsonmap.JsonSlice, 0, end-start)
+	for _, position := range account.Positions[start:end] {
+		positions = append(positions, position.Position)
+	}
+	accountPortfolio := jsonmap.JsonMap{
+		"accountId":  account.Account["accountId"],
+		"Position":   positions,
+		"totalPages": int64((len(account.Positions) + count - 1) / count),
+	}
+	// Like ETrade, only include the next page number if there is one.
+	if next >= 0 {
+		accountPortfolio["nextPageNo"] = strconv.Itoa(pageNumber + 1)
+	}
+	portfolioResponse := jsonmap.JsonMap{
+		"AccountPortfolio": jsonmap.JsonSlice{accountPortfolio},
+	}
+	if r.URL.Query().Get("totalsRequired") == "true" {
+		portfolioResponse["Totals"] = account.PortfolioTotals
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"PortfolioResponse": portfolioResponse})
+}
+
+func (s *Server) listPositionLotsDetails(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	positionIdString := chi.URLParam(r, "positionId")
+	positionId, err := strconv.ParseInt(positionIdString, 10, 64)
+	if err != nil {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("invalid position ID '%s'", positionIdString)))
+		return
+	}
+	position, found := account.position(positionId)
+	if !found {
+		s.writeError(w, newNotFoundError(fmt.Sprintf("position %d not found", positionId)))
+		return
+	}
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"PositionLotsResponse": jsonmap.JsonMap{
+				"PositionLot": toJsonSlice(position.Lots),
+			},
+		},
+	)
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	count, err := queryInt(r, "count", defaultTransactionsCount)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	// The marker is the index of the first transaction to return.
+	marker, err := queryInt(r, "marker", 0)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	startDate, err := queryDate(r, "startDate")
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	endDate, err := queryDate(r, "endDate")
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	transactions := make([]jsonmap.JsonMap, 0, len(account.Transactions))
+	for _, transaction := range account.Transactions {
+		transactionDate := time.UnixMilli(transactionMillis(transaction.Transaction))
+		if startDate != nil && transactionDate.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && !transactionDate.Before(endDate.AddDate(0, 0, 1)) {
+			continue
+		}
+		transactions = append(transactions, transaction.Transaction)
+	}
+	// ETrade returns the newest transactions first unless asked otherwise.
+	ascending := r.URL.Query().Get("sortOrder") == "ASC"
+	sort.SliceStable(
+		transactions, func(i, j int) bool {
+			if ascending {
+				return transactionMillis(transactions[i]) < transactionMillis(transactions[j])
+			}
+			return transactionMillis(transactions[i]) > transactionMillis(transactions[j])
+		},
+	)
+
+	start, end, next := page(len(transactions), marker, count)
+	transactionListResponse := jsonmap.JsonMap{
+		"Transaction":      toJsonSlice(transactions[start:end]),
+		"transactionCount": int64(end - start),
+		"totalCount":       int64(len(transactions)),
+		"moreTransactions": next >= 0,
+	}
+	if next >= 0 {
+		transactionListResponse["marker"] = strconv.Itoa(next)
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"TransactionListResponse": transactionListResponse})
+}
+
+func (s *Server) listTransactionDetails(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	transactionId := chi.URLParam(r, "transactionId")
+	transaction, found := account.transaction(transactionId)
+	if !found {
+		s.writeError(w, newNotFoundError(fmt.Sprintf("transaction '%s' not found", transactionId)))
+		return
+	}
+	details := transaction.Details
+	if details == nil {
+		details = transaction.Transaction
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"TransactionDetailsResponse": details})
+}
+
+// queryDate returns the value of a date query parameter, or nil if the
+// parameter is absent.
+func queryDate(r *http.Request, key string) (*time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation(queryDateLayout, value, time.Local)
+	if err != nil {
+		return nil, newBadRequestError(fmt.Sprintf("invalid %s '%s'", key, value))
+	}
+	return &date, nil
+}
+
+func transactionMillis(transaction jsonmap.JsonMap) int64 {
+	millis, _ := transaction.GetInt("transactionDate")
+	return millis
+}
@@ -0,0 +1,104 @@
+package fakeetrade
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultAlertsCount = 25
+
+func (s *Server) listAlerts(w http.ResponseWriter, r *http.Request) {
+	count, err := queryInt(r, "count", defaultAlertsCount)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	category := r.URL.Query().Get("category")
+	status := r.URL.Query().Get("status")
+	search := strings.ToLower(r.URL.Query().Get("search"))
+
+	alerts := make([]jsonmap.JsonMap, 0, len(s.state.Alerts))
+	for _, alert := range s.state.Alerts {
+		if alertCategory, _ := alert.Alert.GetString("category"); category != "" && alertCategory != category {
+			continue
+		}
+		if alertStatus, _ := alert.Alert.GetString("status"); status != "" && alertStatus != status {
+			continue
+		}
+		subject, _ := alert.Alert.GetString("subject")
+		if search != "" && !strings.Contains(strings.ToLower(subject), search) {
+			continue
+		}
+		alerts = append(alerts, alert.Alert)
+	}
+	// ETrade returns the newest alerts first unless asked otherwise.
+	ascending := r.URL.Query().Get("direction") == "ASC"
+	sort.SliceStable(
+		alerts, func(i, j int) bool {
+			iCreateTime, _ := alerts[i].GetInt("createTime")
+			jCreateTime, _ := alerts[j].GetInt("createTime")
+			if ascending {
+				return iCreateTime < jCreateTime
+			}
+			return iCreateTime > jCreateTime
+		},
+	)
+	start, end, _ := page(len(alerts), 0, count)
+
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"AlertsResponse": jsonmap.JsonMap{
+				"totalAlerts": int64(len(alerts)),
+				"Alert":       toJsonSlice(alerts[start:end]),
+			},
+		},
+	)
+}
+
+func (s *Server) listAlertDetails(w http.ResponseWriter, r *http.Request) {
+	alertIdString := chi.URLParam(r, "alertId")
+	alertId, err := strconv.ParseInt(alertIdString, 10, 64)
+	if err != nil {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("invalid alert ID '%s'", alertIdString)))
+		return
+	}
+	alert, _, found := s.state.alert(alertId)
+	if !found {
+		s.writeError(w, newNotFoundError(fmt.Sprintf("alert %d not found", alertId)))
+		return
+	}
+	details := alert.Details
+	if details == nil {
+		details = alert.Alert
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"AlertDetailsResponse": details})
+}
+
+func (s *Server) deleteAlerts(w http.ResponseWriter, r *http.Request) {
+	failedAlertIds := jsonmap.JsonSlice{}
+	for _, alertIdString := range strings.Split(chi.URLParam(r, "alertIds"), ",") {
+		alertId, err := strconv.ParseInt(alertIdString, 10, 64)
+		if err != nil {
+			s.writeError(w, newBadRequestError(fmt.Sprintf("invalid alert ID '%s'", alertIdString)))
+			return
+		}
+		_, index, found := s.state.alert(alertId)
+		if !found {
+			failedAlertIds = append(failedAlertIds, alertId)
+			continue
+		}
+		s.state.Alerts = append(s.state.Alerts[:index], s.state.Alerts[index+1:]...)
+	}
+
+	alertsResponse := jsonmap.JsonMap{"result": "SUCCESS"}
+	if len(failedAlertIds) > 0 {
+		alertsResponse["result"] = "ERROR"
+		alertsResponse["failedAlerts"] = jsonmap.JsonMap{"alertId": failedAlertIds}
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"AlertsResponse": alertsResponse})
+}
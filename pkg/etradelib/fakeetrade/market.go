@@ -0,0 +1,86 @@
+package fakeetrade
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) getQuotes(w http.ResponseWriter, r *http.Request) {
+	quotes := jsonmap.JsonSlice{}
+	messages := jsonmap.JsonSlice{}
+	for _, symbol := range strings.Split(chi.URLParam(r, "symbols"), ",") {
+		quote, found := s.state.quote(strings.ToUpper(symbol))
+		if !found {
+			// Like ETrade, report invalid symbols in messages rather than
+			// failing the request.
+			messages = append(
+				messages, jsonmap.JsonMap{
+					"description": fmt.Sprintf("%s is not a valid symbol", symbol),
+					"code":        10033,
+					"type":        "WARNING",
+				},
+			)
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+	quoteResponse := jsonmap.JsonMap{
+		"QuoteData": quotes,
+	}
+	if len(messages) > 0 {
+		quoteResponse["Messages"] = jsonmap.JsonMap{"Message": messages}
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"QuoteResponse": quoteResponse})
+}
+
+func (s *Server) lookupProduct(w http.ResponseWriter, r *http.Request) {
+	search := strings.ToLower(chi.URLParam(r, "search"))
+	products := jsonmap.JsonSlice{}
+	for _, product := range s.state.Products {
+		symbol, _ := product.GetString("symbol")
+		description, _ := product.GetString("description")
+		if strings.HasPrefix(strings.ToLower(symbol), search) ||
+			strings.Contains(strings.ToLower(description), search) {
+			products = append(products, product)
+		}
+	}
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"LookupResponse": jsonmap.JsonMap{
+				"Data": products,
+			},
+		},
+	)
+}
+
+// getOptionChains returns the option chain for the requested symbol. The
+// chain is returned as it appears in the state; the filtering parameters
+// (e.g. strikePriceNear) are ignored.
+func (s *Server) getOptionChains(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.ToUpper(r.URL.Query().Get("symbol"))
+	optionChain, found := s.state.OptionChains[symbol]
+	if !found {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("no option chain for symbol '%s'", symbol)))
+		return
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"OptionChainResponse": optionChain})
+}
+
+func (s *Server) getOptionExpireDates(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.ToUpper(r.URL.Query().Get("symbol"))
+	expireDates, found := s.state.OptionExpireDates[symbol]
+	if !found {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("no option expiration dates for symbol '%s'", symbol)))
+		return
+	}
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"OptionExpireDateResponse": jsonmap.JsonMap{
+				"ExpirationDate": toJsonSlice(expireDates),
+			},
+		},
+	)
+}
@@ -0,0 +1,370 @@
+package fakeetrade
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/dghubble/oauth1"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuthorizePath is the path of the page that authorizes a request token. The
+// fake server authorizes every request token without asking; the page
+// responds with the verification code as plain text.
+const AuthorizePath = "/e/t/etws/authorize"
+
+const (
+	oauthCallbackParam        = "oauth_callback"
+	oauthConsumerKeyParam     = "oauth_consumer_key"
+	oauthNonceParam           = "oauth_nonce"
+	oauthSignatureParam       = "oauth_signature"
+	oauthSignatureMethodParam = "oauth_signature_method"
+	oauthTimestampParam       = "oauth_timestamp"
+	oauthTokenParam           = "oauth_token"
+	oauthVerifierParam        = "oauth_verifier"
+	oauthVersionParam         = "oauth_version"
+	realmParam                = "realm"
+
+	authorizationPrefix = "OAuth "
+	signatureMethod     = "HMAC-SHA1"
+	oauthVersion        = "1.0"
+
+	// timestampWindow is how far a request's timestamp may be from the
+	// server's clock.
+	timestampWindow = 5 * time.Minute
+)
+
+// oauthProblem is an OAuth failure. Its message is returned to the client in
+// the body of a 401 response, as ETrade does.
+type oauthProblem string
+
+func (p oauthProblem) Error() string {
+	return "oauth_problem=" + string(p)
+}
+
+const (
+	problemParameterAbsent    = oauthProblem("parameter_absent")
+	problemSignatureMethod    = oauthProblem("signature_method_rejected")
+	problemVersionRejected    = oauthProblem("version_rejected")
+	problemConsumerKeyUnknown = oauthProblem("consumer_key_unknown")
+	problemTimestampRefused   = oauthProblem("timestamp_refused")
+	problemNonceUsed          = oauthProblem("nonce_used")
+	problemTokenRejected      = oauthProblem("token_rejected")
+	problemSignatureInvalid   = oauthProblem("signature_invalid")
+	problemVerifierInvalid    = oauthProblem("oauth_verifier_invalid")
+)
+
+// tokenKind identifies which kind of token a request must be signed with.
+type tokenKind int
+
+const (
+	tokenKindNone tokenKind = iota
+	tokenKindRequest
+	tokenKindAccess
+)
+
+// requestToken is a request token that has been issued but not yet exchanged
+// for an access token.
+type requestToken struct {
+	consumerKey string
+	secret      string
+	verifier    string
+	authorized  bool
+}
+
+// authenticate verifies a request's OAuth 1.0a signature and returns its OAuth
+// parameters. The request must be signed with a token of the given kind. The
+// caller must hold the server's lock.
+func (s *Server) authenticate(r *http.Request, kind tokenKind) (map[string]string, error) {
+	oauthParams, err := parseAuthorizationHeader(r.Header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range []string{
+		oauthConsumerKeyParam, oauthNonceParam, oauthSignatureParam, oauthSignatureMethodParam, oauthTimestampParam,
+	} {
+		if oauthParams[param] == "" {
+			return nil, problemParameterAbsent
+		}
+	}
+	if oauthParams[oauthSignatureMethodParam] != signatureMethod {
+		return nil, problemSignatureMethod
+	}
+	if version, found := oauthParams[oauthVersionParam]; found && version != oauthVersion {
+		return nil, problemVersionRejected
+	}
+	consumerKey := oauthParams[oauthConsumerKeyParam]
+	consumerSecret, found := s.state.consumerSecret(consumerKey)
+	if !found {
+		return nil, problemConsumerKeyUnknown
+	}
+
+	var tokenSecret string
+	token := oauthParams[oauthTokenParam]
+	switch kind {
+	case tokenKindRequest:
+		pending, found := s.requestTokens[token]
+		if !found || pending.consumerKey != consumerKey {
+			return nil, problemTokenRejected
+		}
+		tokenSecret = pending.secret
+	case tokenKindAccess:
+		accessToken, found := s.state.accessToken(token)
+		if !found || accessToken.ConsumerKey != consumerKey {
+			return nil, problemTokenRejected
+		}
+		tokenSecret = accessToken.Secret
+	}
+
+	if err = s.checkTimestampAndNonce(consumerKey, oauthParams); err != nil {
+		return nil, err
+	}
+
+	baseString, err := signatureBaseString(r, oauthParams)
+	if err != nil {
+		return nil, err
+	}
+	expectedSignature := sign(consumerSecret, tokenSecret, baseString)
+	if !hmac.Equal([]byte(expectedSignature), []byte(oauthParams[oauthSignatureParam])) {
+		return nil, problemSignatureInvalid
+	}
+	return oauthParams, nil
+}
+
+// checkTimestampAndNonce rejects requests that are too old or that repeat a
+// nonce, which would indicate a replayed request.
+func (s *Server) checkTimestampAndNonce(consumerKey string, oauthParams map[string]string) error {
+	timestamp, err := strconv.ParseInt(oauthParams[oauthTimestampParam], 10, 64)
+	if err != nil {
+		return problemTimestampRefused
+	}
+	now := s.now()
+	requestTime := time.Unix(timestamp, 0)
+	if requestTime.Before(now.Add(-timestampWindow)) || requestTime.After(now.Add(timestampWindow)) {
+		return problemTimestampRefused
+	}
+	// Forget nonces that are too old to be replayed.
+	for nonce, nonceTime := range s.nonces {
+		if nonceTime.Before(now.Add(-timestampWindow)) {
+			delete(s.nonces, nonce)
+		}
+	}
+	nonce := consumerKey + "&" + oauthParams[oauthTimestampParam] + "&" + oauthParams[oauthNonceParam]
+	if _, found := s.nonces[nonce]; found {
+		return problemNonceUsed
+	}
+	s.nonces[nonce] = requestTime
+	return nil
+}
+
+// parseAuthorizationHeader parses the parameters from an OAuth Authorization
+// header (RFC 5849 3.5.1).
+func parseAuthorizationHeader(header string) (map[string]string, error) {
+	if !strings.HasPrefix(header, authorizationPrefix) {
+		return nil, problemParameterAbsent
+	}
+	params := map[string]string{}
+	for _, pair := range strings.Split(header[len(authorizationPrefix):], ",") {
+		key, quotedValue, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || len(quotedValue) < 2 || quotedValue[0] != '"' || quotedValue[len(quotedValue)-1] != '"' {
+			return nil, problemParameterAbsent
+		}
+		value, err := url.PathUnescape(quotedValue[1 : len(quotedValue)-1])
+		if err != nil {
+			return nil, problemParameterAbsent
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// signatureBaseString builds the string that a request's signature is
+// computed over (RFC 5849 3.4.1).
+func signatureBaseString(r *http.Request, oauthParams map[string]string) (string, error) {
+	params := map[string]string{}
+	for key, values := range r.URL.Query() {
+		params[key] = values[0]
+	}
+	if r.Body != nil && r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", err
+		}
+		for key, value := range values {
+			params[key] = value[0]
+		}
+	}
+	for key, value := range oauthParams {
+		if key != realmParam && key != oauthSignatureParam {
+			params[key] = value
+		}
+	}
+
+	encodedParams := map[string]string{}
+	encodedKeys := make([]string, 0, len(params))
+	for key, value := range params {
+		encodedKey := oauth1.PercentEncode(key)
+		encodedParams[encodedKey] = oauth1.PercentEncode(value)
+		encodedKeys = append(encodedKeys, encodedKey)
+	}
+	sort.Strings(encodedKeys)
+	pairs := make([]string, 0, len(encodedKeys))
+	for _, encodedKey := range encodedKeys {
+		pairs = append(pairs, encodedKey+"="+encodedParams[encodedKey])
+	}
+
+	return strings.Join(
+		[]string{
+			strings.ToUpper(r.Method),
+			oauth1.PercentEncode(requestBaseUri(r)),
+			oauth1.PercentEncode(strings.Join(pairs, "&")),
+		}, "&",
+	), nil
+}
+
+// requestBaseUri returns the scheme, host, and path of the URL that the client
+// requested (RFC 5849 3.4.1.2).
+func requestBaseUri(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := strings.ToLower(r.Host)
+	if hostname, port, found := strings.Cut(host, ":"); found && (port == "80" || port == "443") {
+		host = hostname
+	}
+	path := strings.Split(r.URL.RequestURI(), "?")[0]
+	return fmt.Sprintf("%s://%s%s", scheme, host, path)
+}
+
+// sign computes an HMAC-SHA1 signature (RFC 5849 3.4.2).
+func sign(consumerSecret string, tokenSecret string, baseString string) string {
+	key := oauth1.PercentEncode(consumerSecret) + "&" + oauth1.PercentEncode(tokenSecret)
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newRandomString() string {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(randomBytes)
+}
+
+func (s *Server) getRequestToken(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	oauthParams, err := s.authenticate(r, tokenKindNone)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	if oauthParams[oauthCallbackParam] != "oob" {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("unsupported callback '%s'", oauthParams[oauthCallbackParam])))
+		return
+	}
+	token := newRandomString()
+	pending := &requestToken{
+		consumerKey: oauthParams[oauthConsumerKeyParam],
+		secret:      newRandomString(),
+		verifier:    strings.ToUpper(newRandomString()[:5]),
+	}
+	s.requestTokens[token] = pending
+	writeForm(
+		w, url.Values{
+			"oauth_token":              {token},
+			"oauth_token_secret":       {pending.secret},
+			"oauth_callback_confirmed": {"true"},
+		},
+	)
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	pending, found := s.requestTokens[r.URL.Query().Get("token")]
+	if !found || pending.consumerKey != r.URL.Query().Get("key") {
+		s.writeError(w, problemTokenRejected)
+		return
+	}
+	pending.authorized = true
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(pending.verifier))
+}
+
+func (s *Server) getAccessToken(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	oauthParams, err := s.authenticate(r, tokenKindRequest)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	token := oauthParams[oauthTokenParam]
+	pending := s.requestTokens[token]
+	if !pending.authorized || oauthParams[oauthVerifierParam] != pending.verifier {
+		s.writeError(w, problemVerifierInvalid)
+		return
+	}
+	// A request token can only be exchanged once.
+	delete(s.requestTokens, token)
+
+	accessToken := AccessToken{
+		ConsumerKey: pending.consumerKey,
+		Token:       newRandomString(),
+		Secret:      newRandomString(),
+	}
+	s.state.AccessTokens = append(s.state.AccessTokens, accessToken)
+	writeForm(
+		w, url.Values{
+			"oauth_token":        {accessToken.Token},
+			"oauth_token_secret": {accessToken.Secret},
+		},
+	)
+}
+
+func (s *Server) renewAccessToken(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.authenticate(r, tokenKindAccess); err != nil {
+		s.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte("Access Token has been renewed"))
+}
+
+func (s *Server) revokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	oauthParams, err := s.authenticate(r, tokenKindAccess)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.state.removeAccessToken(oauthParams[oauthTokenParam])
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte("Revoked Access Token"))
+}
+
+func writeForm(w http.ResponseWriter, values url.Values) {
+	w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+	_, _ = w.Write([]byte(values.Encode()))
+}
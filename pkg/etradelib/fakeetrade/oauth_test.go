@@ -0,0 +1,210 @@
+package fakeetrade
+
+import (
+	"context"
+	"github.com/dghubble/oauth1"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer_Login(t *testing.T) {
+	server := NewServer(DefaultState(), etradelibtest.CreateNullLogger())
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	urls, err := client.NewEndpointUrls(httpServer.URL, httpServer.URL+AuthorizePath)
+	require.Nil(t, err)
+	testClient, err := client.CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "fakeConsumerKey", "fakeConsumerSecret", "", "",
+		client.RetryPolicy{}, nil, nil,
+	)
+	require.Nil(t, err)
+
+	// Without an access token, requests are rejected.
+	_, err = testClient.ListAccounts()
+	assert.True(t, client.IsAuthFailed(err))
+
+	// Get a request token and authorize it.
+	response, err := testClient.Authenticate()
+	require.Nil(t, err)
+	authorizeResponse, err := httpServer.Client().Get(getAuthorizationUrl(t, response))
+	require.Nil(t, err)
+	verifier, err := io.ReadAll(authorizeResponse.Body)
+	_ = authorizeResponse.Body.Close()
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, authorizeResponse.StatusCode)
+
+	// Exchange the request token for an access token.
+	_, err = testClient.Verify(string(verifier))
+	require.Nil(t, err)
+	_, err = testClient.ListAccounts()
+	assert.Nil(t, err)
+	_, err = testClient.RenewAccessToken()
+	assert.Nil(t, err)
+
+	// After the access token is revoked, requests signed with it are
+	// rejected.
+	_, _, accessToken, accessSecret := testClient.GetKeys()
+	_, err = testClient.RevokeAccessToken()
+	require.Nil(t, err)
+	revokedClient, err := client.CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "fakeConsumerKey", "fakeConsumerSecret", accessToken, accessSecret,
+		client.RetryPolicy{}, nil, nil,
+	)
+	require.Nil(t, err)
+	_, err = revokedClient.ListAccounts()
+	assert.True(t, client.IsAuthFailed(err))
+}
+
+func TestServer_Verify_RejectsWrongVerifier(t *testing.T) {
+	server := NewServer(DefaultState(), etradelibtest.CreateNullLogger())
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	urls, err := client.NewEndpointUrls(httpServer.URL, httpServer.URL+AuthorizePath)
+	require.Nil(t, err)
+	testClient, err := client.CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "fakeConsumerKey", "fakeConsumerSecret", "", "",
+		client.RetryPolicy{}, nil, nil,
+	)
+	require.Nil(t, err)
+	_, err = testClient.Authenticate()
+	require.Nil(t, err)
+
+	// Call the Method Under Test
+	_, err = testClient.Verify("WRONG")
+	assert.Error(t, err)
+}
+
+func TestServer_RejectsInvalidSignatures(t *testing.T) {
+	tests := []struct {
+		name           string
+		consumerKey    string
+		consumerSecret string
+		accessToken    string
+		accessSecret   string
+		expectMessage  string
+	}{
+		{
+			name:           "Unknown Consumer Key",
+			consumerKey:    "badConsumerKey",
+			consumerSecret: "fakeConsumerSecret",
+			accessToken:    "fakeAccessToken",
+			accessSecret:   "fakeAccessSecret",
+			expectMessage:  "oauth_problem=consumer_key_unknown",
+		},
+		{
+			name:           "Wrong Consumer Secret",
+			consumerKey:    "fakeConsumerKey",
+			consumerSecret: "badConsumerSecret",
+			accessToken:    "fakeAccessToken",
+			accessSecret:   "fakeAccessSecret",
+			expectMessage:  "oauth_problem=signature_invalid",
+		},
+		{
+			name:           "Unknown Access Token",
+			consumerKey:    "fakeConsumerKey",
+			consumerSecret: "fakeConsumerSecret",
+			accessToken:    "badAccessToken",
+			accessSecret:   "fakeAccessSecret",
+			expectMessage:  "oauth_problem=token_rejected",
+		},
+		{
+			name:           "Wrong Access Secret",
+			consumerKey:    "fakeConsumerKey",
+			consumerSecret: "fakeConsumerSecret",
+			accessToken:    "fakeAccessToken",
+			accessSecret:   "badAccessSecret",
+			expectMessage:  "oauth_problem=signature_invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				server := NewServer(DefaultState(), etradelibtest.CreateNullLogger())
+				httpServer := httptest.NewServer(server)
+				defer httpServer.Close()
+				urls, err := client.NewEndpointUrls(httpServer.URL, "")
+				require.Nil(t, err)
+				testClient, err := client.CreateETradeClient(
+					etradelibtest.CreateNullLogger(), urls, tt.consumerKey, tt.consumerSecret, tt.accessToken,
+					tt.accessSecret, client.RetryPolicy{}, nil, nil,
+				)
+				require.Nil(t, err)
+
+				// Call the Method Under Test
+				_, err = testClient.ListAccounts()
+				apiError, ok := client.AsETradeAPIError(err)
+				require.True(t, ok)
+				assert.Equal(t, http.StatusUnauthorized, apiError.StatusCode)
+				assert.Equal(t, tt.expectMessage, apiError.Message)
+			},
+		)
+	}
+}
+
+// headerRecorder is a transport that records the Authorization header of the
+// last request it sends.
+type headerRecorder struct {
+	authorization string
+}
+
+func (h *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.authorization = req.Header.Get("Authorization")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestServer_RejectsReplayedRequests(t *testing.T) {
+	server := NewServer(DefaultState(), etradelibtest.CreateNullLogger())
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	config := oauth1.Config{
+		ConsumerKey:    "fakeConsumerKey",
+		ConsumerSecret: "fakeConsumerSecret",
+	}
+	recorder := &headerRecorder{}
+	ctx := context.WithValue(oauth1.NoContext, oauth1.HTTPClient, &http.Client{Transport: recorder})
+	httpClient := config.Client(ctx, oauth1.NewToken("fakeAccessToken", "fakeAccessSecret"))
+	firstResponse, err := httpClient.Get(httpServer.URL + "/v1/accounts/list")
+	require.Nil(t, err)
+	_ = firstResponse.Body.Close()
+	require.Equal(t, http.StatusOK, firstResponse.StatusCode)
+
+	// Call the Method Under Test
+	replayedRequest, err := http.NewRequest("GET", httpServer.URL+"/v1/accounts/list", nil)
+	require.Nil(t, err)
+	replayedRequest.Header.Set("Authorization", recorder.authorization)
+	replayedResponse, err := http.DefaultClient.Do(replayedRequest)
+	require.Nil(t, err)
+	body, _ := io.ReadAll(replayedResponse.Body)
+	_ = replayedResponse.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, replayedResponse.StatusCode)
+	assert.JSONEq(t, `{"Error":{"message":"oauth_problem=nonce_used"}}`, string(body))
+}
+
+func TestServer_RejectsStaleTimestamps(t *testing.T) {
+	server := NewServer(DefaultState(), etradelibtest.CreateNullLogger())
+	server.now = func() time.Time { return time.Now().Add(time.Hour) }
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	urls, err := client.NewEndpointUrls(httpServer.URL, "")
+	require.Nil(t, err)
+	testClient, err := client.CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "fakeConsumerKey", "fakeConsumerSecret", "fakeAccessToken",
+		"fakeAccessSecret", client.RetryPolicy{}, nil, nil,
+	)
+	require.Nil(t, err)
+
+	// Call the Method Under Test
+	_, err = testClient.ListAccounts()
+	apiError, ok := client.AsETradeAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, "oauth_problem=timestamp_refused", apiError.Message)
+}
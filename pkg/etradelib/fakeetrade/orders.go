@@ -0,0 +1,391 @@
+package fakeetrade
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultOrdersCount = 25
+
+	orderStatusOpen      = "OPEN"
+	orderStatusCancelled = "CANCELLED"
+)
+
+// orderPreview is an order that has been previewed and can be placed with its
+// preview ID.
+type orderPreview struct {
+	accountIdKey string
+	// orderId is the ID of the order being changed, or 0 for a new order.
+	orderId int64
+	order   jsonmap.JsonMap
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	count, err := queryInt(r, "count", defaultOrdersCount)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	// The marker is the index of the first order to return.
+	marker, err := queryInt(r, "marker", 0)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	fromDate, err := queryDate(r, "fromDate")
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	toDate, err := queryDate(r, "toDate")
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	var symbols []string
+	if symbolList := r.URL.Query().Get("symbol"); symbolList != "" {
+		symbols = strings.Split(symbolList, ",")
+	}
+
+	orders := make([]jsonmap.JsonMap, 0, len(account.Orders))
+	for _, order := range account.Orders {
+		orderStatus, _ := order.GetStringAtPath(".OrderDetail[0].status")
+		if status != "" && orderStatus != status {
+			continue
+		}
+		placedTime, _ := order.GetIntAtPath(".OrderDetail[0].placedTime")
+		if fromDate != nil && placedTime < fromDate.UnixMilli() {
+			continue
+		}
+		if toDate != nil && placedTime >= toDate.AddDate(0, 0, 1).UnixMilli() {
+			continue
+		}
+		if len(symbols) > 0 && !orderHasSymbol(order, symbols) {
+			continue
+		}
+		orders = append(orders, order)
+	}
+
+	start, end, next := page(len(orders), marker, count)
+	ordersResponse := jsonmap.JsonMap{
+		"Order": toJsonSlice(orders[start:end]),
+	}
+	if next >= 0 {
+		ordersResponse["marker"] = strconv.Itoa(next)
+	}
+	s.writeJson(w, http.StatusOK, jsonmap.JsonMap{"OrdersResponse": ordersResponse})
+}
+
+func (s *Server) previewOrder(w http.ResponseWriter, r *http.Request) {
+	s.preview(w, r, 0)
+}
+
+func (s *Server) previewChangedOrder(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	orderId, err := s.requestOpenOrderId(r, account)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.preview(w, r, orderId)
+}
+
+func (s *Server) preview(w http.ResponseWriter, r *http.Request, orderId int64) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	request, err := readOrderRequest(r, "PreviewOrderRequest")
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	previewId := s.state.nextId()
+	s.previews[previewId] = &orderPreview{
+		accountIdKey: chi.URLParam(r, "accountIdKey"),
+		orderId:      orderId,
+		order:        request,
+	}
+
+	orderValue := orderRequestValue(request)
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"PreviewOrderResponse": jsonmap.JsonMap{
+				"orderType":       request["orderType"],
+				"clientOrderId":   request["clientOrderId"],
+				"Order":           request["Order"],
+				"PreviewIds":      jsonmap.JsonSlice{jsonmap.JsonMap{"previewId": previewId}},
+				"previewTime":     s.now().UnixMilli(),
+				"accountId":       account.Account["accountId"],
+				"totalOrderValue": orderValue.JsonNumber(),
+				"totalCommission": 0,
+			},
+		},
+	)
+}
+
+func (s *Server) placeOrder(w http.ResponseWriter, r *http.Request) {
+	s.place(w, r, 0)
+}
+
+func (s *Server) placeChangedOrder(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	orderId, err := s.requestOpenOrderId(r, account)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	s.place(w, r, orderId)
+}
+
+// place places a previewed order. If orderId is not 0, the order replaces the
+// open order with that ID.
+func (s *Server) place(w http.ResponseWriter, r *http.Request, orderId int64) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	request, err := readOrderRequest(r, "PlaceOrderRequest")
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	previewId, err := request.GetIntAtPath(".PreviewIds[0].previewId")
+	if err != nil {
+		s.writeError(w, newBadRequestError("previewId not provided"))
+		return
+	}
+	preview, found := s.previews[previewId]
+	if !found || preview.accountIdKey != chi.URLParam(r, "accountIdKey") || preview.orderId != orderId {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("preview %d not found", previewId)))
+		return
+	}
+	// ETrade requires that the placed order match the previewed order.
+	if !ordersMatch(preview.order, request) {
+		s.writeError(w, newBadRequestError("order does not match the previewed order"))
+		return
+	}
+	delete(s.previews, previewId)
+
+	placedTime := s.now().UnixMilli()
+	if orderId == 0 {
+		orderId = s.state.nextId()
+		account.Orders = append(account.Orders, newOrder(orderId, request, placedTime))
+	} else {
+		for i, order := range account.Orders {
+			if id, _ := order.GetInt("orderId"); id == orderId {
+				account.Orders[i] = newOrder(orderId, request, placedTime)
+			}
+		}
+	}
+
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"PlaceOrderResponse": jsonmap.JsonMap{
+				"orderType":     request["orderType"],
+				"clientOrderId": request["clientOrderId"],
+				"Order":         request["Order"],
+				"OrderIds":      jsonmap.JsonSlice{jsonmap.JsonMap{"orderId": orderId}},
+				"placedTime":    placedTime,
+				"accountId":     account.Account["accountId"],
+			},
+		},
+	)
+}
+
+func (s *Server) cancelOrder(w http.ResponseWriter, r *http.Request) {
+	account, err := s.requestAccount(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	request, err := jsonmap.NewJsonMapFromIoReader(r.Body)
+	if err != nil {
+		s.writeError(w, newBadRequestError(err.Error()))
+		return
+	}
+	orderId, err := request.GetIntAtPath(".CancelOrderRequest.orderId")
+	if err != nil {
+		s.writeError(w, newBadRequestError("orderId not provided"))
+		return
+	}
+	order, found := account.order(orderId)
+	if !found {
+		s.writeError(w, newNotFoundError(fmt.Sprintf("order %d not found", orderId)))
+		return
+	}
+	if status, _ := order.GetStringAtPath(".OrderDetail[0].status"); status != orderStatusOpen {
+		s.writeError(w, newBadRequestError(fmt.Sprintf("order %d is not open", orderId)))
+		return
+	}
+	_ = order.SetStringAtPath(".OrderDetail[0].status", orderStatusCancelled)
+
+	s.writeJson(
+		w, http.StatusOK, jsonmap.JsonMap{
+			"CancelOrderResponse": jsonmap.JsonMap{
+				"accountId":  account.Account["accountId"],
+				"orderId":    orderId,
+				"cancelTime": s.now().UnixMilli(),
+				"Messages": jsonmap.JsonMap{
+					"Message": jsonmap.JsonSlice{
+						jsonmap.JsonMap{
+							"description": "Your request to cancel your order is being processed.",
+							"type":        "WARNING",
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
+// requestOpenOrderId returns the ID of the open order identified by the
+// request's orderId URL parameter.
+func (s *Server) requestOpenOrderId(r *http.Request, account *Account) (int64, error) {
+	orderIdString := chi.URLParam(r, "orderId")
+	orderId, err := strconv.ParseInt(orderIdString, 10, 64)
+	if err != nil {
+		return 0, newBadRequestError(fmt.Sprintf("invalid order ID '%s'", orderIdString))
+	}
+	order, found := account.order(orderId)
+	if !found {
+		return 0, newNotFoundError(fmt.Sprintf("order %d not found", orderId))
+	}
+	if status, _ := order.GetStringAtPath(".OrderDetail[0].status"); status != orderStatusOpen {
+		return 0, newBadRequestError(fmt.Sprintf("order %d is not open", orderId))
+	}
+	return orderId, nil
+}
+
+// readOrderRequest reads a preview or place request from the request body and
+// returns the map under the given key.
+func readOrderRequest(r *http.Request, key string) (jsonmap.JsonMap, error) {
+	body, err := jsonmap.NewJsonMapFromIoReader(r.Body)
+	if err != nil {
+		return nil, newBadRequestError(err.Error())
+	}
+	request, err := body.GetMap(key)
+	if err != nil || request == nil {
+		return nil, newBadRequestError(fmt.Sprintf("%s not provided", key))
+	}
+	if _, err = request.GetSliceOfMapsAtPath(".Order[0].Instrument"); err != nil {
+		return nil, newBadRequestError("order instruments not provided")
+	}
+	return request, nil
+}
+
+// ordersMatch reports whether a place request is for the same order as a
+// preview request.
+func ordersMatch(previewRequest jsonmap.JsonMap, placeRequest jsonmap.JsonMap) bool {
+	previewOrder, _ := previewRequest.GetSlice("Order")
+	placeOrder, _ := placeRequest.GetSlice("Order")
+	previewBytes, err := previewOrder.ToJsonBytes(false, false)
+	if err != nil {
+		return false
+	}
+	placeBytes, err := placeOrder.ToJsonBytes(false, false)
+	if err != nil {
+		return false
+	}
+	return jsonValueString(previewRequest["orderType"]) == jsonValueString(placeRequest["orderType"]) &&
+		string(previewBytes) == string(placeBytes)
+}
+
+// orderRequestValue returns the approximate value of an order: its limit
+// price, or zero for a market order, times the quantity of each instrument.
+func orderRequestValue(request jsonmap.JsonMap) decimal.Decimal {
+	limitPrice := jsonDecimal(request.GetValueAtPathWithDefault(".Order[0].limitPrice", nil))
+	instruments, _ := request.GetSliceOfMapsAtPath(".Order[0].Instrument")
+	value := decimal.Zero
+	for _, instrument := range instruments {
+		quantity := jsonDecimal(instrument.GetValueWithDefault("quantity", nil))
+		value = value.Add(limitPrice.Mul(quantity))
+	}
+	return value
+}
+
+// jsonDecimal returns the exact value of a JSON number or numeric string, or
+// zero for any other value.
+func jsonDecimal(value interface{}) decimal.Decimal {
+	number, err := decimal.NewFromString(jsonValueString(value))
+	if err != nil {
+		return decimal.Zero
+	}
+	return number
+}
+
+// newOrder creates an open order, as it appears in an order list, from a
+// place request.
+func newOrder(orderId int64, request jsonmap.JsonMap, placedTime int64) jsonmap.JsonMap {
+	orderMap, _ := request.GetMapAtPath(".Order[0]")
+	instrumentMaps, _ := orderMap.GetSliceOfMaps("Instrument")
+
+	instruments := make(jsonmap.JsonSlice, 0, len(instrumentMaps))
+	for _, instrumentMap := range instrumentMaps {
+		instruments = append(
+			instruments, jsonmap.JsonMap{
+				"Product":         instrumentMap["Product"],
+				"orderAction":     instrumentMap["orderAction"],
+				"quantityType":    instrumentMap["quantityType"],
+				"orderedQuantity": instrumentMap["quantity"],
+				"filledQuantity":  0,
+			},
+		)
+	}
+	orderDetail := jsonmap.JsonMap{
+		"placedTime":    placedTime,
+		"status":        orderStatusOpen,
+		"orderTerm":     orderMap["orderTerm"],
+		"priceType":     orderMap["priceType"],
+		"marketSession": orderMap["marketSession"],
+		"allOrNone":     orderMap["allOrNone"],
+		"Instrument":    instruments,
+	}
+	for _, key := range []string{"limitPrice", "stopPrice"} {
+		if value, found := orderMap[key]; found {
+			orderDetail[key] = value
+		}
+	}
+	return jsonmap.JsonMap{
+		"orderId":       orderId,
+		"orderType":     request["orderType"],
+		"clientOrderId": request["clientOrderId"],
+		"OrderDetail":   jsonmap.JsonSlice{orderDetail},
+	}
+}
+
+func orderHasSymbol(order jsonmap.JsonMap, symbols []string) bool {
+	instruments, _ := order.GetSliceOfMapsAtPath(".OrderDetail[0].Instrument")
+	for _, instrument := range instruments {
+		symbol, _ := instrument.GetStringAtPath(".Product.symbol")
+		for _, s := range symbols {
+			if symbol == s {
+				return true
+			}
+		}
+	}
+	return false
+}
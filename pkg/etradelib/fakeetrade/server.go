@@ -0,0 +1,225 @@
+// Package fakeetrade implements a fake ETrade API server for testing. It
+// serves the endpoints that the client uses from an in-memory State, verifies
+// OAuth 1.0a signatures as ETrade does, and applies orders, cancellations,
+// and alert deletions to the state, so the CLI and the server can be tested
+// end-to-end without ETrade's sandbox.
+package fakeetrade
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Server struct {
+	logger *slog.Logger
+	router http.Handler
+	now    func() time.Time
+
+	// mutex guards everything below.
+	mutex         sync.Mutex
+	state         *State
+	requestTokens map[string]*requestToken
+	// nonces holds the nonces of recent requests, which are rejected if
+	// they are used again.
+	nonces map[string]time.Time
+	// previews holds the orders that have been previewed, by preview ID.
+	previews map[int64]*orderPreview
+}
+
+// NewServer returns a server that serves the given state. The server modifies
+// the state as requests are made, so tests that need to examine or change it
+// while the server runs must do so with UpdateState.
+func NewServer(state *State, logger *slog.Logger) *Server {
+	server := &Server{
+		logger:        logger,
+		now:           time.Now,
+		state:         state,
+		requestTokens: map[string]*requestToken{},
+		nonces:        map[string]time.Time{},
+		previews:      map[int64]*orderPreview{},
+	}
+
+	r := chi.NewRouter()
+	r.Post("/oauth/request_token", server.getRequestToken)
+	r.Get(AuthorizePath, server.authorize)
+	r.Post("/oauth/access_token", server.getAccessToken)
+	r.Get("/oauth/renew_access_token", server.renewAccessToken)
+	r.Get("/oauth/revoke_access_token", server.revokeAccessToken)
+	r.Route(
+		"/v1", func(r chi.Router) {
+			r.Use(server.authenticated)
+			r.Get("/accounts/list", server.listAccounts)
+			r.Route(
+				"/accounts/{accountIdKey}", func(r chi.Router) {
+					r.Get("/balance", server.getAccountBalances)
+					r.Get("/portfolio", server.viewPortfolio)
+					r.Get("/portfolio/{positionId}", server.listPositionLotsDetails)
+					r.Get("/transactions", server.listTransactions)
+					r.Get("/transactions/{transactionId}", server.listTransactionDetails)
+					r.Get("/orders", server.listOrders)
+					r.Post("/orders/preview", server.previewOrder)
+					r.Post("/orders/place", server.placeOrder)
+					r.Put("/orders/cancel", server.cancelOrder)
+					r.Put("/orders/{orderId}/change/preview", server.previewChangedOrder)
+					r.Put("/orders/{orderId}/change/place", server.placeChangedOrder)
+				},
+			)
+			r.Get("/user/alerts", server.listAlerts)
+			r.Get("/user/alerts/{alertId}", server.listAlertDetails)
+			r.Delete("/user/alerts/{alertIds}", server.deleteAlerts)
+			r.Get("/market/quote/{symbols}", server.getQuotes)
+			r.Get("/market/lookup/{search}", server.lookupProduct)
+			r.Get("/market/optionchains", server.getOptionChains)
+			r.Get("/market/optionexpiredate", server.getOptionExpireDates)
+		},
+	)
+	server.router = r
+	return server
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.logger.Debug(r.Method + " " + r.URL.String())
+	s.router.ServeHTTP(w, r)
+}
+
+// UpdateState calls updateFn with the server's state while no requests are
+// being handled, so that the state can be examined or changed safely.
+func (s *Server) UpdateState(updateFn func(state *State)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	updateFn(s.state)
+}
+
+// authenticated verifies that API requests are signed with an access token
+// and holds the server's lock while they are handled.
+func (s *Server) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			if _, err := s.authenticate(r, tokenKindAccess); err != nil {
+				s.writeError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		},
+	)
+}
+
+// apiError is an error that is returned to the client in an ETrade error
+// response.
+type apiError struct {
+	statusCode int
+	code       int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newBadRequestError(message string) *apiError {
+	return &apiError{statusCode: http.StatusBadRequest, code: 100, message: message}
+}
+
+func newNotFoundError(message string) *apiError {
+	return &apiError{statusCode: http.StatusNotFound, code: 404, message: message}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	var statusCode int
+	errorMap := jsonmap.JsonMap{"message": err.Error()}
+	var problem oauthProblem
+	var responseError *apiError
+	switch {
+	case errors.As(err, &problem):
+		statusCode = http.StatusUnauthorized
+	case errors.As(err, &responseError):
+		statusCode = responseError.statusCode
+		errorMap["code"] = responseError.code
+	default:
+		statusCode = http.StatusInternalServerError
+	}
+	s.logger.Debug(fmt.Sprintf("responding with %d: %s", statusCode, err.Error()))
+	s.writeJson(w, statusCode, jsonmap.JsonMap{"Error": errorMap})
+}
+
+func (s *Server) writeJson(w http.ResponseWriter, statusCode int, response jsonmap.JsonMap) {
+	responseBytes, err := response.ToJsonBytes(false, false)
+	if err != nil {
+		s.logger.Error(err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(responseBytes)
+}
+
+// requestAccount returns the account identified by the request's accountIdKey
+// URL parameter.
+func (s *Server) requestAccount(r *http.Request) (*Account, error) {
+	accountIdKey := chi.URLParam(r, "accountIdKey")
+	account, found := s.state.account(accountIdKey)
+	if !found {
+		return nil, newNotFoundError(fmt.Sprintf("account '%s' not found", accountIdKey))
+	}
+	return account, nil
+}
+
+// queryInt returns the value of an integer query parameter, or defaultValue
+// if the parameter is absent.
+func queryInt(r *http.Request, key string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil || intValue < 0 {
+		return 0, newBadRequestError(fmt.Sprintf("invalid %s '%s'", key, value))
+	}
+	return intValue, nil
+}
+
+// page returns the range of the items to return for a paged request that
+// starts at the given index, and the index of the next page's first item, or
+// -1 if there are no more items.
+func page(itemCount int, start int, count int) (pageStart int, pageEnd int, next int) {
+	if start > itemCount {
+		start = itemCount
+	}
+	end := start + count
+	if end >= itemCount {
+		return start, itemCount, -1
+	}
+	return start, end, end
+}
+
+// jsonValueString returns a string or number as a string.
+func jsonValueString(value interface{}) string {
+	switch valueTyped := value.(type) {
+	case string:
+		return valueTyped
+	case json.Number:
+		return valueTyped.String()
+	default:
+		return fmt.Sprintf("%v", valueTyped)
+	}
+}
+
+// toJsonSlice converts a slice of maps to a JsonSlice for a response.
+func toJsonSlice(maps []jsonmap.JsonMap) jsonmap.JsonSlice {
+	slice := make(jsonmap.JsonSlice, 0, len(maps))
+	for _, m := range maps {
+		slice = append(slice, m)
+	}
+	return slice
+}
@@ -0,0 +1,373 @@
+package fakeetrade
+
+import (
+	"github.com/jerryryle/etrade-cli/pkg/etradelib"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/client/constants"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/etradelibtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestClient starts a fake server with the default state and returns it
+// with a client that is logged in with the state's access token.
+func newTestClient(t *testing.T) (*Server, client.ETradeClient) {
+	server := NewServer(DefaultState(), etradelibtest.CreateNullLogger())
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	urls, err := client.NewEndpointUrls(httpServer.URL, httpServer.URL+AuthorizePath)
+	require.Nil(t, err)
+	testClient, err := client.CreateETradeClient(
+		etradelibtest.CreateNullLogger(), urls, "fakeConsumerKey", "fakeConsumerSecret", "fakeAccessToken",
+		"fakeAccessSecret", client.RetryPolicy{}, nil, nil,
+	)
+	require.Nil(t, err)
+	return server, testClient
+}
+
+func getAuthorizationUrl(t *testing.T, response []byte) string {
+	authStatus, err := etradelib.CreateETradeAuthenticationStatusFromResponse(response)
+	require.Nil(t, err)
+	require.True(t, authStatus.NeedAuthorization())
+	return authStatus.GetAuthorizationUrl()
+}
+
+func TestServer_ListAccounts(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Method Under Test
+	response, err := testClient.ListAccounts()
+	require.Nil(t, err)
+	accountList, err := etradelib.CreateETradeAccountListFromResponse(response)
+	require.Nil(t, err)
+
+	accounts := accountList.GetAllAccounts()
+	require.Equal(t, 2, len(accounts))
+	assert.Equal(t, "84910001", accounts[0].GetId())
+	assert.Equal(t, "fakeKey1", accounts[0].GetIdKey())
+}
+
+func TestServer_GetAccountBalances(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Method Under Test
+	response, err := testClient.GetAccountBalances("fakeKey1", true)
+	require.Nil(t, err)
+	balances, err := etradelib.CreateETradeBalancesFromResponse(response)
+	require.Nil(t, err)
+	balancesMap := balances.AsJsonMap()
+	cashBalance, err := balancesMap.GetFloatAtPath(".computed.cashBalance")
+	assert.Nil(t, err)
+	assert.Equal(t, 5000.25, cashBalance)
+
+	_, err = testClient.GetAccountBalances("badKey", true)
+	apiError, ok := client.AsETradeAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, 404, apiError.StatusCode)
+}
+
+func TestServer_ViewPortfolio_Pages(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Method Under Test
+	response, err := testClient.ViewPortfolio(
+		"fakeKey1", 2, constants.PortfolioSortByNil, constants.SortOrderNil, "", constants.MarketSessionNil, true,
+		false, constants.PortfolioViewNil,
+	)
+	require.Nil(t, err)
+	positionList, err := etradelib.CreateETradePositionListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(positionList.GetAllPositions()))
+	assert.Equal(t, "2", positionList.NextPage())
+
+	response, err = testClient.ViewPortfolio(
+		"fakeKey1", 2, constants.PortfolioSortByNil, constants.SortOrderNil, positionList.NextPage(),
+		constants.MarketSessionNil, true, false, constants.PortfolioViewNil,
+	)
+	require.Nil(t, err)
+	require.Nil(t, positionList.AddPageFromResponse(response))
+	assert.Equal(t, "", positionList.NextPage())
+
+	positions := positionList.GetAllPositions()
+	require.Equal(t, 3, len(positions))
+	assert.Equal(t, int64(10003), positions[2].GetId())
+	positionListMap := positionList.AsJsonMap()
+	totalMarketValue, err := positionListMap.GetFloatAtPath(".totals.totalMarketValue")
+	assert.Nil(t, err)
+	assert.Equal(t, 30150.25, totalMarketValue)
+}
+
+func TestServer_ListPositionLotsDetails(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Method Under Test
+	response, err := testClient.ListPositionLotsDetails("fakeKey1", 10001)
+	require.Nil(t, err)
+	responseMap, err := etradelib.NewNormalizedJsonMap(response)
+	require.Nil(t, err)
+	lots, err := responseMap.GetSliceOfMapsAtPath(".positionLotsResponse.positionLot")
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(lots))
+}
+
+func TestServer_ListTransactions_Pages(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Method Under Test
+	response, err := testClient.ListTransactions("fakeKey1", nil, nil, constants.SortOrderNil, "", 2)
+	require.Nil(t, err)
+	transactionList, err := etradelib.CreateETradeTransactionListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, "2", transactionList.NextPage())
+
+	response, err = testClient.ListTransactions(
+		"fakeKey1", nil, nil, constants.SortOrderNil, transactionList.NextPage(), 2,
+	)
+	require.Nil(t, err)
+	require.Nil(t, transactionList.AddPageFromResponse(response))
+	assert.Equal(t, "", transactionList.NextPage())
+
+	// The newest transactions come first.
+	transactions := transactionList.GetAllTransactions()
+	require.Equal(t, 3, len(transactions))
+	assert.Equal(t, "23092800001", transactions[0].GetId())
+	assert.Equal(t, "23091500001", transactions[2].GetId())
+
+	response, err = testClient.ListTransactionDetails("fakeKey1", "23091500001")
+	require.Nil(t, err)
+	transactionDetails, err := etradelib.CreateETradeTransactionDetailsFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, int64(23091500001), transactionDetails.GetId())
+}
+
+func TestServer_PlaceAndCancelOrder(t *testing.T) {
+	server, testClient := newTestClient(t)
+	order := &client.OrderRequest{
+		ClientOrderId: "testorder",
+		OrderType:     constants.OrderTypeEquity,
+		PriceType:     constants.OrderPriceTypeLimit,
+		OrderTerm:     constants.OrderTermGoodForDay,
+		MarketSession: constants.MarketSessionRegular,
+		LimitPrice:    decimal.NewFromInt(170),
+		Instruments: []client.OrderInstrument{
+			{
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Symbol:       "AAPL",
+				OrderAction:  constants.OrderActionBuy,
+				Quantity:     5,
+			},
+		},
+	}
+
+	// Call the Methods Under Test
+	response, err := testClient.PreviewOrder("fakeKey1", order)
+	require.Nil(t, err)
+	orderPreview, err := etradelib.CreateETradeOrderPreviewFromResponse(response)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(orderPreview.GetPreviewIds()))
+
+	// The placed order must match the preview.
+	changedOrder := *order
+	changedOrder.LimitPrice = decimal.NewFromInt(171)
+	_, err = testClient.PlaceOrder("fakeKey1", orderPreview.GetPreviewIds()[0], &changedOrder)
+	assert.Error(t, err)
+
+	response, err = testClient.PlaceOrder("fakeKey1", orderPreview.GetPreviewIds()[0], order)
+	require.Nil(t, err)
+	orderPlaced, err := etradelib.CreateETradeOrderPlacedFromResponse(response)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(orderPlaced.GetOrderIds()))
+	orderId := orderPlaced.GetOrderIds()[0]
+
+	// The preview can only be used once.
+	_, err = testClient.PlaceOrder("fakeKey1", orderPreview.GetPreviewIds()[0], order)
+	assert.Error(t, err)
+
+	response, err = testClient.ListOrders(
+		"fakeKey1", "", -1, constants.OrderStatusOpen, nil, nil, nil, constants.OrderSecurityTypeNil,
+		constants.OrderTransactionTypeNil, constants.MarketSessionNil,
+	)
+	require.Nil(t, err)
+	orderList, err := etradelib.CreateETradeOrderListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(orderList.GetAllOrders()))
+	assert.NotNil(t, orderList.GetOrderById(orderId))
+
+	response, err = testClient.CancelOrder("fakeKey1", orderId)
+	require.Nil(t, err)
+	cancelOrder, err := etradelib.CreateETradeCancelOrderFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, orderId, cancelOrder.GetOrderId())
+
+	server.UpdateState(
+		func(state *State) {
+			account, _ := state.account("fakeKey1")
+			placedOrder, found := account.order(orderId)
+			require.True(t, found)
+			status, _ := placedOrder.GetStringAtPath(".OrderDetail[0].status")
+			assert.Equal(t, "CANCELLED", status)
+		},
+	)
+
+	// A cancelled order can't be cancelled again.
+	_, err = testClient.CancelOrder("fakeKey1", orderId)
+	assert.Error(t, err)
+}
+
+func TestServer_ChangeOrder(t *testing.T) {
+	server, testClient := newTestClient(t)
+	order := &client.OrderRequest{
+		ClientOrderId: "testorder",
+		OrderType:     constants.OrderTypeEquity,
+		PriceType:     constants.OrderPriceTypeLimit,
+		OrderTerm:     constants.OrderTermGoodForDay,
+		MarketSession: constants.MarketSessionRegular,
+		LimitPrice:    decimal.NewFromInt(125),
+		Instruments: []client.OrderInstrument{
+			{
+				SecurityType: constants.OrderSecurityTypeEquity,
+				Symbol:       "GOOG",
+				OrderAction:  constants.OrderActionBuy,
+				Quantity:     10,
+			},
+		},
+	}
+
+	// Call the Methods Under Test
+	response, err := testClient.PreviewChangedOrder("fakeKey1", 501, order)
+	require.Nil(t, err)
+	orderPreview, err := etradelib.CreateETradeOrderPreviewFromResponse(response)
+	require.Nil(t, err)
+	_, err = testClient.PlaceChangedOrder("fakeKey1", 501, orderPreview.GetPreviewIds()[0], order)
+	require.Nil(t, err)
+
+	server.UpdateState(
+		func(state *State) {
+			account, _ := state.account("fakeKey1")
+			changedOrder, found := account.order(501)
+			require.True(t, found)
+			limitPrice, _ := changedOrder.GetFloatAtPath(".OrderDetail[0].limitPrice")
+			assert.Equal(t, 125.0, limitPrice)
+		},
+	)
+
+	// Orders that are not open can't be changed.
+	_, err = testClient.PreviewChangedOrder("fakeKey1", 502, order)
+	assert.Error(t, err)
+}
+
+func TestServer_Alerts(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Methods Under Test
+	response, err := testClient.ListAlerts(
+		-1, constants.AlertCategoryNil, constants.AlertStatusNil, constants.SortOrderNil, "",
+	)
+	require.Nil(t, err)
+	alertList, err := etradelib.CreateETradeAlertListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(alertList.GetAllAlerts()))
+
+	response, err = testClient.ListAlertDetails("601", false)
+	require.Nil(t, err)
+	alertDetails, err := etradelib.CreateETradeAlertDetailsFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, int64(601), alertDetails.GetId())
+
+	response, err = testClient.DeleteAlerts([]string{"601", "999"})
+	require.Nil(t, err)
+	deleteAlerts, err := etradelib.CreateETradeDeleteAlertsFromResponse(response)
+	require.Nil(t, err)
+	assert.False(t, deleteAlerts.IsSuccess())
+	assert.Equal(t, []int64{999}, deleteAlerts.GetFailedAlerts())
+
+	response, err = testClient.ListAlerts(
+		-1, constants.AlertCategoryNil, constants.AlertStatusNil, constants.SortOrderNil, "",
+	)
+	require.Nil(t, err)
+	alertList, err = etradelib.CreateETradeAlertListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(alertList.GetAllAlerts()))
+}
+
+func TestServer_GetQuotes(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Method Under Test
+	response, err := testClient.GetQuotes(
+		[]string{"GOOG", "BAD"}, constants.QuoteDetailFlagNil, false, false,
+	)
+	require.Nil(t, err)
+	quoteList, err := etradelib.CreateETradeQuoteListFromResponse(response)
+	require.Nil(t, err)
+
+	quotes := quoteList.GetAllQuotes()
+	require.Equal(t, 1, len(quotes))
+	quoteMap := quotes[0].AsJsonMap()
+	lastTrade, err := quoteMap.GetFloatAtPath(".all.lastTrade")
+	assert.Nil(t, err)
+	assert.Equal(t, 140.0, lastTrade)
+	quoteListMap := quoteList.AsJsonMap()
+	messages, err := quoteListMap.GetSliceOfMapsAtPath(".messages")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(messages))
+}
+
+func TestServer_MarketData(t *testing.T) {
+	_, testClient := newTestClient(t)
+
+	// Call the Methods Under Test
+	response, err := testClient.LookupProduct("goog")
+	require.Nil(t, err)
+	lookupResultList, err := etradelib.CreateETradeLookupResultListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(lookupResultList.GetAllResults()))
+
+	response, err = testClient.GetOptionChains(
+		"GOOG", -1, -1, -1, -1, -1, false, false, constants.OptionCategoryNil, constants.OptionChainTypeNil,
+		constants.OptionPriceTypeNil,
+	)
+	require.Nil(t, err)
+	optionChainPairList, err := etradelib.CreateETradeOptionChainPairListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(optionChainPairList.GetAllOptionChainPairs()))
+
+	response, err = testClient.GetOptionExpireDates("GOOG", constants.OptionExpiryTypeNil)
+	require.Nil(t, err)
+	optionExpireDateList, err := etradelib.CreateETradeOptionExpireDateListFromResponse(response)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(optionExpireDateList.GetAllOptionExpireDates()))
+
+	_, err = testClient.GetOptionExpireDates("BAD", constants.OptionExpiryTypeNil)
+	assert.Error(t, err)
+}
+
+func TestLoadState(t *testing.T) {
+	state, err := LoadState(
+		strings.NewReader(
+			`{"consumers":[{"key":"k","secret":"s"}],"accounts":[{"account":{"accountIdKey":"a"},` +
+				`"orders":[{"orderId":12345678901234,"OrderDetail":[{"status":"OPEN"}]}]}],"nextId":7}`,
+		),
+	)
+	require.Nil(t, err)
+
+	secret, found := state.consumerSecret("k")
+	assert.True(t, found)
+	assert.Equal(t, "s", secret)
+	account, found := state.account("a")
+	require.True(t, found)
+	order, found := account.order(12345678901234)
+	require.True(t, found)
+	status, err := order.GetStringAtPath(".OrderDetail[0].status")
+	assert.Nil(t, err)
+	assert.Equal(t, "OPEN", status)
+	assert.Equal(t, int64(7), state.nextId())
+	assert.Equal(t, int64(8), state.nextId())
+
+	_, err = LoadState(strings.NewReader(`{"accounts":`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,261 @@
+package fakeetrade
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"io"
+	"os"
+)
+
+// State is the data that the fake server serves. Objects are stored in the
+// form that ETrade returns them (e.g. an account is an ETrade "Account"
+// object), so fixtures can be written from ETrade's API documentation or from
+// recorded responses.
+type State struct {
+	// Consumers are the consumer keys and secrets that the server accepts.
+	Consumers []Consumer `json:"consumers"`
+	// AccessTokens are the access tokens that the server accepts. Tokens
+	// issued by the server are added here, so fixtures can include tokens
+	// that let tests skip logging in.
+	AccessTokens []AccessToken `json:"accessTokens"`
+	Accounts     []*Account    `json:"accounts"`
+	Alerts       []*Alert      `json:"alerts"`
+	// Quotes are ETrade "QuoteData" objects, looked up by their
+	// Product.symbol.
+	Quotes []jsonmap.JsonMap `json:"quotes"`
+	// Products are ETrade "Data" objects returned by product lookups.
+	Products []jsonmap.JsonMap `json:"products"`
+	// OptionChains holds an ETrade "OptionChainResponse" object for each
+	// symbol.
+	OptionChains map[string]jsonmap.JsonMap `json:"optionChains"`
+	// OptionExpireDates holds the ETrade "ExpirationDate" objects for each
+	// symbol.
+	OptionExpireDates map[string][]jsonmap.JsonMap `json:"optionExpireDates"`
+	// NextId is the next ID to assign to an order preview or a placed order.
+	NextId int64 `json:"nextId"`
+}
+
+type Consumer struct {
+	Key    string `json:"key"`
+	Secret string `json:"secret"`
+}
+
+type AccessToken struct {
+	ConsumerKey string `json:"consumerKey"`
+	Token       string `json:"token"`
+	Secret      string `json:"secret"`
+}
+
+type Account struct {
+	// Account is the ETrade "Account" object. Its accountIdKey identifies
+	// the account in requests.
+	Account jsonmap.JsonMap `json:"account"`
+	// Balance is the ETrade "BalanceResponse" object.
+	Balance jsonmap.JsonMap `json:"balance"`
+	// PortfolioTotals is the ETrade "PortfolioTotals" object.
+	PortfolioTotals jsonmap.JsonMap `json:"portfolioTotals"`
+	Positions       []*Position     `json:"positions"`
+	Transactions    []*Transaction  `json:"transactions"`
+	// Orders are ETrade "Order" objects.
+	Orders []jsonmap.JsonMap `json:"orders"`
+}
+
+type Position struct {
+	// Position is the ETrade "Position" object, identified by its positionId.
+	Position jsonmap.JsonMap `json:"position"`
+	// Lots are ETrade "PositionLot" objects.
+	Lots []jsonmap.JsonMap `json:"lots"`
+}
+
+type Transaction struct {
+	// Transaction is the ETrade "Transaction" object returned in transaction
+	// lists, identified by its transactionId.
+	Transaction jsonmap.JsonMap `json:"transaction"`
+	// Details is the ETrade "TransactionDetailsResponse" object. If it is
+	// not provided, the transaction is returned instead.
+	Details jsonmap.JsonMap `json:"details"`
+}
+
+type Alert struct {
+	// Alert is the ETrade "Alert" object returned in alert lists,
+	// identified by its id.
+	Alert jsonmap.JsonMap `json:"alert"`
+	// Details is the ETrade "AlertDetailsResponse" object. If it is not
+	// provided, the alert is returned instead.
+	Details jsonmap.JsonMap `json:"details"`
+}
+
+//go:embed fixtures/default.json
+var defaultFixture []byte
+
+// DefaultState returns the state seeded from the fixture bundled with the
+// package, which has a brokerage account with positions, transactions, and
+// orders, some alerts, and market data for a few symbols.
+func DefaultState() *State {
+	state, err := LoadState(bytes.NewReader(defaultFixture))
+	if err != nil {
+		panic(err)
+	}
+	return state
+}
+
+// LoadState reads a state from a JSON fixture.
+func LoadState(reader io.Reader) (*State, error) {
+	decoder := json.NewDecoder(reader)
+	// Decode numbers using the json.Number type so that IDs and amounts are
+	// returned exactly as they appear in the fixture.
+	decoder.UseNumber()
+	state := &State{}
+	if err := decoder.Decode(state); err != nil {
+		return nil, err
+	}
+	state.normalize()
+	return state, nil
+}
+
+// LoadStateFromFile reads a state from a JSON fixture file.
+func LoadStateFromFile(filename string) (*State, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return LoadState(file)
+}
+
+// normalize converts the maps and slices nested in the state's objects to
+// JsonMaps and JsonSlices so that they can be read with the jsonmap getters.
+func (s *State) normalize() {
+	for _, account := range s.Accounts {
+		account.Account = normalizeMap(account.Account)
+		account.Balance = normalizeMap(account.Balance)
+		account.PortfolioTotals = normalizeMap(account.PortfolioTotals)
+		for _, position := range account.Positions {
+			position.Position = normalizeMap(position.Position)
+			normalizeMaps(position.Lots)
+		}
+		for _, transaction := range account.Transactions {
+			transaction.Transaction = normalizeMap(transaction.Transaction)
+			transaction.Details = normalizeMap(transaction.Details)
+		}
+		normalizeMaps(account.Orders)
+	}
+	for _, alert := range s.Alerts {
+		alert.Alert = normalizeMap(alert.Alert)
+		alert.Details = normalizeMap(alert.Details)
+	}
+	normalizeMaps(s.Quotes)
+	normalizeMaps(s.Products)
+	for symbol, optionChain := range s.OptionChains {
+		s.OptionChains[symbol] = normalizeMap(optionChain)
+	}
+	for _, expireDates := range s.OptionExpireDates {
+		normalizeMaps(expireDates)
+	}
+}
+
+func normalizeMap(m jsonmap.JsonMap) jsonmap.JsonMap {
+	if m == nil {
+		return nil
+	}
+	return m.Map(nil, nil)
+}
+
+func normalizeMaps(maps []jsonmap.JsonMap) {
+	for i := range maps {
+		maps[i] = normalizeMap(maps[i])
+	}
+}
+
+func (s *State) consumerSecret(consumerKey string) (string, bool) {
+	for _, consumer := range s.Consumers {
+		if consumer.Key == consumerKey {
+			return consumer.Secret, true
+		}
+	}
+	return "", false
+}
+
+func (s *State) accessToken(token string) (AccessToken, bool) {
+	for _, accessToken := range s.AccessTokens {
+		if accessToken.Token == token {
+			return accessToken, true
+		}
+	}
+	return AccessToken{}, false
+}
+
+func (s *State) removeAccessToken(token string) {
+	accessTokens := make([]AccessToken, 0, len(s.AccessTokens))
+	for _, accessToken := range s.AccessTokens {
+		if accessToken.Token != token {
+			accessTokens = append(accessTokens, accessToken)
+		}
+	}
+	s.AccessTokens = accessTokens
+}
+
+func (s *State) account(accountIdKey string) (*Account, bool) {
+	for _, account := range s.Accounts {
+		if key, _ := account.Account.GetString("accountIdKey"); key == accountIdKey {
+			return account, true
+		}
+	}
+	return nil, false
+}
+
+func (s *State) alert(alertId int64) (*Alert, int, bool) {
+	for i, alert := range s.Alerts {
+		if id, _ := alert.Alert.GetInt("id"); id == alertId {
+			return alert, i, true
+		}
+	}
+	return nil, 0, false
+}
+
+func (s *State) quote(symbol string) (jsonmap.JsonMap, bool) {
+	for _, quote := range s.Quotes {
+		if quoteSymbol, _ := quote.GetStringAtPath(".Product.symbol"); quoteSymbol == symbol {
+			return quote, true
+		}
+	}
+	return nil, false
+}
+
+func (s *State) nextId() int64 {
+	if s.NextId <= 0 {
+		s.NextId = 1
+	}
+	id := s.NextId
+	s.NextId++
+	return id
+}
+
+func (a *Account) position(positionId int64) (*Position, bool) {
+	for _, position := range a.Positions {
+		if id, _ := position.Position.GetInt("positionId"); id == positionId {
+			return position, true
+		}
+	}
+	return nil, false
+}
+
+func (a *Account) transaction(transactionId string) (*Transaction, bool) {
+	for _, transaction := range a.Transactions {
+		if id, _ := transaction.Transaction.GetValue("transactionId"); jsonValueString(id) == transactionId {
+			return transaction, true
+		}
+	}
+	return nil, false
+}
+
+func (a *Account) order(orderId int64) (jsonmap.JsonMap, bool) {
+	for _, order := range a.Orders {
+		if id, _ := order.GetInt("orderId"); id == orderId {
+			return order, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,131 @@
+package etradelib
+
+import (
+	"context"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+)
+
+// ErrIteratorDone is returned by an iterator's Next method once every item has
+// been returned.
+var ErrIteratorDone = errors.New("no more items in iterator")
+
+// Iterator returns the items of a list one at a time.
+type Iterator[T any] interface {
+	// Next returns the next item, or ErrIteratorDone if there are no more
+	// items. Once Next returns an error, it returns the same error on every
+	// later call.
+	Next(ctx context.Context) (T, error)
+}
+
+// PageFetcher fetches one page of a paginated list. An empty marker fetches
+// the first page. It returns the items on the page and the marker for the next
+// page, which is empty if this is the last page.
+type PageFetcher[T any] func(ctx context.Context, marker string) (items []T, nextMarker string, err error)
+
+type pageIterator[T any] struct {
+	fetch      PageFetcher[T]
+	maxItems   int
+	page       []T
+	nextMarker string
+	fetched    bool
+	returned   int
+	err        error
+}
+
+// NewPageIterator creates an iterator over a paginated list. Pages are
+// fetched only as they are needed, so a caller that stops calling Next early
+// doesn't fetch the rest of the list. If maxItems is greater than zero, the
+// iterator stops after returning that many items.
+func NewPageIterator[T any](fetch PageFetcher[T], maxItems int) Iterator[T] {
+	return &pageIterator[T]{
+		fetch:    fetch,
+		maxItems: maxItems,
+	}
+}
+
+func (i *pageIterator[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	if i.err != nil {
+		return zero, i.err
+	}
+	if i.maxItems > 0 && i.returned >= i.maxItems {
+		i.err = ErrIteratorDone
+		return zero, i.err
+	}
+	// Loop, rather than fetching once, in case ETrade returns an empty page
+	// that isn't the last.
+	for len(i.page) == 0 {
+		if i.fetched && i.nextMarker == "" {
+			i.err = ErrIteratorDone
+			return zero, i.err
+		}
+		if err := ctx.Err(); err != nil {
+			i.err = err
+			return zero, i.err
+		}
+		page, nextMarker, err := i.fetch(ctx, i.nextMarker)
+		if err != nil {
+			i.err = err
+			return zero, i.err
+		}
+		i.page, i.nextMarker, i.fetched = page, nextMarker, true
+	}
+	item := i.page[0]
+	i.page = i.page[1:]
+	i.returned++
+	return item, nil
+}
+
+type mapIterator[T any, U any] struct {
+	iterator Iterator[T]
+	mapFn    func(T) U
+}
+
+// NewMapIterator creates an iterator that returns the result of calling mapFn
+// on each item of another iterator.
+func NewMapIterator[T any, U any](iterator Iterator[T], mapFn func(T) U) Iterator[U] {
+	return &mapIterator[T, U]{
+		iterator: iterator,
+		mapFn:    mapFn,
+	}
+}
+
+func (i *mapIterator[T, U]) Next(ctx context.Context) (U, error) {
+	item, err := i.iterator.Next(ctx)
+	if err != nil {
+		var zero U
+		return zero, err
+	}
+	return i.mapFn(item), nil
+}
+
+// NewJsonMapIterator creates an iterator that returns the JsonMap of each item
+// of another iterator.
+func NewJsonMapIterator[T interface{ AsJsonMap() jsonmap.JsonMap }](iterator Iterator[T]) Iterator[jsonmap.JsonMap] {
+	return NewMapIterator(iterator, func(item T) jsonmap.JsonMap { return item.AsJsonMap() })
+}
+
+// CollectIterator reads every remaining item from an iterator.
+func CollectIterator[T any](ctx context.Context, iterator Iterator[T]) ([]T, error) {
+	items := make([]T, 0)
+	for {
+		item, err := iterator.Next(ctx)
+		if errors.Is(err, ErrIteratorDone) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+// pageSize returns the number of items to request per page: the API's maximum,
+// or fewer if the caller wants fewer items than that in total.
+func pageSize(maxCount int, maxItems int) int {
+	if maxItems > 0 && maxItems < maxCount {
+		return maxItems
+	}
+	return maxCount
+}
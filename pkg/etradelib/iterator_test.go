@@ -0,0 +1,153 @@
+package etradelib
+
+import (
+	"context"
+	"errors"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testPages is a paginated list in which each page's marker is its index.
+type testPages struct {
+	pages   [][]int
+	fetched []string
+}
+
+func (p *testPages) fetch(_ context.Context, marker string) ([]int, string, error) {
+	p.fetched = append(p.fetched, marker)
+	index := 0
+	if marker != "" {
+		index = int(marker[0] - '0')
+	}
+	nextMarker := ""
+	if index+1 < len(p.pages) {
+		nextMarker = string(rune('0' + index + 1))
+	}
+	return p.pages[index], nextMarker, nil
+}
+
+func TestPageIterator(t *testing.T) {
+	tests := []struct {
+		name          string
+		pages         [][]int
+		maxItems      int
+		readCount     int
+		expectItems   []int
+		expectFetched []string
+	}{
+		{
+			name:          "Reads Every Page",
+			pages:         [][]int{{1, 2}, {3}, {4, 5}},
+			readCount:     10,
+			expectItems:   []int{1, 2, 3, 4, 5},
+			expectFetched: []string{"", "1", "2"},
+		},
+		{
+			name:          "Fetches Pages Only When Needed",
+			pages:         [][]int{{1, 2}, {3}, {4, 5}},
+			readCount:     2,
+			expectItems:   []int{1, 2},
+			expectFetched: []string{""},
+		},
+		{
+			name:          "Stops At Max Items",
+			pages:         [][]int{{1, 2}, {3}, {4, 5}},
+			maxItems:      3,
+			readCount:     10,
+			expectItems:   []int{1, 2, 3},
+			expectFetched: []string{"", "1"},
+		},
+		{
+			name:          "Skips Empty Pages",
+			pages:         [][]int{{}, {1}, {}},
+			readCount:     10,
+			expectItems:   []int{1},
+			expectFetched: []string{"", "1", "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				pages := &testPages{pages: tt.pages}
+				iterator := NewPageIterator(pages.fetch, tt.maxItems)
+
+				// Call the Method Under Test
+				actualItems := make([]int, 0)
+				for i := 0; i < tt.readCount; i++ {
+					item, err := iterator.Next(context.Background())
+					if errors.Is(err, ErrIteratorDone) {
+						break
+					}
+					assert.Nil(t, err)
+					actualItems = append(actualItems, item)
+				}
+
+				assert.Equal(t, tt.expectItems, actualItems)
+				assert.Equal(t, tt.expectFetched, pages.fetched)
+			},
+		)
+	}
+}
+
+func TestPageIterator_KeepsReturningError(t *testing.T) {
+	fetchCount := 0
+	iterator := NewPageIterator(
+		func(ctx context.Context, marker string) ([]int, string, error) {
+			fetchCount++
+			return nil, "", errors.New("test error")
+		}, 0,
+	)
+
+	// Call the Method Under Test
+	_, err1 := iterator.Next(context.Background())
+	_, err2 := iterator.Next(context.Background())
+
+	assert.EqualError(t, err1, "test error")
+	assert.EqualError(t, err2, "test error")
+	assert.Equal(t, 1, fetchCount)
+}
+
+func TestPageIterator_StopsWhenContextIsCanceled(t *testing.T) {
+	pages := &testPages{pages: [][]int{{1}, {2}}}
+	iterator := NewPageIterator(pages.fetch, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	item, err := iterator.Next(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+
+	// Call the Method Under Test
+	cancel()
+	_, err = iterator.Next(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{""}, pages.fetched)
+}
+
+func TestCollectIterator(t *testing.T) {
+	pages := &testPages{pages: [][]int{{1, 2}, {3}}}
+
+	// Call the Method Under Test
+	actualItems, err := CollectIterator(context.Background(), NewPageIterator(pages.fetch, 0))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, actualItems)
+}
+
+func TestNewJsonMapIterator(t *testing.T) {
+	iterator := NewPageIterator(
+		func(ctx context.Context, marker string) ([]ETradeTransaction, string, error) {
+			return []ETradeTransaction{
+				&eTradeTransaction{id: "1234", jsonMap: jsonmap.JsonMap{"transactionId": "1234"}},
+			}, "", nil
+		}, 0,
+	)
+
+	// Call the Method Under Test
+	actualItems, err := CollectIterator(context.Background(), NewJsonMapIterator(iterator))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []jsonmap.JsonMap{{"transactionId": "1234"}}, actualItems)
+}
@@ -0,0 +1,158 @@
+package etradelib
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"strconv"
+	"time"
+)
+
+// typedReader reads the values of a JsonMap into the fields of a typed model.
+// ETrade omits many values and is inconsistent about whether it sends IDs
+// and amounts as numbers or strings, so a value that is missing is read as
+// the zero value for its type and a number or string is accepted wherever
+// either is sensible. The first value that is present but can't be converted
+// is kept in err, so that a model can be filled in with a series of reads and
+// checked once.
+type typedReader struct {
+	jsonMap jsonmap.JsonMap
+	err     error
+}
+
+func newTypedReader(jsonMap jsonmap.JsonMap) *typedReader {
+	return &typedReader{jsonMap: jsonMap}
+}
+
+// value returns the value at a path, or nil if there is no value.
+func (r *typedReader) value(path string) interface{} {
+	return r.jsonMap.GetValueAtPathWithDefault(path, nil)
+}
+
+func (r *typedReader) fail(path string, err error) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%s: %w", path, err)
+	}
+}
+
+func (r *typedReader) string(path string) string {
+	switch value := r.value(path).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		r.fail(path, fmt.Errorf("type %T is not a string", value))
+		return ""
+	}
+}
+
+func (r *typedReader) int(path string) int64 {
+	switch value := r.value(path).(type) {
+	case nil:
+		return 0
+	case json.Number:
+		intValue, err := value.Int64()
+		if err != nil {
+			r.fail(path, err)
+		}
+		return intValue
+	case string:
+		if value == "" {
+			return 0
+		}
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			r.fail(path, err)
+		}
+		return intValue
+	default:
+		r.fail(path, fmt.Errorf("type %T is not an int", value))
+		return 0
+	}
+}
+
+// number reads a decimal value exactly as ETrade sent it.
+func (r *typedReader) number(path string) decimal.Decimal {
+	var text string
+	switch value := r.value(path).(type) {
+	case nil:
+		return decimal.Zero
+	case json.Number:
+		text = value.String()
+	case string:
+		if value == "" {
+			return decimal.Zero
+		}
+		text = value
+	default:
+		r.fail(path, fmt.Errorf("type %T is not a number", value))
+		return decimal.Zero
+	}
+	number, err := decimal.NewFromString(text)
+	if err != nil {
+		r.fail(path, err)
+	}
+	return number
+}
+
+func (r *typedReader) bool(path string) bool {
+	switch value := r.value(path).(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		// ETrade sends some flags as "true"/"false" and others as "Y"/"N".
+		switch value {
+		case "", "false", "FALSE", "N", "n":
+			return false
+		case "true", "TRUE", "Y", "y":
+			return true
+		}
+		r.fail(path, fmt.Errorf("'%s' is not a bool", value))
+		return false
+	default:
+		r.fail(path, fmt.Errorf("type %T is not a bool", value))
+		return false
+	}
+}
+
+// millis reads a time sent as milliseconds since the Unix epoch. A missing
+// or zero value is read as the zero time.
+func (r *typedReader) millis(path string) time.Time {
+	if millis := r.int(path); millis != 0 {
+		return time.UnixMilli(millis)
+	}
+	return time.Time{}
+}
+
+// seconds reads a time sent as seconds since the Unix epoch. A missing or
+// zero value is read as the zero time.
+func (r *typedReader) seconds(path string) time.Time {
+	if seconds := r.int(path); seconds != 0 {
+		return time.Unix(seconds, 0)
+	}
+	return time.Time{}
+}
+
+func (r *typedReader) maps(path string) []jsonmap.JsonMap {
+	maps, err := r.jsonMap.GetSliceOfMapsAtPathWithDefault(path, nil)
+	if err != nil {
+		r.fail(path, err)
+	}
+	return maps
+}
+
+// readEnum reads a value with one of the enum types from the constants
+// package. ETrade adds values from time to time, so a value that the enum
+// doesn't know is read as the enum's nil value rather than as an error.
+func readEnum[T any](r *typedReader, path string, fromString func(string) (T, error)) T {
+	enum, _ := fromString(r.string(path))
+	return enum
+}
@@ -0,0 +1,113 @@
+package etradelib
+
+import (
+	"encoding/json"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/decimal"
+	"github.com/jerryryle/etrade-cli/pkg/etradelib/jsonmap"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTypedReader(t *testing.T) {
+	type testFn func(r *typedReader) interface{}
+	tests := []struct {
+		name        string
+		testFn      testFn
+		expectErr   bool
+		expectValue interface{}
+	}{
+		{
+			name:        "Reads String",
+			testFn:      func(r *typedReader) interface{} { return r.string(".string") },
+			expectValue: "value",
+		},
+		{
+			name:        "Reads Number As String",
+			testFn:      func(r *typedReader) interface{} { return r.string(".number") },
+			expectValue: "123.45",
+		},
+		{
+			name:        "Reads Missing String As Empty",
+			testFn:      func(r *typedReader) interface{} { return r.string(".missing") },
+			expectValue: "",
+		},
+		{
+			name:        "Reads Int",
+			testFn:      func(r *typedReader) interface{} { return r.int(".int") },
+			expectValue: int64(1234),
+		},
+		{
+			name:        "Reads Numeric String As Int",
+			testFn:      func(r *typedReader) interface{} { return r.int(".intString") },
+			expectValue: int64(1234),
+		},
+		{
+			name:      "Fails To Read Decimal As Int",
+			testFn:    func(r *typedReader) interface{} { return r.int(".number") },
+			expectErr: true,
+		},
+		{
+			name:        "Reads Number Exactly",
+			testFn:      func(r *typedReader) interface{} { return r.number(".number") },
+			expectValue: decimal.MustNewFromString("123.45"),
+		},
+		{
+			name:        "Reads Numeric String As Number",
+			testFn:      func(r *typedReader) interface{} { return r.number(".numberString") },
+			expectValue: decimal.MustNewFromString("0.10"),
+		},
+		{
+			name:      "Fails To Read Non-Numeric String As Number",
+			testFn:    func(r *typedReader) interface{} { return r.number(".string") },
+			expectErr: true,
+		},
+		{
+			name:        "Reads Missing Number As Zero",
+			testFn:      func(r *typedReader) interface{} { return r.number(".missing") },
+			expectValue: decimal.Zero,
+		},
+		{
+			name:        "Reads Y As True",
+			testFn:      func(r *typedReader) interface{} { return r.bool(".yesFlag") },
+			expectValue: true,
+		},
+		{
+			name:        "Reads Milliseconds As Time",
+			testFn:      func(r *typedReader) interface{} { return r.millis(".millis") },
+			expectValue: time.UnixMilli(1695758400123),
+		},
+		{
+			name:        "Reads Missing Time As Zero",
+			testFn:      func(r *typedReader) interface{} { return r.seconds(".missing") },
+			expectValue: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				r := newTypedReader(
+					jsonmap.JsonMap{
+						"string":       "value",
+						"number":       json.Number("123.45"),
+						"numberString": "0.10",
+						"int":          json.Number("1234"),
+						"intString":    "1234",
+						"yesFlag":      "Y",
+						"millis":       json.Number("1695758400123"),
+					},
+				)
+
+				// Call the Method Under Test
+				actualValue := tt.testFn(r)
+				if tt.expectErr {
+					assert.Error(t, r.err)
+				} else {
+					assert.Nil(t, r.err)
+					assert.Equal(t, tt.expectValue, actualValue)
+				}
+			},
+		)
+	}
+}